@@ -12,6 +12,7 @@ type Config struct {
 	FilterNetworkList []string `yaml:"filterNetworkList"`
 	MQ                MQ       `yaml:"mq"`
 	DB                DB       `yaml:"db"`
+	Emulator          Emulator `yaml:"emulator"`
 }
 
 // 日志配置
@@ -41,6 +42,17 @@ type MQ struct {
 	ClientCertificate    string `yaml:"clientCertificate"`
 	ClientKey            string `yaml:"clientKey"`
 	CaCertificate        string `yaml:"caCertificate"`
+	MaxRetry             int    `yaml:"maxRetry"`
+	RetryDelay           int    `yaml:"retryDelay"`
+	Bus                  string `yaml:"bus"`
+}
+
+// BusType 返回消息总线类型（rabbitmq、grpc、memory），未配置时默认使用RabbitMQ
+func (m MQ) BusType() string {
+	if m.Bus == "" {
+		return "rabbitmq"
+	}
+	return m.Bus
 }
 
 // 获取rabbitMQ连接地址
@@ -69,3 +81,11 @@ type DB struct {
 	MaxOpenConns    int    `yaml:"maxOpenConns"`
 	ConnMaxLifetime int    `yaml:"connMaxLifetime"`
 }
+
+// 内置模拟器配置
+type Emulator struct {
+	HostName    string `yaml:"hostName"`    // 模拟shell中显示的主机名
+	SshHostKey  string `yaml:"sshHostKey"`  // SSH模拟器的主机私钥文件，不存在时自动生成
+	IdleTimeout int    `yaml:"idleTimeout"` // 会话空闲超时（秒）
+	MaxDuration int    `yaml:"maxDuration"` // 单个会话的最长持续时间（秒）
+}
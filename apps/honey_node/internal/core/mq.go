@@ -6,6 +6,7 @@ package core
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"honey_node/internal/global"
 	"os"
 
@@ -13,8 +14,18 @@ import (
 	"github.com/streadway/amqp"
 )
 
-// InitMQ 初始化RabbitMQ连接并返回消息通道
+// InitMQ 初始化RabbitMQ连接并返回消息通道，连接失败时终止程序
 func InitMQ() *amqp.Channel {
+	_, ch, err := ConnectMQ()
+	if err != nil {
+		logrus.Fatalf("%s", err)
+	}
+	return ch // 返回创建好的消息通道
+}
+
+// ConnectMQ 建立RabbitMQ连接并打开消息通道，供启动初始化及断线重连使用
+// 每次调用都会建立新连接，调用方不再使用时需关闭返回的连接
+func ConnectMQ() (*amqp.Connection, *amqp.Channel, error) {
 	cfg := global.Config.MQ // 获取全局配置中的MQ配置项
 	var conn *amqp.Connection
 	var err error
@@ -24,13 +35,13 @@ func InitMQ() *amqp.Channel {
 		// 1. 加载客户端证书和私钥（用于双向认证，服务端验证客户端身份）
 		cert, err := tls.LoadX509KeyPair(cfg.ClientCertificate, cfg.ClientKey)
 		if err != nil {
-			logrus.Fatalf("加载客户端证书失败: %v", err)
+			return nil, nil, fmt.Errorf("加载客户端证书失败: %v", err)
 		}
 
 		// 2. 加载CA根证书（用于验证服务端证书的合法性）
 		caCert, err := os.ReadFile(cfg.CaCertificate)
 		if err != nil {
-			logrus.Fatalf("读取CA证书失败: %v", err)
+			return nil, nil, fmt.Errorf("读取CA证书失败: %v", err)
 		}
 		caCertPool := x509.NewCertPool()      // 创建CA证书池
 		caCertPool.AppendCertsFromPEM(caCert) // 将CA证书添加到信任池
@@ -45,23 +56,24 @@ func InitMQ() *amqp.Channel {
 		// 通过TLS加密方式连接RabbitMQ
 		conn, err = amqp.DialTLS(cfg.Addr(), tlsConfig)
 		if err != nil {
-			logrus.Fatalf("无法连接到 RabbitMQ: %v", err)
+			return nil, nil, fmt.Errorf("无法连接到 RabbitMQ: %v", err)
 		}
 	} else {
 		// 使用非加密方式连接RabbitMQ
 		conn, err = amqp.Dial(cfg.Addr())
 	}
 
-	// 连接失败则终止程序并记录日志
+	// 连接失败则返回错误
 	if err != nil {
-		logrus.Fatalf("无法连接到 RabbitMQ: %v", err)
+		return nil, nil, fmt.Errorf("无法连接到 RabbitMQ: %v", err)
 	}
 
 	// 创建RabbitMQ消息通道（Channel）
 	ch, err := conn.Channel()
 	if err != nil {
-		logrus.Fatalf("无法打开通道: %v", err)
+		conn.Close()
+		return nil, nil, fmt.Errorf("无法打开通道: %v", err)
 	}
 
-	return ch // 返回创建好的消息通道
+	return conn, ch, nil
 }
@@ -15,6 +15,7 @@ func Migrate() {
 	err := global.DB.AutoMigrate(
 		&models.PortModel{},
 		&models.IpModel{},
+		&models.ProcessedMessageModel{},
 	)
 	if err != nil {
 		logrus.Fatalf("表结构迁移失败 %s", err)
@@ -5,6 +5,8 @@ package models
 
 type PortModel struct {
 	Model
-	LocalAddr  string `gorm:"size:64" json:"localAddr"`  // 本地地址
-	TargetAddr string `gorm:"size:64" json:"targetAddr"` // 目标地址
+	LocalAddr      string `gorm:"size:64" json:"localAddr"`        // 本地地址
+	TargetAddr     string `gorm:"size:64" json:"targetAddr"`       // 目标地址
+	Emulator       string `gorm:"size:16" json:"emulator"`         // 内置模拟器协议，不为空时直接模拟该协议，不建立隧道
+	EmulatorConfig string `gorm:"type:text" json:"emulatorConfig"` // 内置模拟器的设备配置（JSON）
 }
@@ -0,0 +1,11 @@
+package models
+
+// File: models/processed_message_model.go
+// Description: 已处理消息台账模型，按消息ID记录已成功处理的消息，用于消息重复投递时的幂等判断
+
+// ProcessedMessageModel 已处理消息台账
+type ProcessedMessageModel struct {
+	Model
+	MessageID string `gorm:"size:64;uniqueIndex" json:"messageID"` // 消息ID（由服务端发件箱生成）
+	Exchange  string `gorm:"size:64" json:"exchange"`              // 消息来源交换器
+}
@@ -0,0 +1,37 @@
+package command
+
+// File: service/command/command_bus_message.go
+// Description: 节点客户端中处理gRPC消息总线投递的业务消息，交由消息消费服务处理并返回处理结果
+
+import (
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/service/mq_service"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CmdBusMessage 处理业务消息投递命令
+// 处理成功返回code 0，失败返回code 1及错误信息，由服务端发件箱负责重试
+func (nc *NodeClient) CmdBusMessage(request *node_rpc.CmdRequest) {
+	response := &node_rpc.CmdResponse{
+		CmdType: node_rpc.CmdType_cmdBusMessageType,
+		TaskID:  request.TaskID,
+		NodeID:  nc.config.System.Uid,
+	}
+
+	req := request.GetBusInMessage()
+	if req == nil {
+		response.Code = 1
+		response.ErrorMsg = "缺少消息内容"
+	} else if err := mq_service.HandleMessage(req.Topic, req.MessageID, req.Body); err != nil {
+		logrus.Errorf("业务消息处理失败 %s %s", req.MessageID, err)
+		response.Code = 1
+		response.ErrorMsg = err.Error()
+	}
+
+	select {
+	case nc.cmdResponseChan <- response:
+	case <-nc.ctx.Done():
+		logrus.Warn("上下文已取消，丢弃响应")
+	}
+}
@@ -0,0 +1,163 @@
+package command
+
+// File: service/command/command_diagnose.go
+// Description: 节点客户端中处理远程诊断命令的逻辑实现，只执行白名单内的诊断项，并将输出按批次流式返回给服务器
+
+import (
+	"context"
+	"fmt"
+	"honey_node/internal/rpc/node_rpc"
+	"honey_node/internal/utils/cmd"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/j-keck/arping"
+	"github.com/sirupsen/logrus"
+)
+
+// diagnoseTimeout 单次诊断的最长执行时间
+const diagnoseTimeout = 15 * time.Second
+
+// diagnoseBatchLines 每批返回的输出行数
+const diagnoseBatchLines = 20
+
+// networkNameRegexp 网卡名称校验规则（与内核IFNAMSIZ限制保持一致）
+var networkNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.:@-]{1,15}$`)
+
+// diagnoseCommandMap 系统命令类诊断项白名单：诊断类型 → 固定的命令及参数
+// 网卡名称参数会在校验通过后追加到参数末尾，除此之外不接受任何外部输入
+var diagnoseCommandMap = map[node_rpc.DiagnoseType]struct {
+	name       string   // 命令名称
+	args       []string // 固定参数
+	networkArg []string // 指定网卡时追加的参数（网卡名称追加在其后）
+}{
+	node_rpc.DiagnoseType_diagnoseLinkListType:   {name: "ip", args: []string{"-d", "link", "show"}, networkArg: []string{"dev"}},
+	node_rpc.DiagnoseType_diagnoseAddrListType:   {name: "ip", args: []string{"addr", "show"}, networkArg: []string{"dev"}},
+	node_rpc.DiagnoseType_diagnoseListenListType: {name: "ss", args: []string{"-lntup"}},
+	node_rpc.DiagnoseType_diagnoseNeighListType:  {name: "ip", args: []string{"neigh", "show"}, networkArg: []string{"dev"}},
+	node_rpc.DiagnoseType_diagnoseRouteListType:  {name: "ip", args: []string{"route", "show"}, networkArg: []string{"dev"}},
+}
+
+// CmdDiagnose 处理远程诊断命令
+// 根据诊断类型执行白名单内的诊断项，输出按行分批通过命令响应通道返回，最后发送结束标识
+func (nc *NodeClient) CmdDiagnose(request *node_rpc.CmdRequest) {
+	req := request.GetDiagnoseInMessage()
+	if req == nil {
+		nc.sendDiagnose(request, &node_rpc.DiagnoseOutMessage{End: true, ErrMsg: "缺少诊断参数"})
+		return
+	}
+	logrus.Infof("远程诊断 %v", req)
+
+	// 校验网卡名称（可选参数）
+	if req.Network != "" && !networkNameRegexp.MatchString(req.Network) {
+		nc.sendDiagnose(request, &node_rpc.DiagnoseOutMessage{End: true, ErrMsg: fmt.Sprintf("无效的网卡名称 %s", req.Network)})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(nc.ctx, diagnoseTimeout)
+	defer cancel()
+
+	// 按行收集输出，满一批即发送
+	var lines []string
+	onLine := func(line string) {
+		lines = append(lines, line)
+		if len(lines) >= diagnoseBatchLines {
+			nc.sendDiagnose(request, &node_rpc.DiagnoseOutMessage{Output: strings.Join(lines, "\n")})
+			lines = nil
+		}
+	}
+
+	var err error
+	switch req.DiagnoseType {
+	case node_rpc.DiagnoseType_diagnoseArpingType:
+		err = diagnoseArping(ctx, req, onLine)
+	case node_rpc.DiagnoseType_diagnoseTcpConnectType:
+		err = diagnoseTcpConnect(ctx, req, onLine)
+	default:
+		command, ok := diagnoseCommandMap[req.DiagnoseType]
+		if !ok {
+			err = fmt.Errorf("不支持的诊断类型 %v", req.DiagnoseType)
+			break
+		}
+		args := append([]string{}, command.args...)
+		if req.Network != "" && len(command.networkArg) > 0 {
+			args = append(append(args, command.networkArg...), req.Network)
+		}
+		err = cmd.StreamCommand(ctx, onLine, command.name, args...)
+	}
+
+	// 发送剩余输出及结束标识
+	out := &node_rpc.DiagnoseOutMessage{End: true, Output: strings.Join(lines, "\n")}
+	if err != nil {
+		logrus.Errorf("远程诊断失败 %v: %v", req.DiagnoseType, err)
+		out.ErrMsg = err.Error()
+	}
+	nc.sendDiagnose(request, out)
+}
+
+// diagnoseArping 对目标IP执行arping探测，用于排查"ip已存在"等冲突问题
+func diagnoseArping(ctx context.Context, req *node_rpc.DiagnoseInMessage, onLine func(line string)) error {
+	ip := net.ParseIP(req.Ip).To4()
+	if ip == nil {
+		return fmt.Errorf("无效的IPv4地址 %s", req.Ip)
+	}
+	if req.Network == "" {
+		return fmt.Errorf("arping需要指定网卡")
+	}
+
+	// 连续探测3次，逐次输出结果
+	for i := 1; i <= 3; i++ {
+		// 超时或节点退出时停止探测
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		mac, duration, err := arping.PingOverIfaceByName(ip, req.Network)
+		if err != nil {
+			onLine(fmt.Sprintf("[%d] %s 无响应: %s", i, ip, err))
+			continue
+		}
+		onLine(fmt.Sprintf("[%d] %s 响应 mac=%s 耗时=%s", i, ip, mac, duration))
+	}
+	return nil
+}
+
+// diagnoseTcpConnect 从节点对目标IP:端口发起TCP连接测试
+func diagnoseTcpConnect(ctx context.Context, req *node_rpc.DiagnoseInMessage, onLine func(line string)) error {
+	ip := net.ParseIP(req.Ip)
+	if ip == nil {
+		return fmt.Errorf("无效的IP地址 %s", req.Ip)
+	}
+	if req.Port < 1 || req.Port > 65535 {
+		return fmt.Errorf("无效的端口 %d", req.Port)
+	}
+
+	addr := net.JoinHostPort(ip.String(), fmt.Sprintf("%d", req.Port))
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	startTime := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		onLine(fmt.Sprintf("连接 %s 失败 耗时=%s: %s", addr, time.Since(startTime), err))
+		return nil
+	}
+	defer conn.Close()
+	onLine(fmt.Sprintf("连接 %s 成功 本地地址=%s 耗时=%s", addr, conn.LocalAddr(), time.Since(startTime)))
+	return nil
+}
+
+// sendDiagnose 发送诊断响应到命令响应通道
+func (nc *NodeClient) sendDiagnose(request *node_rpc.CmdRequest, out *node_rpc.DiagnoseOutMessage) {
+	response := &node_rpc.CmdResponse{
+		CmdType:            node_rpc.CmdType_cmdDiagnoseType,
+		TaskID:             request.TaskID,
+		NodeID:             nc.config.System.Uid,
+		DiagnoseOutMessage: out,
+	}
+
+	select {
+	case nc.cmdResponseChan <- response:
+	case <-nc.ctx.Done():
+		logrus.Warn("上下文已取消，丢弃响应")
+	}
+}
@@ -0,0 +1,49 @@
+package command
+
+// File: service/command/command_ip_status.go
+// Description: 节点客户端中处理诱捕IP网卡状态查询命令，返回指定网卡是否存在及其MAC地址、IP地址，供服务端核对过渡状态
+
+import (
+	"honey_node/internal/rpc/node_rpc"
+	"net"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CmdIpStatus 处理诱捕IP网卡状态查询命令
+func (nc *NodeClient) CmdIpStatus(request *node_rpc.CmdRequest) {
+	out := &node_rpc.IpStatusOutMessage{}
+	for _, linkName := range request.GetIpStatusInMessage().GetLinkNameList() {
+		out.LinkList = append(out.LinkList, getLinkStatus(linkName))
+	}
+
+	response := &node_rpc.CmdResponse{
+		CmdType:            node_rpc.CmdType_cmdIpStatusType,
+		TaskID:             request.TaskID,
+		NodeID:             nc.config.System.Uid,
+		IpStatusOutMessage: out,
+	}
+
+	select {
+	case nc.cmdResponseChan <- response:
+	case <-nc.ctx.Done():
+		logrus.Warn("上下文已取消，丢弃响应")
+	}
+}
+
+// getLinkStatus 查询单个网卡的实际状态
+func getLinkStatus(linkName string) *node_rpc.IpLinkStatusMessage {
+	status := &node_rpc.IpLinkStatusMessage{LinkName: linkName}
+	iface, err := net.InterfaceByName(linkName)
+	if err != nil {
+		return status // 网卡不存在
+	}
+	status.Exists = true
+	status.Mac = iface.HardwareAddr.String()
+
+	addrs, _ := iface.Addrs()
+	for _, addr := range addrs {
+		status.AddrList = append(status.AddrList, addr.String())
+	}
+	return status
+}
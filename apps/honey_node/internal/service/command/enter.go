@@ -203,6 +203,15 @@ func (nc *NodeClient) handleCommand(request *node_rpc.CmdRequest) {
 	case node_rpc.CmdType_cmdNetScanType:
 		// 处理网络扫描命令
 		nc.CmdNetScan(request)
+	case node_rpc.CmdType_cmdDiagnoseType:
+		// 处理远程诊断命令
+		nc.CmdDiagnose(request)
+	case node_rpc.CmdType_cmdBusMessageType:
+		// 处理gRPC消息总线投递的业务消息，业务处理可能耗时较长，异步执行避免阻塞命令接收
+		go nc.CmdBusMessage(request)
+	case node_rpc.CmdType_cmdIpStatusType:
+		// 处理诱捕IP网卡状态查询命令
+		nc.CmdIpStatus(request)
 	default:
 		// 未知命令类型，记录警告日志
 		logrus.Warnf("未知命令类型: %v", request.CmdType)
@@ -20,6 +20,9 @@ func Run() {
 	// 向调度器添加任务：每5秒执行一次Resource函数（节点资源信息上报）
 	crontab.AddFunc("*/5 * * * * *", Resource)
 
+	// 每天凌晨3点清理过期的已处理消息台账
+	crontab.AddFunc("0 0 3 * * *", CleanProcessedMessage)
+
 	// 启动定时任务调度器，开始执行已添加的任务
 	crontab.Start()
 }
@@ -0,0 +1,28 @@
+package cron_service
+
+// File: service/cron_service/processed_message.go
+// Description: 定期清理过期的已处理消息台账，避免台账表无限增长
+
+import (
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// processedMessageKeep 已处理消息台账保留时长（需大于消息最长的重投周期）
+const processedMessageKeep = 7 * 24 * time.Hour
+
+// CleanProcessedMessage 删除超过保留时长的已处理消息台账
+func CleanProcessedMessage() {
+	result := global.DB.Where("created_at < ?", time.Now().Add(-processedMessageKeep)).
+		Delete(&models.ProcessedMessageModel{})
+	if result.Error != nil {
+		logrus.Errorf("清理已处理消息台账失败 %s", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		logrus.Infof("清理已处理消息台账 %d条", result.RowsAffected)
+	}
+}
@@ -0,0 +1,200 @@
+package emulator_service
+
+// File: service/emulator_service/device.go
+// Description: 模拟器设备配置与状态，包括设备标识（厂商、型号、固件版本）及工控协议的数据点（线圈、寄存器、遥信、遥测、数据块），
+// 数据点在同一端口的会话间共享，攻击者写入的值后续读取时可见
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// DeviceConfig 设备配置（与服务端模拟器服务的设备配置一致）
+type DeviceConfig struct {
+	Vendor       string  `json:"vendor"`       // 设备厂商
+	Model        string  `json:"model"`        // 设备型号
+	Firmware     string  `json:"firmware"`     // 固件版本
+	SerialNumber string  `json:"serialNumber"` // 序列号
+	PointList    []Point `json:"pointList"`    // 数据点列表
+}
+
+// Point 数据点
+type Point struct {
+	Area    string  `json:"area"`    // 数据区 coil discrete holding input（Modbus） single measured（IEC104） db（S7）
+	DB      int     `json:"db"`      // S7数据块编号
+	Address int     `json:"address"` // 地址（IEC104为信息对象地址，S7为数据块内的字节偏移）
+	Value   float64 `json:"value"`   // 值（线圈、遥信为0或1，S7数据块为16位整数）
+}
+
+// defaultDeviceMap 各工控协议的默认设备配置
+var defaultDeviceMap = map[string]DeviceConfig{
+	"modbus": {
+		Vendor:       "Schneider Electric",
+		Model:        "BMX P34 2020",
+		Firmware:     "v3.10",
+		SerialNumber: "20BX04271",
+		PointList: []Point{
+			{Area: "coil", Address: 0, Value: 1},
+			{Area: "coil", Address: 1, Value: 0},
+			{Area: "discrete", Address: 0, Value: 1},
+			{Area: "holding", Address: 0, Value: 2200},
+			{Area: "holding", Address: 1, Value: 500},
+			{Area: "holding", Address: 2, Value: 1},
+			{Area: "input", Address: 0, Value: 2213},
+			{Area: "input", Address: 1, Value: 498},
+		},
+	},
+	"iec104": {
+		Vendor:       "NARI",
+		Model:        "PCS-9799",
+		Firmware:     "V2.10",
+		SerialNumber: "N9799A1605",
+		PointList: []Point{
+			{Area: "single", Address: 1, Value: 1},
+			{Area: "single", Address: 2, Value: 0},
+			{Area: "single", Address: 3, Value: 1},
+			{Area: "measured", Address: 16385, Value: 10.52},
+			{Area: "measured", Address: 16386, Value: 220.3},
+			{Area: "measured", Address: 16387, Value: 49.98},
+		},
+	},
+	"s7": {
+		Vendor:       "Siemens",
+		Model:        "6ES7 315-2EH14-0AB0",
+		Firmware:     "V3.2.6",
+		SerialNumber: "S C-C2UR28922012",
+		PointList: []Point{
+			{Area: "db", DB: 1, Address: 0, Value: 1},
+			{Area: "db", DB: 1, Address: 2, Value: 2200},
+			{Area: "db", DB: 1, Address: 4, Value: 500},
+		},
+	},
+}
+
+// maxPointCount 单个设备的最大数据点数，攻击者写入未配置的地址会新增数据点，超出后不再新增
+const maxPointCount = 4096
+
+// maxIdentityLength 设备标识（厂商、型号、固件版本、序列号）的最大字节数，协议报文中以单字节表示长度
+const maxIdentityLength = 64
+
+// pointKey 数据点索引
+type pointKey struct {
+	area    string
+	db      int
+	address int
+}
+
+// Device 模拟的设备
+type Device struct {
+	DeviceConfig
+	mu       sync.Mutex
+	pointMap map[pointKey]float64
+}
+
+// newDevice 解析设备配置，未配置的设备标识及数据点使用协议的默认配置
+func newDevice(name string, config string) (*Device, error) {
+	var cfg DeviceConfig
+	if config != "" {
+		if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+			return nil, err
+		}
+	}
+	def := defaultDeviceMap[name]
+	if cfg.Vendor == "" {
+		cfg.Vendor = def.Vendor
+	}
+	if cfg.Model == "" {
+		cfg.Model = def.Model
+	}
+	if cfg.Firmware == "" {
+		cfg.Firmware = def.Firmware
+	}
+	if cfg.SerialNumber == "" {
+		cfg.SerialNumber = def.SerialNumber
+	}
+	if len(cfg.PointList) == 0 {
+		cfg.PointList = def.PointList
+	}
+	// 服务端已校验长度，这里再次截断，避免超长配置导致报文长度字段溢出
+	cfg.Vendor = truncateBytes(cfg.Vendor, maxIdentityLength)
+	cfg.Model = truncateBytes(cfg.Model, maxIdentityLength)
+	cfg.Firmware = truncateBytes(cfg.Firmware, maxIdentityLength)
+	cfg.SerialNumber = truncateBytes(cfg.SerialNumber, maxIdentityLength)
+	if len(cfg.PointList) > maxPointCount {
+		cfg.PointList = cfg.PointList[:maxPointCount]
+	}
+
+	d := &Device{
+		DeviceConfig: cfg,
+		pointMap:     map[pointKey]float64{},
+	}
+	for _, point := range cfg.PointList {
+		if point.Area == "db" {
+			// S7数据块按字节存储，16位整数以大端序拆为两个字节
+			word := uint16(int16(point.Value))
+			d.pointMap[pointKey{"db", point.DB, point.Address}] = float64(word >> 8)
+			d.pointMap[pointKey{"db", point.DB, point.Address + 1}] = float64(word & 0xff)
+			continue
+		}
+		d.pointMap[pointKey{point.Area, 0, point.Address}] = point.Value
+	}
+	return d, nil
+}
+
+// Get 读取数据点的值，未配置的数据点为0
+func (d *Device) Get(area string, db int, address int) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.pointMap[pointKey{area, db, address}]
+}
+
+// Set 写入数据点的值，数据点数已达上限时忽略对未配置地址的写入
+func (d *Device) Set(area string, db int, address int, value float64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := pointKey{area, db, address}
+	if _, ok := d.pointMap[key]; !ok && len(d.pointMap) >= maxPointCount {
+		return
+	}
+	d.pointMap[key] = value
+}
+
+// GetUint16 读取寄存器类数据点的值
+func (d *Device) GetUint16(area string, address int) uint16 {
+	return uint16(math.Max(0, math.Min(d.Get(area, 0, address), math.MaxUint16)))
+}
+
+// GetBool 读取开关量数据点的值
+func (d *Device) GetBool(area string, address int) bool {
+	return d.Get(area, 0, address) != 0
+}
+
+// AreaPoints 按地址顺序返回指定数据区的全部数据点（IEC104总召唤使用）
+func (d *Device) AreaPoints(area string) []Point {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var list []Point
+	for key, value := range d.pointMap {
+		if key.area == area {
+			list = append(list, Point{Area: area, DB: key.db, Address: key.address, Value: value})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Address < list[j].Address
+	})
+	return list
+}
+
+// truncateBytes 按字节数截断字符串，不会截断多字节字符
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,2 @@
+// Package emulator_service 内置低交互协议模拟器服务
+package emulator_service
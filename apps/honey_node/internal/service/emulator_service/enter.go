@@ -0,0 +1,67 @@
+package emulator_service
+
+// File: service/emulator_service/enter.go
+// Description: 内置模拟器入口，按协议名称创建绑定在端口上的模拟器实例，分发客户端连接到对应的模拟器，会话结束后上报交互记录
+
+import (
+	"fmt"
+	"net"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Emulator 协议模拟器，负责与单个客户端会话的协议交互，返回即表示会话结束
+type Emulator func(s *Session)
+
+// emulatorMap 支持的模拟器协议（需与image_server创建模拟器服务时的协议列表一致）
+var emulatorMap = map[string]Emulator{
+	"ssh":    sshEmulator,
+	"telnet": telnetEmulator,
+	"ftp":    ftpEmulator,
+	"http":   httpEmulator,
+	"redis":  redisEmulator,
+	"mysql":  mysqlEmulator,
+	"modbus": modbusEmulator,
+	"iec104": iec104Emulator,
+	"s7":     s7Emulator,
+}
+
+// Instance 绑定在端口上的模拟器实例，同一端口上的所有会话共享设备状态（如工控协议的寄存器）
+type Instance struct {
+	name     string
+	emulator Emulator
+	device   *Device
+}
+
+// New 按协议名称及设备配置（JSON）创建模拟器实例，配置为空时使用该协议的默认设备配置
+func New(name string, config string) (*Instance, error) {
+	emulator, ok := emulatorMap[name]
+	if !ok {
+		return nil, fmt.Errorf("不支持的模拟器协议 %s", name)
+	}
+	device, err := newDevice(name, config)
+	if err != nil {
+		return nil, fmt.Errorf("模拟器设备配置错误 %s", err)
+	}
+	return &Instance{
+		name:     name,
+		emulator: emulator,
+		device:   device,
+	}, nil
+}
+
+// Serve 处理单个客户端连接：创建会话并运行模拟器，会话结束后关闭连接并上报交互记录
+func (i *Instance) Serve(conn net.Conn) {
+	s := newSession(conn, i.name)
+	s.Device = i.device
+	defer s.close()
+	// 模拟器处理的是不可信的输入，单个会话异常不能影响节点进程
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("模拟器会话异常 %s %s: %v\n%s", i.name, s.ID, r, debug.Stack())
+		}
+	}()
+
+	i.emulator(s)
+}
@@ -0,0 +1,77 @@
+package emulator_service
+
+// File: service/emulator_service/ftp.go
+// Description: FTP模拟器，模拟vsftpd的控制连接以收集口令与命令，匿名用户可登录但不提供数据连接
+
+import (
+	"strings"
+)
+
+// ftpEmulator FTP模拟器
+func ftpEmulator(s *Session) {
+	if s.Write("220 (vsFTPd 3.0.5)\r\n") != nil {
+		return
+	}
+
+	var user string
+	var login bool
+	for {
+		line, err := s.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		cmd = strings.ToUpper(cmd)
+		if cmd == "" {
+			continue
+		}
+		if cmd != "PASS" {
+			s.Event(EventCommand, line)
+		}
+
+		var reply string
+		switch cmd {
+		case "USER":
+			user = arg
+			login = false
+			reply = "331 Please specify the password."
+		case "PASS":
+			s.Auth(user, arg)
+			if user == "anonymous" || user == "ftp" {
+				login = true
+				reply = "230 Login successful."
+			} else {
+				reply = "530 Login incorrect."
+			}
+		case "QUIT":
+			s.Write("221 Goodbye.\r\n")
+			return
+		case "SYST":
+			reply = "215 UNIX Type: L8"
+		case "FEAT":
+			reply = "211-Features:\r\n EPRT\r\n EPSV\r\n MDTM\r\n PASV\r\n REST STREAM\r\n SIZE\r\n TVFS\r\n211 End"
+		case "NOOP":
+			reply = "200 NOOP ok."
+		default:
+			if !login {
+				reply = "530 Please login with USER and PASS."
+				break
+			}
+			switch cmd {
+			case "PWD", "XPWD":
+				reply = `257 "/" is the current directory`
+			case "CWD", "CDUP":
+				reply = "250 Directory successfully changed."
+			case "TYPE":
+				reply = "200 Switching to Binary mode."
+			case "PASV", "EPSV", "PORT", "EPRT", "LIST", "NLST", "RETR", "STOR":
+				reply = "425 Failed to establish connection."
+			default:
+				reply = "550 Permission denied."
+			}
+		}
+		if s.Write("%s\r\n", reply) != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package emulator_service
+
+// File: service/emulator_service/http.go
+// Description: HTTP模拟器，模拟nginx上的后台登录页，记录每个请求并收集Basic认证及登录表单中的口令
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	httpServer      = "nginx/1.18.0 (Ubuntu)" // 模拟的Web服务版本
+	maxHttpBodySize = 4096                    // 记录请求体的最大长度
+)
+
+// httpLoginPage 模拟的后台登录页
+const httpLoginPage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Admin Login</title></head>
+<body>
+<form method="post" action="/login">
+<h2>Administrator Login</h2>
+%s
+<p><input type="text" name="username" placeholder="Username"></p>
+<p><input type="password" name="password" placeholder="Password"></p>
+<p><button type="submit">Sign in</button></p>
+</form>
+</body>
+</html>
+`
+
+// httpRequestData 请求事件内容
+type httpRequestData struct {
+	Method    string `json:"method"`         // 请求方法
+	URI       string `json:"uri"`            // 请求路径及参数
+	Host      string `json:"host"`           // Host请求头
+	UserAgent string `json:"userAgent"`      // User-Agent请求头
+	Body      string `json:"body,omitempty"` // 请求体
+}
+
+// httpEmulator HTTP模拟器，支持长连接上的多个请求
+func httpEmulator(s *Session) {
+	for {
+		req, err := http.ReadRequest(s.Reader)
+		if err != nil {
+			return
+		}
+		body, _ := io.ReadAll(io.LimitReader(req.Body, maxHttpBodySize))
+		req.Body.Close()
+
+		byteData, _ := json.Marshal(httpRequestData{
+			Method:    req.Method,
+			URI:       req.RequestURI,
+			Host:      req.Host,
+			UserAgent: req.UserAgent(),
+			Body:      string(body),
+		})
+		s.Event(EventRequest, string(byteData))
+
+		// 收集Basic认证及登录表单中的口令
+		var tip string
+		if user, password, ok := req.BasicAuth(); ok {
+			s.Auth(user, password)
+		}
+		if req.Method == http.MethodPost {
+			form, _ := url.ParseQuery(string(body))
+			if form.Has("username") || form.Has("password") {
+				s.Auth(form.Get("username"), form.Get("password"))
+				tip = `<p style="color:red">Invalid username or password</p>`
+			}
+		}
+
+		status := http.StatusOK
+		if req.URL.Path != "/" && req.URL.Path != "/login" && !strings.HasPrefix(req.URL.Path, "/admin") {
+			status = http.StatusNotFound
+		}
+		if writeHttpResponse(s, status, fmt.Sprintf(httpLoginPage, tip), req.Close) != nil || req.Close {
+			return
+		}
+	}
+}
+
+// writeHttpResponse 写入HTTP响应，404时返回nginx默认错误页
+func writeHttpResponse(s *Session, status int, page string, close bool) error {
+	if status == http.StatusNotFound {
+		page = "<html>\r\n<head><title>404 Not Found</title></head>\r\n<body>\r\n<center><h1>404 Not Found</h1></center>\r\n" +
+			"<hr><center>" + httpServer + "</center>\r\n</body>\r\n</html>\r\n"
+	}
+	connection := "keep-alive"
+	if close {
+		connection = "close"
+	}
+	return s.Write("HTTP/1.1 %d %s\r\nServer: %s\r\nDate: %s\r\nContent-Type: text/html; charset=utf-8\r\nContent-Length: %d\r\nConnection: %s\r\n\r\n%s",
+		status, http.StatusText(status), httpServer, time.Now().UTC().Format(http.TimeFormat), len(page), connection, page)
+}
@@ -0,0 +1,242 @@
+package emulator_service
+
+// File: service/emulator_service/iec104.go
+// Description: IEC 60870-5-104模拟器，模拟变电站远动终端，支持链路启停与测试、总召唤、读命令、单点遥控、浮点设定值及时钟同步，
+// 记录每个应用服务数据单元的类型标识、传送原因、信息对象地址及解析后的值
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// 应用服务数据单元类型标识
+const (
+	iecSinglePoint    = 1   // M_SP_NA_1 单点遥信
+	iecMeasuredFloat  = 13  // M_ME_NC_1 短浮点遥测
+	iecSingleCommand  = 45  // C_SC_NA_1 单点遥控
+	iecSetPointFloat  = 50  // C_SE_NC_1 短浮点设定值
+	iecInterrogation  = 100 // C_IC_NA_1 总召唤
+	iecReadCommand    = 102 // C_RD_NA_1 读命令
+	iecClockSync      = 103 // C_CS_NA_1 时钟同步
+	iecStartByte      = 0x68
+	iecMaxApduSize    = 253
+	iecMaxPointsFrame = 30 // 总召唤时单帧最多携带的信息对象数
+)
+
+// 传送原因
+const (
+	iecCotRequest        = 5  // 请求
+	iecCotActivation     = 6  // 激活
+	iecCotActivationCon  = 7  // 激活确认
+	iecCotActivationTerm = 10 // 激活终止
+	iecCotInterrogated   = 20 // 响应总召唤
+	iecCotUnknownType    = 44 // 未知的类型标识
+)
+
+// iecTypeNameMap 类型标识名称
+var iecTypeNameMap = map[byte]string{
+	iecSingleCommand: "C_SC_NA_1",
+	iecSetPointFloat: "C_SE_NC_1",
+	iecInterrogation: "C_IC_NA_1",
+	iecReadCommand:   "C_RD_NA_1",
+	iecClockSync:     "C_CS_NA_1",
+}
+
+// iec104Operation IEC104读写事件内容
+type iec104Operation struct {
+	TypeID        byte     `json:"typeID"`          // 类型标识
+	Name          string   `json:"name"`            // 类型名称
+	Cot           byte     `json:"cot"`             // 传送原因
+	CommonAddress int      `json:"commonAddress"`   // 公共地址
+	Address       int      `json:"address"`         // 信息对象地址
+	Value         *float64 `json:"value,omitempty"` // 遥控、设定的值
+	Select        bool     `json:"select"`          // 是否为选择命令（遥控选择/执行）
+}
+
+// iec104Session IEC104链路状态
+type iec104Session struct {
+	s         *Session
+	sendSeq   uint16 // 发送序号
+	recvSeq   uint16 // 接收序号
+	commonLow byte   // 公共地址低字节
+	commonHi  byte   // 公共地址高字节
+}
+
+// iec104Emulator IEC 60870-5-104模拟器
+func iec104Emulator(s *Session) {
+	l := &iec104Session{s: s}
+	header := make([]byte, 2)
+	for {
+		if _, err := io.ReadFull(s.Reader, header); err != nil {
+			return
+		}
+		if header[0] != iecStartByte || header[1] < 4 || header[1] > iecMaxApduSize {
+			s.Event(EventRequest, "invalid apci")
+			return
+		}
+		apdu := make([]byte, header[1])
+		if _, err := io.ReadFull(s.Reader, apdu); err != nil {
+			return
+		}
+
+		switch {
+		case apdu[0]&0x03 == 0x03:
+			// U格式：链路启动、停止、测试
+			var reply byte
+			switch apdu[0] {
+			case 0x07:
+				s.Event(EventRequest, "STARTDT act")
+				reply = 0x0b
+			case 0x13:
+				s.Event(EventRequest, "STOPDT act")
+				reply = 0x23
+			case 0x43:
+				reply = 0x83
+			}
+			if reply != 0 && l.write([]byte{reply, 0, 0, 0}) != nil {
+				return
+			}
+		case apdu[0]&0x01 == 0x01:
+			// S格式：仅确认
+		default:
+			// I格式：携带应用服务数据单元
+			l.recvSeq = (l.recvSeq + 1) & 0x7fff
+			if len(apdu) > 4 && l.handleAsdu(apdu[4:]) != nil {
+				return
+			}
+		}
+	}
+}
+
+// handleAsdu 处理一个应用服务数据单元
+func (l *iec104Session) handleAsdu(asdu []byte) error {
+	// 类型标识(1) 可变结构限定词(1) 传送原因(2) 公共地址(2) 信息对象地址(3)
+	if len(asdu) < 9 {
+		return nil
+	}
+	op := iec104Operation{
+		TypeID:        asdu[0],
+		Name:          iecTypeNameMap[asdu[0]],
+		Cot:           asdu[2] & 0x3f,
+		CommonAddress: int(binary.LittleEndian.Uint16(asdu[4:6])),
+		Address:       int(asdu[6]) | int(asdu[7])<<8 | int(asdu[8])<<16,
+	}
+	l.commonLow, l.commonHi = asdu[4], asdu[5]
+	element := asdu[9:]
+	d := l.s.Device
+
+	switch op.TypeID {
+	case iecInterrogation:
+		l.s.Operate(EventRead, op)
+		if err := l.confirm(asdu, iecCotActivationCon); err != nil {
+			return err
+		}
+		if err := l.sendPoints(iecSinglePoint, d.AreaPoints("single"), iecCotInterrogated); err != nil {
+			return err
+		}
+		if err := l.sendPoints(iecMeasuredFloat, d.AreaPoints("measured"), iecCotInterrogated); err != nil {
+			return err
+		}
+		return l.confirm(asdu, iecCotActivationTerm)
+
+	case iecReadCommand:
+		l.s.Operate(EventRead, op)
+		if hasPoint(d, "single", op.Address) {
+			return l.sendPoints(iecSinglePoint, []Point{{Address: op.Address, Value: d.Get("single", 0, op.Address)}}, iecCotRequest)
+		}
+		return l.sendPoints(iecMeasuredFloat, []Point{{Address: op.Address, Value: d.Get("measured", 0, op.Address)}}, iecCotRequest)
+
+	case iecSingleCommand:
+		if len(element) < 1 {
+			return nil
+		}
+		value := float64(element[0] & 0x01)
+		op.Value = &value
+		op.Select = element[0]&0x80 != 0
+		l.s.Operate(EventWrite, op)
+		if !op.Select {
+			d.Set("single", 0, op.Address, value)
+		}
+		return l.confirm(asdu, iecCotActivationCon)
+
+	case iecSetPointFloat:
+		if len(element) < 5 {
+			return nil
+		}
+		value := float64(math.Float32frombits(binary.LittleEndian.Uint32(element)))
+		op.Value = &value
+		op.Select = element[4]&0x80 != 0
+		l.s.Operate(EventWrite, op)
+		if !op.Select {
+			d.Set("measured", 0, op.Address, value)
+		}
+		return l.confirm(asdu, iecCotActivationCon)
+
+	case iecClockSync:
+		l.s.Operate(EventWrite, op)
+		return l.confirm(asdu, iecCotActivationCon)
+	}
+
+	// 不支持的类型标识：否定确认
+	op.Name = "Unknown"
+	l.s.Operate(EventRequest, op)
+	return l.confirm(asdu, iecCotUnknownType|0x40)
+}
+
+// hasPoint 判断数据区中是否配置了指定地址的数据点
+func hasPoint(d *Device, area string, address int) bool {
+	for _, point := range d.AreaPoints(area) {
+		if point.Address == address {
+			return true
+		}
+	}
+	return false
+}
+
+// confirm 以指定传送原因回送收到的应用服务数据单元（激活确认、激活终止、否定确认）
+func (l *iec104Session) confirm(asdu []byte, cot byte) error {
+	resp := append([]byte{}, asdu...)
+	resp[2] = cot
+	return l.writeI(resp)
+}
+
+// sendPoints 发送遥信或遥测数据，按单帧上限分多帧发送
+func (l *iec104Session) sendPoints(typeID byte, pointList []Point, cot byte) error {
+	for start := 0; start < len(pointList); start += iecMaxPointsFrame {
+		batch := pointList[start:min(start+iecMaxPointsFrame, len(pointList))]
+		asdu := []byte{typeID, byte(len(batch)), cot, 0, l.commonLow, l.commonHi}
+		for _, point := range batch {
+			asdu = append(asdu, byte(point.Address), byte(point.Address>>8), byte(point.Address>>16))
+			if typeID == iecSinglePoint {
+				var spi byte
+				if point.Value != 0 {
+					spi = 1
+				}
+				asdu = append(asdu, spi)
+				continue
+			}
+			asdu = binary.LittleEndian.AppendUint32(asdu, math.Float32bits(float32(point.Value)))
+			asdu = append(asdu, 0) // 品质描述词
+		}
+		if err := l.writeI(asdu); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeI 以I格式发送应用服务数据单元
+func (l *iec104Session) writeI(asdu []byte) error {
+	control := make([]byte, 4)
+	binary.LittleEndian.PutUint16(control, l.sendSeq<<1)
+	binary.LittleEndian.PutUint16(control[2:], l.recvSeq<<1)
+	l.sendSeq = (l.sendSeq + 1) & 0x7fff
+	return l.write(append(control, asdu...))
+}
+
+// write 添加APCI报文头后发送
+func (l *iec104Session) write(apdu []byte) error {
+	_, err := l.s.Conn.Write(append([]byte{iecStartByte, byte(len(apdu))}, apdu...))
+	return err
+}
@@ -0,0 +1,247 @@
+package emulator_service
+
+// File: service/emulator_service/modbus.go
+// Description: Modbus TCP模拟器，支持线圈、离散输入、保持寄存器、输入寄存器的读写及设备标识读取，记录每个功能码及解析后的地址与值
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Modbus功能码
+const (
+	modbusReadCoils              = 0x01
+	modbusReadDiscreteInputs     = 0x02
+	modbusReadHoldingRegisters   = 0x03
+	modbusReadInputRegisters     = 0x04
+	modbusWriteSingleCoil        = 0x05
+	modbusWriteSingleRegister    = 0x06
+	modbusWriteMultipleCoils     = 0x0f
+	modbusWriteMultipleRegisters = 0x10
+	modbusReportServerID         = 0x11
+	modbusEncapsulatedInterface  = 0x2b
+)
+
+// Modbus异常码
+const (
+	modbusIllegalFunction = 0x01
+	modbusIllegalAddress  = 0x02
+	modbusIllegalValue    = 0x03
+)
+
+// modbusFunctionNameMap 功能码名称
+var modbusFunctionNameMap = map[byte]string{
+	modbusReadCoils:              "Read Coils",
+	modbusReadDiscreteInputs:     "Read Discrete Inputs",
+	modbusReadHoldingRegisters:   "Read Holding Registers",
+	modbusReadInputRegisters:     "Read Input Registers",
+	modbusWriteSingleCoil:        "Write Single Coil",
+	modbusWriteSingleRegister:    "Write Single Register",
+	modbusWriteMultipleCoils:     "Write Multiple Coils",
+	modbusWriteMultipleRegisters: "Write Multiple Registers",
+	modbusReportServerID:         "Report Server ID",
+	modbusEncapsulatedInterface:  "Read Device Identification",
+}
+
+// modbusOperation Modbus读写事件内容
+type modbusOperation struct {
+	UnitID   byte   `json:"unitID"`           // 从站地址
+	Function byte   `json:"function"`         // 功能码
+	Name     string `json:"name"`             // 功能码名称
+	Area     string `json:"area,omitempty"`   // 数据区
+	Address  int    `json:"address"`          // 起始地址
+	Count    int    `json:"count"`            // 数量
+	Values   []int  `json:"values,omitempty"` // 读取或写入的值
+	Error    byte   `json:"error,omitempty"`  // 返回的异常码
+}
+
+// modbusEmulator Modbus TCP模拟器
+func modbusEmulator(s *Session) {
+	header := make([]byte, 7)
+	for {
+		// MBAP报文头：事务标识(2) 协议标识(2) 长度(2) 单元标识(1)
+		if _, err := io.ReadFull(s.Reader, header); err != nil {
+			return
+		}
+		length := int(binary.BigEndian.Uint16(header[4:6]))
+		if binary.BigEndian.Uint16(header[2:4]) != 0 || length < 2 || length > 254 {
+			s.Event(EventRequest, "invalid mbap header")
+			return
+		}
+		pdu := make([]byte, length-1)
+		if _, err := io.ReadFull(s.Reader, pdu); err != nil {
+			return
+		}
+
+		op := modbusOperation{UnitID: header[6], Function: pdu[0], Name: modbusFunctionNameMap[pdu[0]]}
+		resp := modbusHandle(s.Device, pdu, &op)
+		if op.Name == "" {
+			op.Name = "Unknown"
+		}
+		eventType := EventRead
+		if op.Function == modbusWriteSingleCoil || op.Function == modbusWriteSingleRegister ||
+			op.Function == modbusWriteMultipleCoils || op.Function == modbusWriteMultipleRegisters {
+			eventType = EventWrite
+		}
+		s.Operate(eventType, op)
+
+		respHeader := make([]byte, 7)
+		copy(respHeader, header[:4])
+		binary.BigEndian.PutUint16(respHeader[4:6], uint16(len(resp)+1))
+		respHeader[6] = header[6]
+		if _, err := s.Conn.Write(append(respHeader, resp...)); err != nil {
+			return
+		}
+	}
+}
+
+// modbusHandle 处理一个PDU并返回响应PDU
+func modbusHandle(d *Device, pdu []byte, op *modbusOperation) []byte {
+	fc := pdu[0]
+	data := pdu[1:]
+	exception := func(code byte) []byte {
+		op.Error = code
+		return []byte{fc | 0x80, code}
+	}
+
+	switch fc {
+	case modbusReadCoils, modbusReadDiscreteInputs:
+		if len(data) < 4 {
+			return exception(modbusIllegalValue)
+		}
+		op.Area = map[byte]string{modbusReadCoils: "coil", modbusReadDiscreteInputs: "discrete"}[fc]
+		op.Address = int(binary.BigEndian.Uint16(data))
+		op.Count = int(binary.BigEndian.Uint16(data[2:]))
+		if op.Count < 1 || op.Count > 2000 {
+			return exception(modbusIllegalValue)
+		}
+		if op.Address+op.Count > 0x10000 {
+			return exception(modbusIllegalAddress)
+		}
+		bits := make([]byte, (op.Count+7)/8)
+		for i := 0; i < op.Count; i++ {
+			value := 0
+			if d.GetBool(op.Area, op.Address+i) {
+				value = 1
+				bits[i/8] |= 1 << (i % 8)
+			}
+			op.Values = append(op.Values, value)
+		}
+		return append([]byte{fc, byte(len(bits))}, bits...)
+
+	case modbusReadHoldingRegisters, modbusReadInputRegisters:
+		if len(data) < 4 {
+			return exception(modbusIllegalValue)
+		}
+		op.Area = map[byte]string{modbusReadHoldingRegisters: "holding", modbusReadInputRegisters: "input"}[fc]
+		op.Address = int(binary.BigEndian.Uint16(data))
+		op.Count = int(binary.BigEndian.Uint16(data[2:]))
+		if op.Count < 1 || op.Count > 125 {
+			return exception(modbusIllegalValue)
+		}
+		if op.Address+op.Count > 0x10000 {
+			return exception(modbusIllegalAddress)
+		}
+		resp := []byte{fc, byte(op.Count * 2)}
+		for i := 0; i < op.Count; i++ {
+			value := d.GetUint16(op.Area, op.Address+i)
+			op.Values = append(op.Values, int(value))
+			resp = binary.BigEndian.AppendUint16(resp, value)
+		}
+		return resp
+
+	case modbusWriteSingleCoil:
+		if len(data) < 4 {
+			return exception(modbusIllegalValue)
+		}
+		op.Area = "coil"
+		op.Address = int(binary.BigEndian.Uint16(data))
+		op.Count = 1
+		switch binary.BigEndian.Uint16(data[2:]) {
+		case 0xff00:
+			op.Values = []int{1}
+		case 0x0000:
+			op.Values = []int{0}
+		default:
+			return exception(modbusIllegalValue)
+		}
+		d.Set("coil", 0, op.Address, float64(op.Values[0]))
+		return pdu[:5]
+
+	case modbusWriteSingleRegister:
+		if len(data) < 4 {
+			return exception(modbusIllegalValue)
+		}
+		op.Area = "holding"
+		op.Address = int(binary.BigEndian.Uint16(data))
+		op.Count = 1
+		value := binary.BigEndian.Uint16(data[2:])
+		op.Values = []int{int(value)}
+		d.Set("holding", 0, op.Address, float64(value))
+		return pdu[:5]
+
+	case modbusWriteMultipleCoils:
+		if len(data) < 5 {
+			return exception(modbusIllegalValue)
+		}
+		op.Area = "coil"
+		op.Address = int(binary.BigEndian.Uint16(data))
+		op.Count = int(binary.BigEndian.Uint16(data[2:]))
+		byteCount := int(data[4])
+		if op.Count < 1 || op.Count > 1968 || byteCount != (op.Count+7)/8 || len(data) < 5+byteCount {
+			return exception(modbusIllegalValue)
+		}
+		if op.Address+op.Count > 0x10000 {
+			return exception(modbusIllegalAddress)
+		}
+		for i := 0; i < op.Count; i++ {
+			value := int(data[5+i/8]>>(i%8)) & 1
+			op.Values = append(op.Values, value)
+			d.Set("coil", 0, op.Address+i, float64(value))
+		}
+		return pdu[:5]
+
+	case modbusWriteMultipleRegisters:
+		if len(data) < 5 {
+			return exception(modbusIllegalValue)
+		}
+		op.Area = "holding"
+		op.Address = int(binary.BigEndian.Uint16(data))
+		op.Count = int(binary.BigEndian.Uint16(data[2:]))
+		byteCount := int(data[4])
+		if op.Count < 1 || op.Count > 123 || byteCount != op.Count*2 || len(data) < 5+byteCount {
+			return exception(modbusIllegalValue)
+		}
+		if op.Address+op.Count > 0x10000 {
+			return exception(modbusIllegalAddress)
+		}
+		for i := 0; i < op.Count; i++ {
+			value := binary.BigEndian.Uint16(data[5+i*2:])
+			op.Values = append(op.Values, int(value))
+			d.Set("holding", 0, op.Address+i, float64(value))
+		}
+		return pdu[:5]
+
+	case modbusReportServerID:
+		// 从站标识(1) 运行状态(1) 附加数据（厂商及型号），字节数以单字节表示
+		info := []byte{0x01, 0xff}
+		info = append(info, truncateBytes(d.Vendor+" "+d.Model, 2*maxIdentityLength+1)...)
+		return append([]byte{fc, byte(len(info))}, info...)
+
+	case modbusEncapsulatedInterface:
+		// 仅支持读设备标识（MEI类型14）的基本标识：厂商、型号、固件版本
+		if len(data) < 3 || data[0] != 0x0e {
+			return exception(modbusIllegalFunction)
+		}
+		objectList := []string{d.Vendor, d.Model, d.Firmware}
+		resp := []byte{fc, 0x0e, data[1], 0x01, 0x00, 0x00, byte(len(objectList))}
+		for id, value := range objectList {
+			// 对象长度以单字节表示
+			value = truncateBytes(value, maxIdentityLength)
+			resp = append(resp, byte(id), byte(len(value)))
+			resp = append(resp, value...)
+		}
+		return resp
+	}
+	return exception(modbusIllegalFunction)
+}
@@ -0,0 +1,186 @@
+package emulator_service
+
+// File: service/emulator_service/mysql.go
+// Description: MySQL模拟器，发送MySQL 5.7握手包并解析客户端的认证应答，记录用户名、目标库及口令挑战应答后返回拒绝访问
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+const (
+	mysqlServerVersion = "5.7.40-log"            // 模拟的MySQL服务版本
+	mysqlAuthPlugin    = "mysql_native_password" // 认证插件
+	maxMysqlPacketSize = 16 * 1024               // 客户端认证包的最大长度
+)
+
+// MySQL能力标志
+const (
+	mysqlClientLongPassword     = 0x00000001
+	mysqlClientFoundRows        = 0x00000002
+	mysqlClientLongFlag         = 0x00000004
+	mysqlClientConnectWithDB    = 0x00000008
+	mysqlClientProtocol41       = 0x00000200
+	mysqlClientTransactions     = 0x00002000
+	mysqlClientSecureConnection = 0x00008000
+	mysqlClientPluginAuth       = 0x00080000
+	mysqlClientPluginAuthLenenc = 0x00200000
+
+	mysqlServerCapabilities = mysqlClientLongPassword | mysqlClientFoundRows | mysqlClientLongFlag |
+		mysqlClientConnectWithDB | mysqlClientProtocol41 | mysqlClientTransactions |
+		mysqlClientSecureConnection | mysqlClientPluginAuth | mysqlClientPluginAuthLenenc
+)
+
+// mysqlConnectionID 握手包中的连接ID，从一个常见的量级开始递增
+var mysqlConnectionID = func() *atomic.Uint32 {
+	var id atomic.Uint32
+	id.Store(1024)
+	return &id
+}()
+
+// mysqlEmulator MySQL模拟器
+func mysqlEmulator(s *Session) {
+	salt := make([]byte, 20)
+	rand.Read(salt)
+	// 挑战值不能包含0字节（握手包中以0结尾）
+	for i := range salt {
+		salt[i] = salt[i]%94 + 33
+	}
+
+	if writeMysqlPacket(s, 0, mysqlHandshake(salt)) != nil {
+		return
+	}
+
+	seq, payload, err := readMysqlPacket(s)
+	if err != nil {
+		return
+	}
+	user, authResponse, database, ok := parseMysqlHandshakeResponse(payload)
+	if !ok {
+		s.Event(EventRequest, "invalid handshake response "+hex.EncodeToString(payload[:min(len(payload), 64)]))
+		return
+	}
+	if database != "" {
+		s.Event(EventRequest, "database "+database)
+	}
+	// mysql_native_password无法还原明文，记录挑战值与应答供离线分析
+	s.AuthHash(user, fmt.Sprintf("%s:%s", hex.EncodeToString(salt), hex.EncodeToString(authResponse)))
+
+	usingPassword := "YES"
+	if len(authResponse) == 0 {
+		usingPassword = "NO"
+	}
+	msg := fmt.Sprintf("Access denied for user '%s'@'%s' (using password: %s)", user, s.RemoteIP(), usingPassword)
+	writeMysqlPacket(s, seq+1, mysqlError(1045, "28000", msg))
+}
+
+// mysqlHandshake 构建协议版本10的初始握手包
+func mysqlHandshake(salt []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(10) // 协议版本
+	buf.WriteString(mysqlServerVersion)
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.LittleEndian, mysqlConnectionID.Add(1)) // 连接ID
+	buf.Write(salt[:8])
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.LittleEndian, uint16(mysqlServerCapabilities&0xffff))
+	buf.WriteByte(33)                                       // 字符集 utf8_general_ci
+	binary.Write(&buf, binary.LittleEndian, uint16(0x0002)) // 状态标志 SERVER_STATUS_AUTOCOMMIT
+	binary.Write(&buf, binary.LittleEndian, uint16(mysqlServerCapabilities>>16))
+	buf.WriteByte(byte(len(salt) + 1))
+	buf.Write(make([]byte, 10)) // 保留字节
+	buf.Write(salt[8:])
+	buf.WriteByte(0)
+	buf.WriteString(mysqlAuthPlugin)
+	buf.WriteByte(0)
+	return buf.Bytes()
+}
+
+// mysqlError 构建错误包
+func mysqlError(code uint16, state string, msg string) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(0xff)
+	binary.Write(&buf, binary.LittleEndian, code)
+	buf.WriteByte('#')
+	buf.WriteString(state)
+	buf.WriteString(msg)
+	return buf.Bytes()
+}
+
+// parseMysqlHandshakeResponse 解析客户端的握手应答包（仅支持4.1协议）
+func parseMysqlHandshakeResponse(payload []byte) (user string, authResponse []byte, database string, ok bool) {
+	// 能力标志4字节 + 最大包长4字节 + 字符集1字节 + 保留23字节
+	if len(payload) < 32 {
+		return
+	}
+	capabilities := binary.LittleEndian.Uint32(payload)
+	if capabilities&mysqlClientProtocol41 == 0 {
+		return
+	}
+	data := payload[32:]
+
+	end := bytes.IndexByte(data, 0)
+	if end < 0 {
+		return
+	}
+	user = string(data[:end])
+	data = data[end+1:]
+
+	// 认证应答：长度编码 / 1字节长度 / 0结尾
+	switch {
+	case capabilities&mysqlClientPluginAuthLenenc != 0, capabilities&mysqlClientSecureConnection != 0:
+		if len(data) == 0 || int(data[0]) >= 0xfb {
+			return
+		}
+		size := int(data[0])
+		if len(data) < 1+size {
+			return
+		}
+		authResponse = data[1 : 1+size]
+		data = data[1+size:]
+	default:
+		end = bytes.IndexByte(data, 0)
+		if end < 0 {
+			return
+		}
+		authResponse = data[:end]
+		data = data[end+1:]
+	}
+
+	if capabilities&mysqlClientConnectWithDB != 0 {
+		if end = bytes.IndexByte(data, 0); end >= 0 {
+			database = string(data[:end])
+		}
+	}
+	return user, authResponse, database, true
+}
+
+// readMysqlPacket 读取一个数据包，返回序号与内容
+func readMysqlPacket(s *Session) (byte, []byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(s.Reader, header); err != nil {
+		return 0, nil, err
+	}
+	size := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	if size > maxMysqlPacketSize {
+		return 0, nil, fmt.Errorf("数据包过长 %d", size)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(s.Reader, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[3], payload, nil
+}
+
+// writeMysqlPacket 写入一个数据包
+func writeMysqlPacket(s *Session, seq byte, payload []byte) error {
+	size := len(payload)
+	packet := append([]byte{byte(size), byte(size >> 8), byte(size >> 16), seq}, payload...)
+	_, err := s.Conn.Write(packet)
+	return err
+}
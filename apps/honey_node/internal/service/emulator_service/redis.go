@@ -0,0 +1,133 @@
+package emulator_service
+
+// File: service/emulator_service/redis.go
+// Description: Redis模拟器，模拟未设置口令的Redis服务，解析RESP协议记录每条命令（包括常见的写计划任务、主从复制等利用手法）
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxRedisArgCount = 64   // 单条命令的最大参数个数
+	maxRedisArgSize  = 4096 // 单个参数的最大长度
+)
+
+var errRedisProtocol = errors.New("RESP协议错误")
+
+// redisInfo INFO命令返回的伪造服务信息
+const redisInfo = "# Server\r\nredis_version:6.2.6\r\nredis_mode:standalone\r\nos:Linux 5.15.0-91-generic x86_64\r\n" +
+	"arch_bits:64\r\ntcp_port:6379\r\nuptime_in_days:37\r\n\r\n# Clients\r\nconnected_clients:1\r\n\r\n" +
+	"# Memory\r\nused_memory:873216\r\nused_memory_human:852.75K\r\n\r\n# Keyspace\r\n"
+
+// redisEmulator Redis模拟器
+func redisEmulator(s *Session) {
+	for {
+		args, err := readRedisCommand(s)
+		if err != nil {
+			if errors.Is(err, errRedisProtocol) {
+				s.Write("-ERR Protocol error: invalid multibulk length\r\n")
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		s.Event(EventCommand, strings.Join(args, " "))
+
+		cmd := strings.ToUpper(args[0])
+		var reply string
+		switch cmd {
+		case "PING":
+			reply = "+PONG\r\n"
+			if len(args) > 1 {
+				reply = bulkString(args[1])
+			}
+		case "ECHO":
+			if len(args) > 1 {
+				reply = bulkString(args[1])
+			}
+		case "AUTH":
+			if len(args) == 2 {
+				s.Auth("default", args[1])
+			} else if len(args) > 2 {
+				s.Auth(args[1], args[2])
+			}
+			reply = "-ERR AUTH <password> called without any password configured for the default user. Are you sure your configuration is correct?\r\n"
+		case "INFO":
+			reply = bulkString(redisInfo)
+		case "SET", "SELECT", "FLUSHALL", "FLUSHDB", "SAVE", "SLAVEOF", "REPLICAOF", "CLIENT":
+			reply = "+OK\r\n"
+		case "BGSAVE":
+			reply = "+Background saving started\r\n"
+		case "GET":
+			reply = "$-1\r\n"
+		case "DBSIZE", "DEL", "EXISTS":
+			reply = ":0\r\n"
+		case "KEYS", "COMMAND":
+			reply = "*0\r\n"
+		case "SCAN":
+			reply = "*2\r\n$1\r\n0\r\n*0\r\n"
+		case "CONFIG":
+			reply = "+OK\r\n"
+			if len(args) > 1 && strings.ToUpper(args[1]) == "GET" {
+				reply = "*0\r\n"
+			}
+		case "MODULE":
+			reply = "-ERR Error loading the extension. Please check the server logs.\r\n"
+		case "QUIT":
+			s.Write("+OK\r\n")
+			return
+		default:
+			reply = fmt.Sprintf("-ERR unknown command `%s`, with args beginning with: \r\n", args[0])
+		}
+		if s.Write("%s", reply) != nil {
+			return
+		}
+	}
+}
+
+// readRedisCommand 读取一条命令，支持RESP数组格式及内联命令格式
+func readRedisCommand(s *Session) ([]string, error) {
+	line, err := s.ReadLine()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil
+	}
+
+	count, err := strconv.Atoi(line[1:])
+	if err != nil || count > maxRedisArgCount {
+		return nil, errRedisProtocol
+	}
+	args := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		line, err = s.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "$") {
+			return nil, errRedisProtocol
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 || size > maxRedisArgSize {
+			return nil, errRedisProtocol
+		}
+		// 参数内容后跟CRLF
+		buf := make([]byte, size+2)
+		if _, err = io.ReadFull(s.Reader, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+// bulkString 编码RESP批量字符串
+func bulkString(str string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(str), str)
+}
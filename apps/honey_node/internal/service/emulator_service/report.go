@@ -0,0 +1,89 @@
+package emulator_service
+
+// File: service/emulator_service/report.go
+// Description: 交互会话上报，会话结束后进入上报队列，由上报协程批量通过gRPC上报到服务端，上报失败时保留待下次重试
+
+import (
+	"context"
+	"honey_node/internal/global"
+	"honey_node/internal/rpc/node_rpc"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	reportInterval  = time.Second // 批量上报间隔
+	reportBatchSize = 50          // 单次上报的最大会话数
+	maxPendingCount = 1000        // 上报失败时最多保留的待上报会话数
+)
+
+// reportChan 待上报的交互会话队列
+var reportChan = make(chan *node_rpc.InteractionMessage, 1024)
+
+// report 将会话加入上报队列，队列已满时丢弃（避免大量扫描连接阻塞模拟器）
+func report(interaction *node_rpc.InteractionMessage) {
+	select {
+	case reportChan <- interaction:
+	default:
+		logrus.Warnf("交互会话上报队列已满，丢弃会话 %s", interaction.SessionID)
+	}
+}
+
+// RunReporter 启动交互会话上报循环（阻塞运行，需以协程方式启动）
+func RunReporter() {
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+
+	var pendingList []*node_rpc.InteractionMessage
+	for {
+		select {
+		case interaction := <-reportChan:
+			pendingList = append(pendingList, interaction)
+			// 刚好凑满一批时立即上报，其余情况（包括上报失败积压时）等待定时上报
+			if len(pendingList) != reportBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		pendingList = flush(pendingList)
+	}
+}
+
+// flush 分批上报待上报的会话，返回上报失败需保留的会话
+func flush(pendingList []*node_rpc.InteractionMessage) []*node_rpc.InteractionMessage {
+	pendingList = dropInvalid(pendingList)
+	for len(pendingList) > 0 {
+		batch := pendingList[:min(len(pendingList), reportBatchSize)]
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err := global.GrpcClient.ReportInteraction(ctx, &node_rpc.ReportInteractionRequest{
+			NodeUid:         global.Config.System.Uid,
+			InteractionList: batch,
+		})
+		cancel()
+		if err != nil {
+			logrus.Errorf("上报交互会话失败 %v", err)
+			// 保留最新的会话等待下次重试，超出上限的旧会话丢弃
+			if len(pendingList) > maxPendingCount {
+				pendingList = pendingList[len(pendingList)-maxPendingCount:]
+			}
+			return pendingList
+		}
+		pendingList = pendingList[len(batch):]
+	}
+	return nil
+}
+
+// dropInvalid 丢弃无法序列化的会话（如含非法UTF-8的字段），避免整批上报反复失败阻塞后续会话
+func dropInvalid(pendingList []*node_rpc.InteractionMessage) []*node_rpc.InteractionMessage {
+	validList := pendingList[:0]
+	for _, interaction := range pendingList {
+		if _, err := proto.Marshal(interaction); err != nil {
+			logrus.Errorf("交互会话 %s 数据异常，丢弃 %v", interaction.SessionID, err)
+			continue
+		}
+		validList = append(validList, interaction)
+	}
+	return validList
+}
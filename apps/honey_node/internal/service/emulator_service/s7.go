@@ -0,0 +1,356 @@
+package emulator_service
+
+// File: service/emulator_service/s7.go
+// Description: S7comm模拟器，模拟西门子S7-300/400系列PLC，支持COTP连接、通信设置、变量读写及SZL设备标识读取，
+// 记录每个功能码及解析后的数据区、数据块、地址与值
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// S7协议常量
+const (
+	s7ProtocolID     = 0x32
+	s7RosctrJob      = 0x01 // 作业请求
+	s7RosctrAckData  = 0x03 // 带数据的应答
+	s7RosctrUserData = 0x07 // 用户数据（SZL读取等）
+	s7MaxPduLength   = 240  // 协商的PDU长度
+	s7MaxTpktLength  = 1024 // 接收的TPKT报文最大长度
+	s7MaxItemBytes   = 200  // 单个变量读取的最大字节数
+)
+
+// S7作业功能码
+const (
+	s7FuncReadVar    = 0x04
+	s7FuncWriteVar   = 0x05
+	s7FuncSetupComm  = 0xf0
+	s7FuncPiService  = 0x28
+	s7FuncPlcStop    = 0x29
+	s7FuncUploadFrom = 0x1d
+	s7FuncDownload   = 0x1a
+)
+
+// s7FunctionNameMap 作业功能码名称
+var s7FunctionNameMap = map[byte]string{
+	s7FuncReadVar:    "Read Var",
+	s7FuncWriteVar:   "Write Var",
+	s7FuncSetupComm:  "Setup Communication",
+	s7FuncPiService:  "PI Service",
+	s7FuncPlcStop:    "PLC Stop",
+	s7FuncUploadFrom: "Start Upload",
+	s7FuncDownload:   "Request Download",
+}
+
+// s7AreaMap 数据区代码对应的数据区名称
+var s7AreaMap = map[byte]string{
+	0x81: "i",  // 输入
+	0x82: "q",  // 输出
+	0x83: "m",  // 位存储区
+	0x84: "db", // 数据块
+}
+
+// s7Item S7变量读写项
+type s7Item struct {
+	Area    string `json:"area"`             // 数据区
+	DB      int    `json:"db"`               // 数据块编号
+	Address int    `json:"address"`          // 字节偏移
+	Length  int    `json:"length"`           // 字节数
+	Values  string `json:"values,omitempty"` // 读取或写入的值（十六进制）
+}
+
+// s7Operation S7读写事件内容
+type s7Operation struct {
+	Function byte     `json:"function"`        // 功能码
+	Name     string   `json:"name"`            // 功能名称
+	ItemList []s7Item `json:"itemList"`        // 变量读写项
+	SzlID    string   `json:"szlID,omitempty"` // 读取的SZL标识
+}
+
+// s7Emulator S7comm模拟器
+func s7Emulator(s *Session) {
+	for {
+		payload, err := readTpkt(s)
+		if err != nil {
+			return
+		}
+		if len(payload) < 2 {
+			return
+		}
+
+		switch payload[1] {
+		case 0xe0:
+			// COTP连接请求：回复连接确认
+			s.Event(EventRequest, "COTP connect "+hex.EncodeToString(payload))
+			if len(payload) < 7 {
+				return
+			}
+			cc := append([]byte{}, payload...)
+			cc[1] = 0xd0
+			copy(cc[2:4], payload[4:6]) // 目的引用为客户端的源引用
+			cc[4], cc[5] = 0x00, 0x01
+			if writeTpkt(s, cc) != nil {
+				return
+			}
+		case 0xf0:
+			// COTP数据：S7协议数据单元
+			if len(payload) < 3 || s7Handle(s, payload[3:]) != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// s7Handle 处理一个S7协议数据单元
+func s7Handle(s *Session, pdu []byte) error {
+	if len(pdu) < 10 || pdu[0] != s7ProtocolID {
+		return io.ErrUnexpectedEOF
+	}
+	rosctr := pdu[1]
+	pduRef := pdu[4:6]
+	paramLen := int(binary.BigEndian.Uint16(pdu[6:8]))
+	dataLen := int(binary.BigEndian.Uint16(pdu[8:10]))
+	if len(pdu) < 10+paramLen+dataLen || paramLen == 0 {
+		return io.ErrUnexpectedEOF
+	}
+	param := pdu[10 : 10+paramLen]
+	data := pdu[10+paramLen : 10+paramLen+dataLen]
+
+	if rosctr == s7RosctrUserData {
+		return s7UserData(s, pduRef, param, data)
+	}
+	if rosctr != s7RosctrJob {
+		return nil
+	}
+
+	op := s7Operation{Function: param[0], Name: s7FunctionNameMap[param[0]]}
+	switch param[0] {
+	case s7FuncSetupComm:
+		s.Operate(EventRequest, op)
+		respParam := []byte{s7FuncSetupComm, 0x00, 0x00, 0x01, 0x00, 0x01}
+		respParam = binary.BigEndian.AppendUint16(respParam, s7MaxPduLength)
+		return s7Write(s, s7RosctrAckData, pduRef, respParam, nil)
+
+	case s7FuncReadVar:
+		itemList := s7ParseItems(param)
+		var respData []byte
+		for i, item := range itemList {
+			values := make([]byte, item.Length)
+			for j := range values {
+				values[j] = byte(s.Device.Get(item.Area, item.DB, item.Address+j))
+			}
+			itemList[i].Values = hex.EncodeToString(values)
+			respData = append(respData, 0xff, 0x04)
+			respData = binary.BigEndian.AppendUint16(respData, uint16(len(values)*8))
+			respData = append(respData, values...)
+			// 非最后一项按偶数字节对齐
+			if len(values)%2 == 1 && i < len(itemList)-1 {
+				respData = append(respData, 0)
+			}
+		}
+		op.ItemList = itemList
+		s.Operate(EventRead, op)
+		return s7Write(s, s7RosctrAckData, pduRef, []byte{s7FuncReadVar, byte(len(itemList))}, respData)
+
+	case s7FuncWriteVar:
+		itemList := s7ParseItems(param)
+		var respData []byte
+		for i := range itemList {
+			// 数据项：返回码(1) 传输类型(1) 长度(2) 数据
+			if len(data) < 4 {
+				break
+			}
+			length := int(binary.BigEndian.Uint16(data[2:4]))
+			if data[1] == 0x03 || data[1] == 0x04 || data[1] == 0x05 {
+				length = (length + 7) / 8 // 长度单位为位
+			}
+			length = min(length, len(data)-4, s7MaxItemBytes)
+			values := data[4 : 4+length]
+			for j, value := range values {
+				s.Device.Set(itemList[i].Area, itemList[i].DB, itemList[i].Address+j, float64(value))
+			}
+			itemList[i].Values = hex.EncodeToString(values)
+			data = data[min(4+length+length%2, len(data)):]
+			respData = append(respData, 0xff)
+		}
+		op.ItemList = itemList
+		s.Operate(EventWrite, op)
+		return s7Write(s, s7RosctrAckData, pduRef, []byte{s7FuncWriteVar, byte(len(respData))}, respData)
+	}
+
+	// 其他作业（启停PLC、程序上传下载等）只记录，返回功能不支持
+	if op.Name == "" {
+		op.Name = "Unknown"
+	}
+	eventType := EventRequest
+	if param[0] == s7FuncPlcStop || param[0] == s7FuncPiService || param[0] == s7FuncDownload {
+		eventType = EventWrite
+	}
+	s.Operate(eventType, op)
+	return s7WriteError(s, pduRef, param[0])
+}
+
+// s7ParseItems 解析变量读写请求中的变量项（每项12字节：规格类型 长度 语法 传输类型 数量 数据块 数据区 位地址）
+func s7ParseItems(param []byte) []s7Item {
+	var itemList []s7Item
+	if len(param) < 2 {
+		return itemList
+	}
+	count := int(param[1])
+	items := param[2:]
+	for i := 0; i < count && len(items) >= 12; i++ {
+		item := items[:12]
+		items = items[12:]
+		if item[0] != 0x12 || item[2] != 0x10 {
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(item[4:6]))
+		switch item[3] {
+		case 0x01: // BIT
+			length = 1
+		case 0x04, 0x05: // WORD INT
+			length *= 2
+		case 0x06, 0x07, 0x08: // DWORD DINT REAL
+			length *= 4
+		}
+		area, ok := s7AreaMap[item[8]]
+		if !ok {
+			area = "unknown"
+		}
+		s7 := s7Item{
+			Area:    area,
+			Address: (int(item[9])<<16 | int(item[10])<<8 | int(item[11])) >> 3,
+			Length:  min(length, s7MaxItemBytes),
+		}
+		if area == "db" {
+			s7.DB = int(binary.BigEndian.Uint16(item[6:8]))
+		}
+		itemList = append(itemList, s7)
+	}
+	return itemList
+}
+
+// s7UserData 处理用户数据请求，仅支持SZL读取（设备标识）
+func s7UserData(s *Session, pduRef []byte, param []byte, data []byte) error {
+	// 参数：头(3) 长度(1) 方法(1) 类型/功能组(1) 子功能(1) 序号(1)
+	if len(param) < 8 || param[5] != 0x44 || param[6] != 0x01 || len(data) < 8 {
+		s.Event(EventRequest, "userdata "+hex.EncodeToString(param))
+		return nil
+	}
+	szlID := binary.BigEndian.Uint16(data[4:6])
+	szlIndex := binary.BigEndian.Uint16(data[6:8])
+	s.Operate(EventRead, s7Operation{Name: "Read SZL", SzlID: strconv.FormatUint(uint64(szlID), 16)})
+
+	respParam := []byte{0x00, 0x01, 0x12, 0x08, 0x12, 0x84, 0x01, param[7], 0x00, 0x00, 0x00, 0x00}
+	recordLen, recordList := s7Szl(s.Device, szlID)
+	if recordList == nil {
+		// SZL不存在
+		return s7Write(s, s7RosctrUserData, pduRef, respParam, []byte{0x0a, 0x00, 0x00, 0x00})
+	}
+	body := binary.BigEndian.AppendUint16(nil, szlID)
+	body = binary.BigEndian.AppendUint16(body, szlIndex)
+	body = binary.BigEndian.AppendUint16(body, uint16(recordLen))
+	body = binary.BigEndian.AppendUint16(body, uint16(len(recordList)/recordLen))
+	body = append(body, recordList...)
+	respData := []byte{0xff, 0x09}
+	respData = binary.BigEndian.AppendUint16(respData, uint16(len(body)))
+	return s7Write(s, s7RosctrUserData, pduRef, respParam, append(respData, body...))
+}
+
+// firmwareRegexp 固件版本中的数字
+var firmwareRegexp = regexp.MustCompile(`\d+`)
+
+// s7Szl 构建SZL记录：0x0011模块标识（订货号、固件版本），0x001C组件标识（名称、序列号、版权）
+func s7Szl(d *Device, szlID uint16) (recordLen int, recordList []byte) {
+	// 定长字符串：订货号以空格补齐，组件名称以0补齐
+	padding := func(str string, size int, pad byte) []byte {
+		buf := bytes.Repeat([]byte{pad}, size)
+		copy(buf, str)
+		return buf
+	}
+	switch szlID {
+	case 0x0011:
+		version := []byte{'V', 0, 0, 0}
+		for i, v := range firmwareRegexp.FindAllString(d.Firmware, 3) {
+			n, _ := strconv.Atoi(v)
+			version[i+1] = byte(n)
+		}
+		for _, index := range []uint16{0x0001, 0x0006, 0x0007} {
+			recordList = binary.BigEndian.AppendUint16(recordList, index)
+			if index == 0x0007 {
+				recordList = append(recordList, padding("", 20, ' ')...)
+				recordList = append(recordList, 0x00, 0xc0)
+				recordList = append(recordList, version...)
+				continue
+			}
+			recordList = append(recordList, padding(d.Model, 20, ' ')...)
+			recordList = append(recordList, 0x00, 0xc0, 0x00, 0x01, 0x00, 0x00)
+		}
+		return 28, recordList
+	case 0x001c:
+		for index, value := range []string{
+			1: d.Model,                               // 系统名称
+			2: d.Model,                               // 模块名称
+			4: "Original " + d.Vendor + " Equipment", // 版权
+			5: d.SerialNumber,                        // 序列号
+			7: d.Model,                               // 模块类型名称
+		} {
+			if value == "" {
+				continue
+			}
+			recordList = binary.BigEndian.AppendUint16(recordList, uint16(index))
+			recordList = append(recordList, padding(value, 32, 0)...)
+		}
+		return 34, recordList
+	}
+	return 0, nil
+}
+
+// s7WriteError 回复作业失败（功能不支持）
+func s7WriteError(s *Session, pduRef []byte, function byte) error {
+	header := []byte{s7ProtocolID, s7RosctrAckData, 0x00, 0x00, pduRef[0], pduRef[1], 0x00, 0x01, 0x00, 0x00, 0x81, 0x04}
+	return writeTpkt(s, append([]byte{0x02, 0xf0, 0x80}, append(header, function)...))
+}
+
+// s7Write 发送S7协议数据单元（应答带错误类与错误码字段）
+func s7Write(s *Session, rosctr byte, pduRef []byte, param []byte, data []byte) error {
+	header := []byte{s7ProtocolID, rosctr, 0x00, 0x00, pduRef[0], pduRef[1]}
+	header = binary.BigEndian.AppendUint16(header, uint16(len(param)))
+	header = binary.BigEndian.AppendUint16(header, uint16(len(data)))
+	if rosctr == s7RosctrAckData {
+		header = append(header, 0x00, 0x00)
+	}
+	pdu := append(header, param...)
+	pdu = append(pdu, data...)
+	return writeTpkt(s, append([]byte{0x02, 0xf0, 0x80}, pdu...))
+}
+
+// readTpkt 读取一个TPKT报文，返回其中的COTP内容
+func readTpkt(s *Session) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(s.Reader, header); err != nil {
+		return nil, err
+	}
+	length := int(binary.BigEndian.Uint16(header[2:4]))
+	if header[0] != 0x03 || length < 4 || length > s7MaxTpktLength {
+		return nil, io.ErrUnexpectedEOF
+	}
+	payload := make([]byte, length-4)
+	if _, err := io.ReadFull(s.Reader, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// writeTpkt 添加TPKT报文头后发送
+func writeTpkt(s *Session, payload []byte) error {
+	packet := binary.BigEndian.AppendUint16([]byte{0x03, 0x00}, uint16(len(payload)+4))
+	_, err := s.Conn.Write(append(packet, payload...))
+	return err
+}
@@ -0,0 +1,208 @@
+package emulator_service
+
+// File: service/emulator_service/session.go
+// Description: 模拟器会话，封装客户端连接的超时控制、按行读取及交互事件记录
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"honey_node/internal/global"
+	"honey_node/internal/rpc/node_rpc"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// 交互事件类型
+const (
+	EventAuth    = "auth"    // 认证尝试
+	EventCommand = "command" // 命令执行
+	EventRequest = "request" // 协议请求
+	EventRead    = "read"    // 工控协议读操作
+	EventWrite   = "write"   // 工控协议写操作
+)
+
+const (
+	defaultIdleTimeout = 60 * time.Second // 未配置时的默认会话空闲超时
+	defaultMaxDuration = 10 * time.Minute // 未配置时的默认会话最长持续时间
+	maxLineSize        = 4096             // 单行输入的最大长度
+	maxEventCount      = 200              // 单个会话记录的最大事件数，超出后不再记录
+	maxEventDataSize   = 1024             // 单个事件内容的最大长度
+)
+
+var errLineTooLong = errors.New("输入行过长")
+
+// Session 模拟器会话（对应一个客户端连接）
+type Session struct {
+	ID        string        // 会话ID
+	Protocol  string        // 模拟器协议
+	Conn      net.Conn      // 带超时控制的客户端连接
+	Reader    *bufio.Reader // 客户端输入缓冲
+	Device    *Device       // 模拟的设备（同一端口上的会话共享）
+	startTime time.Time
+	eventList []*node_rpc.InteractionEventMessage
+}
+
+// authData 认证事件内容
+type authData struct {
+	Username string `json:"username"`       // 用户名
+	Password string `json:"password"`       // 明文口令
+	Hash     string `json:"hash,omitempty"` // 无法获取明文时记录的口令摘要（如MySQL的挑战值与应答）
+}
+
+// newSession 创建会话，会话的每次读写都会刷新空闲超时，且不超过会话最长持续时间
+func newSession(conn net.Conn, protocol string) *Session {
+	cfg := global.Config.Emulator
+	idleTimeout := defaultIdleTimeout
+	if cfg.IdleTimeout > 0 {
+		idleTimeout = time.Duration(cfg.IdleTimeout) * time.Second
+	}
+	maxDuration := defaultMaxDuration
+	if cfg.MaxDuration > 0 {
+		maxDuration = time.Duration(cfg.MaxDuration) * time.Second
+	}
+
+	now := time.Now()
+	c := &deadlineConn{
+		Conn:        conn,
+		idleTimeout: idleTimeout,
+		endTime:     now.Add(maxDuration),
+	}
+	return &Session{
+		ID:        uuid.New().String(),
+		Protocol:  protocol,
+		Conn:      c,
+		Reader:    bufio.NewReader(c),
+		startTime: now,
+	}
+}
+
+// Event 记录一条交互事件，事件内容来自攻击者输入，替换非法UTF-8字节后按字符边界截断（proto3的string字段要求合法UTF-8）
+func (s *Session) Event(eventType string, data string) {
+	if len(s.eventList) >= maxEventCount {
+		return
+	}
+	data = truncateUTF8(strings.ToValidUTF8(data, "\uFFFD"), maxEventDataSize)
+	s.eventList = append(s.eventList, &node_rpc.InteractionEventMessage{
+		Time:      time.Now().UnixMilli(),
+		EventType: eventType,
+		Data:      data,
+	})
+}
+
+// Auth 记录一次明文口令的认证尝试
+func (s *Session) Auth(username, password string) {
+	byteData, _ := json.Marshal(authData{Username: username, Password: password})
+	s.Event(EventAuth, string(byteData))
+}
+
+// AuthHash 记录一次无法获取明文口令的认证尝试
+func (s *Session) AuthHash(username, hash string) {
+	byteData, _ := json.Marshal(authData{Username: username, Hash: hash})
+	s.Event(EventAuth, string(byteData))
+}
+
+// Operate 记录一次工控协议的读写操作，data为解析后的操作内容
+func (s *Session) Operate(eventType string, data any) {
+	byteData, _ := json.Marshal(data)
+	s.Event(eventType, string(byteData))
+}
+
+// ReadLine 读取一行输入（不含行尾换行符）
+func (s *Session) ReadLine() (string, error) {
+	var line []byte
+	for {
+		part, isPrefix, err := s.Reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		line = append(line, part...)
+		if len(line) > maxLineSize {
+			return "", errLineTooLong
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+// Write 向客户端写入格式化内容
+func (s *Session) Write(format string, a ...any) error {
+	_, err := fmt.Fprintf(s.Conn, format, a...)
+	return err
+}
+
+// RemoteIP 攻击者IP
+func (s *Session) RemoteIP() string {
+	host, _, _ := net.SplitHostPort(s.Conn.RemoteAddr().String())
+	return host
+}
+
+// close 关闭连接并上报本次会话的交互记录
+func (s *Session) close() {
+	s.Conn.Close()
+
+	srcIP, srcPort := splitAddr(s.Conn.RemoteAddr())
+	dstIP, dstPort := splitAddr(s.Conn.LocalAddr())
+	report(&node_rpc.InteractionMessage{
+		SessionID: s.ID,
+		Protocol:  s.Protocol,
+		SrcIP:     srcIP,
+		SrcPort:   int32(srcPort),
+		DstIP:     dstIP,
+		DstPort:   int32(dstPort),
+		StartTime: s.startTime.UnixMilli(),
+		EndTime:   time.Now().UnixMilli(),
+		EventList: s.eventList,
+	})
+}
+
+// truncateUTF8 将字符串截断到不超过size字节，且不拆分多字节字符
+func truncateUTF8(str string, size int) string {
+	if len(str) <= size {
+		return str
+	}
+	for size > 0 && !utf8.RuneStart(str[size]) {
+		size--
+	}
+	return str[:size]
+}
+
+// splitAddr 拆分连接地址的IP与端口
+func splitAddr(addr net.Addr) (string, int) {
+	host, portStr, _ := net.SplitHostPort(addr.String())
+	port, _ := strconv.Atoi(portStr)
+	return host, port
+}
+
+// deadlineConn 读写前自动刷新超时时间的连接
+type deadlineConn struct {
+	net.Conn
+	idleTimeout time.Duration // 空闲超时
+	endTime     time.Time     // 会话最晚结束时间
+}
+
+// refresh 将连接超时设置为空闲超时与会话最晚结束时间中较早的一个
+func (c *deadlineConn) refresh() {
+	deadline := time.Now().Add(c.idleTimeout)
+	if deadline.After(c.endTime) {
+		deadline = c.endTime
+	}
+	c.Conn.SetDeadline(deadline)
+}
+
+func (c *deadlineConn) Read(b []byte) (int, error) {
+	c.refresh()
+	return c.Conn.Read(b)
+}
+
+func (c *deadlineConn) Write(b []byte) (int, error) {
+	c.refresh()
+	return c.Conn.Write(b)
+}
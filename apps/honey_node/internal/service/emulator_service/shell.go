@@ -0,0 +1,139 @@
+package emulator_service
+
+// File: service/emulator_service/shell.go
+// Description: 模拟交互式shell（SSH、Telnet共用），记录攻击者输入的每条命令，对常见的侦察命令返回伪造的输出
+
+import (
+	"fmt"
+	"honey_node/internal/global"
+	"strings"
+	"sync"
+)
+
+const (
+	acceptAuthAttempt = 3     // 同一来源IP第几次认证尝试时接受任意口令（此前的尝试均返回失败，以收集更多口令）
+	maxAuthRecord     = 10000 // 认证次数记录的最大来源IP数，超出后清空重新计数
+)
+
+var (
+	authMu        sync.Mutex
+	authRecordMap = map[string]int{} // 来源IP的累计认证尝试次数（跨连接，多数爆破工具每次连接只尝试一个口令）
+)
+
+// authAccepted 记录来源IP的一次认证尝试，返回本次是否接受
+func authAccepted(ip string) bool {
+	authMu.Lock()
+	defer authMu.Unlock()
+	if len(authRecordMap) >= maxAuthRecord {
+		authRecordMap = map[string]int{}
+	}
+	authRecordMap[ip]++
+	return authRecordMap[ip] >= acceptAuthAttempt
+}
+
+// terminal 行式终端，由SSH、Telnet各自实现输入回显与换行处理
+type terminal interface {
+	ReadLine() (string, error)
+	Write(p []byte) (int, error)
+}
+
+// hostName 模拟的主机名
+func hostName() string {
+	if name := global.Config.Emulator.HostName; name != "" {
+		return name
+	}
+	return "ubuntu-server"
+}
+
+// shellPrompt 模拟shell的提示符
+func shellPrompt(user string) string {
+	if user == "root" {
+		return fmt.Sprintf("root@%s:~# ", hostName())
+	}
+	return fmt.Sprintf("%s@%s:~$ ", user, hostName())
+}
+
+// motd 登录成功后的欢迎信息
+func motd() string {
+	return "Welcome to Ubuntu 22.04.3 LTS (GNU/Linux 5.15.0-91-generic x86_64)\n\n" +
+		" * Documentation:  https://help.ubuntu.com\n" +
+		" * Management:     https://landscape.canonical.com\n" +
+		" * Support:        https://ubuntu.com/advantage\n\n" +
+		"Last login: Mon Oct 14 09:12:37 2024 from 10.0.0.12\n"
+}
+
+// runShell 运行模拟shell直到客户端退出或连接断开
+func runShell(s *Session, t terminal, user string) {
+	for {
+		line, err := t.ReadLine()
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		s.Event(EventCommand, line)
+
+		output, exit := execCommand(line, user)
+		if exit {
+			return
+		}
+		if output != "" {
+			t.Write([]byte(output))
+		}
+	}
+}
+
+// execCommand 返回命令的伪造输出，exit为true表示客户端请求退出
+func execCommand(line string, user string) (output string, exit bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", false
+	}
+	home := "/home/" + user
+	if user == "root" {
+		home = "/root"
+	}
+
+	switch fields[0] {
+	case "exit", "logout", "quit":
+		return "", true
+	case "whoami":
+		return user + "\n", false
+	case "id":
+		if user == "root" {
+			return "uid=0(root) gid=0(root) groups=0(root)\n", false
+		}
+		return fmt.Sprintf("uid=1000(%s) gid=1000(%s) groups=1000(%s),27(sudo)\n", user, user, user), false
+	case "hostname":
+		return hostName() + "\n", false
+	case "pwd":
+		return home + "\n", false
+	case "uname":
+		if len(fields) > 1 && strings.Contains(fields[1], "a") {
+			return fmt.Sprintf("Linux %s 5.15.0-91-generic #101-Ubuntu SMP Tue Nov 14 13:30:08 UTC 2023 x86_64 x86_64 x86_64 GNU/Linux\n", hostName()), false
+		}
+		return "Linux\n", false
+	case "ls":
+		return "backup.tar.gz  config.bak  scripts\n", false
+	case "cd", "export", "unset", "history", "clear":
+		return "", false
+	case "echo":
+		return strings.Join(fields[1:], " ") + "\n", false
+	case "cat":
+		if len(fields) > 1 && fields[1] == "/etc/passwd" {
+			return "root:x:0:0:root:/root:/bin/bash\n" +
+				"daemon:x:1:1:daemon:/usr/sbin:/usr/sbin/nologin\n" +
+				"www-data:x:33:33:www-data:/var/www:/usr/sbin/nologin\n" +
+				"ubuntu:x:1000:1000:Ubuntu:/home/ubuntu:/bin/bash\n", false
+		}
+		if len(fields) > 1 {
+			return fmt.Sprintf("cat: %s: Permission denied\n", fields[1]), false
+		}
+		return "", false
+	case "wget", "curl":
+		return fmt.Sprintf("%s: unable to resolve host address\n", fields[0]), false
+	}
+	return fmt.Sprintf("-bash: %s: command not found\n", fields[0]), false
+}
@@ -0,0 +1,154 @@
+package emulator_service
+
+// File: service/emulator_service/ssh.go
+// Description: SSH模拟器，完成真实的SSH握手以收集口令与公钥认证尝试，认证通过后提供模拟shell及命令执行
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"honey_node/internal/global"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+)
+
+const (
+	sshServerVersion  = "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.6" // 模拟的SSH服务版本
+	defaultSshHostKey = "ssh_host_key"                            // 未配置时的默认主机私钥文件
+)
+
+var (
+	sshSignerOnce sync.Once
+	sshSigner     ssh.Signer
+	sshSignerErr  error
+)
+
+// getSshSigner 加载SSH主机私钥，文件不存在时生成并保存，保证节点重启后主机指纹不变
+func getSshSigner() (ssh.Signer, error) {
+	sshSignerOnce.Do(func() {
+		path := global.Config.Emulator.SshHostKey
+		if path == "" {
+			path = defaultSshHostKey
+		}
+
+		byteData, err := os.ReadFile(path)
+		if err == nil {
+			sshSigner, sshSignerErr = ssh.ParsePrivateKey(byteData)
+			return
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			sshSignerErr = err
+			return
+		}
+
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			sshSignerErr = err
+			return
+		}
+		block, err := ssh.MarshalPrivateKey(key, "")
+		if err != nil {
+			sshSignerErr = err
+			return
+		}
+		if err = os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+			logrus.Warnf("保存SSH主机私钥失败 %s", err)
+		}
+		sshSigner, sshSignerErr = ssh.NewSignerFromKey(key)
+	})
+	return sshSigner, sshSignerErr
+}
+
+// sshEmulator SSH模拟器
+func sshEmulator(s *Session) {
+	signer, err := getSshSigner()
+	if err != nil {
+		logrus.Errorf("加载SSH主机私钥失败 %s", err)
+		return
+	}
+
+	config := &ssh.ServerConfig{
+		ServerVersion: sshServerVersion,
+		MaxAuthTries:  6,
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			s.Auth(conn.User(), string(password))
+			if !authAccepted(s.RemoteIP()) {
+				return nil, fmt.Errorf("口令错误")
+			}
+			return nil, nil
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			s.Event(EventAuth, fmt.Sprintf(`{"username":%q,"publicKey":%q}`, conn.User(), ssh.FingerprintSHA256(key)))
+			return nil, fmt.Errorf("不接受公钥认证")
+		},
+	}
+	config.AddHostKey(signer)
+
+	serverConn, chans, reqs, err := ssh.NewServerConn(s.Conn, config)
+	if err != nil {
+		return
+	}
+	defer serverConn.Close()
+	s.Event(EventRequest, "client "+string(serverConn.ClientVersion()))
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			// 端口转发等通道请求只记录不处理
+			s.Event(EventRequest, "channel "+newChannel.ChannelType())
+			newChannel.Reject(ssh.Prohibited, "administratively prohibited")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		sshSession(s, channel, requests, serverConn.User())
+	}
+}
+
+// sshSession 处理SSH会话通道上的请求：shell进入模拟shell，exec执行单条命令
+func sshSession(s *Session, channel ssh.Channel, requests <-chan *ssh.Request, user string) {
+	defer channel.Close()
+	for req := range requests {
+		switch req.Type {
+		case "shell":
+			req.Reply(true, nil)
+			t := term.NewTerminal(channel, shellPrompt(user))
+			t.Write([]byte(motd()))
+			runShell(s, t, user)
+			sendExitStatus(channel)
+			return
+		case "exec":
+			req.Reply(true, nil)
+			var payload struct{ Command string }
+			ssh.Unmarshal(req.Payload, &payload)
+			s.Event(EventCommand, payload.Command)
+			if payload.Command != "" {
+				output, _ := execCommand(payload.Command, user)
+				channel.Write([]byte(output))
+			}
+			sendExitStatus(channel)
+			return
+		case "pty-req", "env", "window-change":
+			req.Reply(true, nil)
+		default:
+			s.Event(EventRequest, "request "+req.Type)
+			req.Reply(false, nil)
+		}
+	}
+}
+
+// sendExitStatus 发送命令退出码0
+func sendExitStatus(channel ssh.Channel) {
+	status := make([]byte, 4)
+	binary.BigEndian.PutUint32(status, 0)
+	channel.SendRequest("exit-status", false, status)
+}
@@ -0,0 +1,135 @@
+package emulator_service
+
+// File: service/emulator_service/telnet.go
+// Description: Telnet模拟器，处理Telnet选项协商并模拟登录流程以收集口令，认证通过后提供模拟shell
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Telnet协议控制字节
+const (
+	telnetIAC  = 255 // 命令前缀
+	telnetDONT = 254
+	telnetDO   = 253
+	telnetWONT = 252
+	telnetWILL = 251
+	telnetSB   = 250 // 子协商开始
+	telnetSE   = 240 // 子协商结束
+)
+
+// telnetEmulator Telnet模拟器
+func telnetEmulator(s *Session) {
+	t := &telnetTerminal{s: s}
+	s.Write("Ubuntu 22.04.3 LTS\r\n")
+
+	for {
+		t.prompt = fmt.Sprintf("%s login: ", hostName())
+		user, err := t.ReadLine()
+		if err != nil {
+			return
+		}
+		if user == "" {
+			continue
+		}
+		t.prompt = "Password: "
+		password, err := t.ReadLine()
+		if err != nil {
+			return
+		}
+		s.Auth(user, password)
+		if authAccepted(s.RemoteIP()) {
+			t.Write([]byte("\n" + motd()))
+			t.prompt = shellPrompt(user)
+			runShell(s, t, user)
+			return
+		}
+		s.Write("\r\nLogin incorrect\r\n")
+	}
+}
+
+// telnetTerminal Telnet行式终端：读取前输出提示符，过滤选项协商字节，输出时将换行转换为CRLF
+type telnetTerminal struct {
+	s      *Session
+	prompt string
+}
+
+// ReadLine 输出提示符后读取一行输入，客户端的选项协商一律拒绝
+func (t *telnetTerminal) ReadLine() (string, error) {
+	if err := t.s.Write("%s", t.prompt); err != nil {
+		return "", err
+	}
+
+	var line []byte
+	for {
+		b, err := t.s.Reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch b {
+		case telnetIAC:
+			if err = t.negotiate(); err != nil {
+				return "", err
+			}
+		case '\r':
+			// 行尾为CR LF或CR NUL
+			if next, err := t.s.Reader.Peek(1); err == nil && (next[0] == '\n' || next[0] == 0) {
+				t.s.Reader.ReadByte()
+			}
+			return string(line), nil
+		case '\n':
+			return string(line), nil
+		case 0:
+		default:
+			line = append(line, b)
+			if len(line) > maxLineSize {
+				return "", errLineTooLong
+			}
+		}
+	}
+}
+
+// negotiate 处理IAC之后的命令：拒绝客户端请求的选项，跳过子协商内容
+func (t *telnetTerminal) negotiate() error {
+	cmd, err := t.s.Reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch cmd {
+	case telnetDO, telnetDONT, telnetWILL, telnetWONT:
+		option, err := t.s.Reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if cmd == telnetDO {
+			t.s.Conn.Write([]byte{telnetIAC, telnetWONT, option})
+		}
+		if cmd == telnetWILL {
+			t.s.Conn.Write([]byte{telnetIAC, telnetDONT, option})
+		}
+	case telnetSB:
+		// 子协商内容直到 IAC SE
+		var last byte
+		for n := 0; ; n++ {
+			b, err := t.s.Reader.ReadByte()
+			if err != nil {
+				return err
+			}
+			if last == telnetIAC && b == telnetSE {
+				return nil
+			}
+			if n > maxLineSize {
+				return errLineTooLong
+			}
+			last = b
+		}
+	}
+	return nil
+}
+
+// Write 输出内容，换行转换为CRLF
+func (t *telnetTerminal) Write(p []byte) (int, error) {
+	_, err := t.s.Conn.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n")))
+	return len(p), err
+}
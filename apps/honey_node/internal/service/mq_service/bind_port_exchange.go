@@ -1,13 +1,14 @@
 package mq_service
 
 // File: service/mq_service/bind_port_exchange.go
-// Description: 处理端口绑定的消息，解析端口转发配置并启动TCP隧道转发服务，实现本地端口到目标地址的映射
+// Description: 处理端口绑定的消息，解析端口转发配置并启动TCP隧道转发服务或内置模拟器，实现本地端口到目标地址的映射，并上报每个端口的绑定状态
 
 import (
 	"encoding/json"
 	"fmt"
 	"honey_node/internal/global"
 	"honey_node/internal/models"
+	"honey_node/internal/rpc/node_rpc"
 	"honey_node/internal/service/port_service"
 
 	"github.com/sirupsen/logrus"
@@ -22,10 +23,12 @@ type BindPortRequest struct {
 
 // PortInfo 单个端口的转发配置结构体
 type PortInfo struct {
-	IP       string `json:"ip"`       // 本地监听的源IP地址
-	Port     int    `json:"port"`     // 本地监听的源端口号
-	DestIP   string `json:"destIP"`   // 转发的目标IP地址
-	DestPort int    `json:"destPort"` // 转发的目标端口号
+	IP             string          `json:"ip"`             // 本地监听的源IP地址
+	Port           int             `json:"port"`           // 本地监听的源端口号
+	DestIP         string          `json:"destIP"`         // 转发的目标IP地址
+	DestPort       int             `json:"destPort"`       // 转发的目标端口号
+	Emulator       string          `json:"emulator"`       // 内置模拟器协议，不为空时直接在本地模拟该协议，不建立隧道
+	EmulatorConfig json.RawMessage `json:"emulatorConfig"` // 内置模拟器的设备配置（工控协议的设备标识及数据点）
 }
 
 // LocalAddr 拼接本地监听的地址字符串（IP:Port）
@@ -56,21 +59,26 @@ func BindPortExChange(msg string) error {
 	// 把这个IP上的服务全部停掉
 	port_service.CloseIpTunnel(req.IP)
 
-	// 遍历端口转发配置列表，为每个端口启动独立的转发服务
+	// 遍历端口转发配置列表，为每个端口启动受监管的转发服务，收集首次监听结果
+	var statusList []*node_rpc.BindPortStatusMessage
 	for _, port := range req.PortList {
-		global.DB.Create(&models.PortModel{
+		model := models.PortModel{
 			TargetAddr: port.TargetAddr(),
 			LocalAddr:  port.LocalAddr(),
-		})
-		// 使用goroutine异步启动每个端口的转发，避免阻塞消息处理
-		go func(port PortInfo) {
-			// 调用port_service的Tunnel方法，建立本地端口到目标地址的TCP转发隧道
-			err := port_service.Tunnel(port.LocalAddr(), port.TargetAddr())
-			if err != nil {
-				logrus.Errorf("端口绑定失败 %s", err)
-			}
-		}(port)
+			Emulator:   port.Emulator,
+		}
+		if len(port.EmulatorConfig) > 0 && string(port.EmulatorConfig) != "null" {
+			model.EmulatorConfig = string(port.EmulatorConfig)
+		}
+		global.DB.Create(&model)
+		// 建立本地端口的转发隧道或内置模拟器，监听异常时自动按退避策略重启并上报状态变化
+		err := port_service.Bind(model)
+		if err != nil {
+			logrus.Errorf("端口绑定失败 %s", err)
+		}
+		statusList = append(statusList, port_service.PortStatus(port.Port, err))
 	}
 
-	return nil
+	// 上报各端口的绑定状态，上报失败时返回错误由消息重试机制重新处理
+	return port_service.ReportStatus(req.IP, statusList)
 }
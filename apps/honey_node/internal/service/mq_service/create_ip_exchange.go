@@ -12,6 +12,7 @@ import (
 	"honey_node/internal/rpc/node_rpc"
 	"honey_node/internal/service/ip_service"
 	"net"
+	"sync"
 
 	"github.com/j-keck/arping"
 	"github.com/sirupsen/logrus"
@@ -27,13 +28,58 @@ type CreateIPRequest struct {
 	IsTan     bool   `json:"isTan"`     // 是否为探针IP（探针IP无需创建新接口，复用主接口）
 }
 
-// CreateIpExChange 处理创建IP的消息消费逻辑
+// CreateIPBatchRequest 批量创建IP的消息请求结构体
+type CreateIPBatchRequest struct {
+	IpList []CreateIPRequest `json:"ipList"` // 待创建的诱捕IP列表
+	LogID  string            `json:"logID"`  // 日志追踪ID
+}
+
+// createIPWorkers 批量创建时的并发数
+const createIPWorkers = 4
+
+// CreateIpExChange 处理创建IP的消息消费逻辑，兼容单个创建与批量创建两种消息体
 func CreateIpExChange(msg string) error {
-	var req CreateIPRequest
-	if err := json.Unmarshal([]byte(msg), &req); err != nil {
+	var batch CreateIPBatchRequest
+	if err := json.Unmarshal([]byte(msg), &batch); err != nil {
 		logrus.Errorf("JSON解析失败: %v, 消息: %s", err, msg)
 		return nil // 解析失败返回nil，避免消息重复处理
 	}
+	if len(batch.IpList) == 0 {
+		var req CreateIPRequest
+		json.Unmarshal([]byte(msg), &req)
+		return createIP(req)
+	}
+
+	// 批量创建：有限并发处理，每个IP独立上报状态；任一IP上报失败时返回错误，
+	// 消息重试时已创建成功的IP会因幂等判断直接重新上报
+	logrus.Infof("批量创建诱捕ip %d个", len(batch.IpList))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, createIPWorkers)
+	)
+	for _, req := range batch.IpList {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(req CreateIPRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := createIP(req); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(req)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// createIP 处理单个诱捕IP的创建
+func createIP(req CreateIPRequest) error {
 
 	// 探针IP处理逻辑：无需创建新接口，直接获取主接口MAC地址并上报
 	if req.IsTan {
@@ -50,6 +96,16 @@ func CreateIpExChange(msg string) error {
 		"logID":     req.LogID,
 	}).Info("开始处理创建IP请求")
 
+	// 生成唯一的macvlan子接口名称（格式：hy_+诱捕IPID）
+	linkName := fmt.Sprintf("hy_%d", req.HoneyIPID)
+
+	// 幂等处理：接口已创建（上次处理成功但状态上报失败后重试），直接重新上报，避免重复执行SetIp
+	var ipModel models.IpModel
+	if err := global.DB.Take(&ipModel, "link_name = ?", linkName).Error; err == nil {
+		logrus.Infof("诱捕ip接口已存在，重新上报状态 %s", linkName)
+		return reportStatus(req.HoneyIPID, linkName, ipModel.Mac, "")
+	}
+
 	// ARP冲突检测：通过arping检查目标IP是否已被局域网内其他设备占用
 	_mac, _, err := arping.PingOverIfaceByName(net.ParseIP(req.IP), req.Network)
 	if err == nil {
@@ -58,9 +114,6 @@ func CreateIpExChange(msg string) error {
 		return reportStatus(req.HoneyIPID, "", _mac.String(), err.Error()) // 上报IP冲突错误
 	}
 
-	// 生成唯一的macvlan子接口名称（格式：hy_+诱捕IPID）
-	linkName := fmt.Sprintf("hy_%d", req.HoneyIPID)
-
 	// 调用ip_service创建macvlan接口并配置IP
 	mac, err := ip_service.SetIp(ip_service.SetIpRequest{
 		Ip:       req.IP,
@@ -5,20 +5,57 @@ package mq_service
 
 import (
 	"fmt"
+	"honey_node/internal/core"
 	"honey_node/internal/global"
 
 	"github.com/sirupsen/logrus"
 )
 
-// Run 启动消息队列消费服务
-func Run() {
+// Handler 业务消息处理函数
+type Handler func(msg string) error
+
+// handlerMap 返回消息主题（RabbitMQ模式下即交换器名称）到业务处理函数的映射
+func handlerMap() map[string]Handler {
 	cfg := global.Config.MQ
-	// 启动创建IP消息的消费协程
-	go register(cfg.CreateIpExchangeName, CreateIpExChange)
-	// 启动删除IP消息的消费协程
-	go register(cfg.DeleteIpExchangeName, DeleteIpExChange)
-	// 启动绑定端口消息的消费协程
-	go register(cfg.BindPortExchangeName, BindPortExChange)
+	return map[string]Handler{
+		cfg.CreateIpExchangeName: CreateIpExChange, // 创建IP消息
+		cfg.DeleteIpExchangeName: DeleteIpExChange, // 删除IP消息
+		cfg.BindPortExchangeName: BindPortExChange, // 绑定端口消息
+	}
+}
+
+// Run 根据配置的消息总线类型启动业务消息消费服务
+// RabbitMQ模式下为每个业务启动消费协程；gRPC模式下消息经命令流到达，由HandleMessage处理；内存模式仅用于测试
+func Run() {
+	busType := global.Config.MQ.BusType()
+	logrus.Infof("消息总线类型 %s", busType)
+	if busType != "rabbitmq" {
+		return
+	}
+
+	// 初始化消息队列：建立与RabbitMQ的连接
+	global.Queue = core.InitMQ()
+	for topic, fun := range handlerMap() {
+		go register(topic, fun)
+	}
+}
+
+// HandleMessage 处理经gRPC命令流或内存总线投递的业务消息，按消息ID做幂等判断
+// 返回错误时由服务端发件箱负责重试
+func HandleMessage(topic string, messageID string, body string) error {
+	fun, ok := handlerMap()[topic]
+	if !ok {
+		return fmt.Errorf("未知的消息主题 %s", topic)
+	}
+	if messageID != "" && isProcessed(messageID) {
+		logrus.Infof("消息已处理，跳过 %s", messageID)
+		return nil
+	}
+	if err := fun(body); err != nil {
+		return err
+	}
+	markProcessed(topic, messageID)
+	return nil
 }
 
 // register 注册单个业务的消息消费处理器
@@ -63,6 +100,11 @@ func register(exChangeName string, fun func(msg string) error) {
 		logrus.Fatalf("绑定队列失败 %s", err)
 	}
 
+	// 声明死信交换器与延迟重试队列
+	if err = declareRetry(exChangeName, queue.Name); err != nil {
+		logrus.Fatalf("%s %s", exChangeName, err)
+	}
+
 	// 注册消费者，开始监听队列消息（关闭自动确认，手动控制消息ack）
 	msgs, err := global.Queue.Consume(
 		queue.Name, // 消费的队列名称
@@ -79,16 +121,8 @@ func register(exChangeName string, fun func(msg string) error) {
 		logrus.Infof("绑定交换器成功 %s", exChangeName)
 	}
 
-	// 循环消费队列中的消息
+	// 循环消费队列中的消息（幂等判断、失败重试及死信处理见handleDelivery）
 	for d := range msgs {
-		// 调用业务处理函数处理消息内容
-		err = fun(string(d.Body))
-		if err == nil {
-			// 消息处理成功：手动确认消息（false表示仅确认当前消息）
-			d.Ack(false)
-			continue
-		}
-		// 消息处理失败：拒绝消息并重新入队（false仅拒绝当前消息，true重新入队）
-		d.Nack(false, true)
+		handleDelivery(exChangeName, queue.Name, d, fun)
 	}
 }
@@ -0,0 +1,293 @@
+package mq_service
+
+// File: service/mq_service/retry.go
+// Description: 消息消费的幂等与失败处理逻辑：按消息ID查询已处理台账避免重复执行，
+// 处理失败时携带重试次数头投递到对应延迟档位的重试队列（指数退避），超过最大重试次数后投递到死信交换器，
+// 重试及死信消息经开启发布确认的独立通道发布，broker确认后才确认原消息
+
+import (
+	"errors"
+	"fmt"
+	"honey_node/internal/core"
+	"honey_node/internal/global"
+	"honey_node/internal/models"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+const (
+	retryCountHeader     = "x-retry-count"     // 重试次数消息头
+	errorMsgHeader       = "x-error"           // 最近一次处理失败原因消息头
+	originExchangeHeader = "x-origin-exchange" // 原始交换器消息头（死信消息使用）
+	nodeUidHeader        = "x-node-uid"        // 节点UID消息头（死信消息使用）
+
+	defaultMaxRetry   = 5                // 未配置时的默认最大重试次数
+	defaultRetryDelay = 5 * time.Second  // 未配置时的默认首次重试延迟
+	maxRetryDelay     = 10 * time.Minute // 重试延迟上限
+
+	confirmTimeout = 10 * time.Second // 等待broker发布确认的超时时间
+	requeueDelay   = 5 * time.Second  // 重试或死信投递失败后，退回原队列前的等待时间
+)
+
+// deadLetterExchangeName 死信交换器名称（与服务端约定：业务交换器名称 + _dlx）
+func deadLetterExchangeName(exChangeName string) string {
+	return exChangeName + "_dlx"
+}
+
+// retryQueueName 延迟重试队列名称（每个节点、每个业务、每个延迟档位独立）
+func retryQueueName(queueName string, delay time.Duration) string {
+	return fmt.Sprintf("%s_retry_%ds", queueName, int(delay.Seconds()))
+}
+
+// declareRetry 声明死信交换器与各延迟档位的重试队列
+// 重试队列无消费者，消息到达队列级过期时间后经默认交换器回到业务队列重新消费
+// 同一队列内的消息延迟相同，不会出现延迟较长的消息阻塞其后消息过期的问题
+func declareRetry(exChangeName string, queueName string) error {
+	// 死信交换器使用fanout类型，服务端绑定后即可收到所有节点的死信消息
+	err := global.Queue.ExchangeDeclare(
+		deadLetterExchangeName(exChangeName), // 死信交换器名称
+		"fanout",                             // 交换器类型：fanout（广播到所有绑定队列）
+		true,                                 // 持久化
+		false,                                // 自动删除：否
+		false,                                // 内部交换器：否
+		false,                                // 非阻塞：否
+		nil,                                  // 额外参数：无
+	)
+	if err != nil {
+		return fmt.Errorf("声明死信交换器失败 %s", err)
+	}
+
+	for _, delay := range retryDelayList() {
+		_, err = global.Queue.QueueDeclare(
+			retryQueueName(queueName, delay), // 延迟重试队列名称
+			true,                             // 持久化队列
+			false,                            // 自动删除：否
+			false,                            // 排他性：否
+			false,                            // 非阻塞：否
+			amqp.Table{
+				"x-message-ttl":             delay.Milliseconds(), // 队列内消息的过期时间（毫秒），到期后回到业务队列
+				"x-dead-letter-exchange":    "",                   // 过期后投递到默认交换器
+				"x-dead-letter-routing-key": queueName,            // 默认交换器按队列名路由回业务队列
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("声明重试队列失败 %s", err)
+		}
+	}
+	return nil
+}
+
+// handleDelivery 处理单条消息：幂等判断 → 业务处理 → 成功记台账 / 失败重试或转入死信
+// 无论结果如何，原消息都会被确认，避免失败消息在业务队列中热循环重投
+func handleDelivery(exChangeName string, queueName string, d amqp.Delivery, fun func(msg string) error) {
+	// 幂等判断：消息ID已在台账中说明此前已处理成功，直接确认
+	if d.MessageId != "" && isProcessed(d.MessageId) {
+		logrus.Infof("消息已处理，跳过 %s", d.MessageId)
+		d.Ack(false)
+		return
+	}
+
+	err := fun(string(d.Body))
+	if err == nil {
+		markProcessed(exChangeName, d.MessageId)
+		d.Ack(false)
+		return
+	}
+
+	retryCount := getRetryCount(d.Headers)
+	if retryCount < maxRetry() {
+		err = publishRetry(queueName, d, retryCount+1, err)
+	} else {
+		err = publishDeadLetter(exChangeName, d, retryCount, err)
+	}
+	if err != nil {
+		// 重试或死信投递失败时等待一段时间再退回原队列，保证消息不丢失且不会热循环重投
+		logrus.Errorf("消息失败处理投递失败，%s后退回原队列 %s", requeueDelay, err)
+		time.Sleep(requeueDelay)
+		d.Nack(false, true)
+		return
+	}
+	d.Ack(false)
+}
+
+// confirmPublisher 开启发布确认模式的消息发布器，使用独立的连接，多个消费协程共用
+type confirmPublisher struct {
+	mu       sync.Mutex
+	conn     *amqp.Connection       // 发布器独立持有的连接
+	ch       *amqp.Channel          // 开启发布确认模式的消息通道
+	confirms chan amqp.Confirmation // broker发布确认通知
+}
+
+// publisher 重试及死信消息的发布器
+var publisher = &confirmPublisher{}
+
+// open 建立消息通道并开启发布确认模式
+func (p *confirmPublisher) open() error {
+	conn, ch, err := core.ConnectMQ()
+	if err != nil {
+		return err
+	}
+	if err = ch.Confirm(false); err != nil {
+		conn.Close()
+		return fmt.Errorf("开启发布确认失败 %s", err)
+	}
+	p.conn = conn
+	p.ch = ch
+	p.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+	return nil
+}
+
+// reset 关闭当前消息通道及连接，下次发布时重新建立连接
+func (p *confirmPublisher) reset() {
+	if p.ch != nil {
+		p.ch.Close()
+	}
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.ch = nil
+	p.conn = nil
+}
+
+// Publish 发布单条消息并同步等待broker确认，发布串行执行以保证确认序号与消息一一对应
+func (p *confirmPublisher) Publish(exchange string, key string, msg amqp.Publishing) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ch == nil {
+		if err := p.open(); err != nil {
+			return err
+		}
+	}
+	if err := p.ch.Publish(exchange, key, false, false, msg); err != nil {
+		p.reset()
+		return err
+	}
+
+	select {
+	case confirm, ok := <-p.confirms:
+		if !ok {
+			p.reset()
+			return errors.New("消息通道已关闭")
+		}
+		if !confirm.Ack {
+			return errors.New("broker拒绝接收消息")
+		}
+		return nil
+	case <-time.After(confirmTimeout):
+		// 超时后确认序号无法再与消息对应，需要重建通道
+		p.reset()
+		return errors.New("等待broker确认超时")
+	}
+}
+
+// publishRetry 将消息投递到对应延迟档位的重试队列，延迟时间按重试次数指数递增
+func publishRetry(queueName string, d amqp.Delivery, retryCount int, cause error) error {
+	delay := retryDelay(retryCount)
+	logrus.Warnf("消息处理失败，%s后第%d次重试 %s: %s", delay, retryCount, d.MessageId, cause)
+	return publisher.Publish(
+		"",                               // 默认交换器：按队列名直接路由
+		retryQueueName(queueName, delay), // 延迟重试队列
+		amqp.Publishing{
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    d.MessageId,
+			Timestamp:    d.Timestamp,
+			Headers: amqp.Table{
+				retryCountHeader: int32(retryCount),
+				errorMsgHeader:   cause.Error(),
+			},
+			Body: d.Body,
+		})
+}
+
+// publishDeadLetter 将超过最大重试次数的消息投递到死信交换器，由服务端收集后查看或重放
+func publishDeadLetter(exChangeName string, d amqp.Delivery, retryCount int, cause error) error {
+	logrus.Errorf("消息超过最大重试次数，转入死信 %s: %s", d.MessageId, cause)
+	return publisher.Publish(
+		deadLetterExchangeName(exChangeName), // 死信交换器
+		global.Config.System.Uid,             // 路由键：节点UID
+		amqp.Publishing{
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    d.MessageId,
+			Timestamp:    time.Now(),
+			Headers: amqp.Table{
+				retryCountHeader:     int32(retryCount),
+				errorMsgHeader:       cause.Error(),
+				originExchangeHeader: exChangeName,
+				nodeUidHeader:        global.Config.System.Uid,
+			},
+			Body: d.Body,
+		})
+}
+
+// getRetryCount 从消息头中读取已重试次数
+func getRetryCount(headers amqp.Table) int {
+	switch v := headers[retryCountHeader].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+// maxRetry 获取最大重试次数配置
+func maxRetry() int {
+	if n := global.Config.MQ.MaxRetry; n > 0 {
+		return n
+	}
+	return defaultMaxRetry
+}
+
+// retryDelay 计算第retryCount次重试的延迟：首次延迟 * 2^(retryCount-1)，不超过上限
+func retryDelay(retryCount int) time.Duration {
+	base := defaultRetryDelay
+	if global.Config.MQ.RetryDelay > 0 {
+		base = time.Duration(global.Config.MQ.RetryDelay) * time.Second
+	}
+	delay := base << min(retryCount-1, 16)
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// retryDelayList 返回各次重试用到的延迟档位（去重），每个档位对应一个重试队列
+func retryDelayList() []time.Duration {
+	var delayList []time.Duration
+	for i := 1; i <= maxRetry(); i++ {
+		delay := retryDelay(i)
+		if len(delayList) == 0 || delayList[len(delayList)-1] != delay {
+			delayList = append(delayList, delay)
+		}
+	}
+	return delayList
+}
+
+// isProcessed 查询消息是否已处理成功
+func isProcessed(messageID string) bool {
+	var count int64
+	global.DB.Model(&models.ProcessedMessageModel{}).Where("message_id = ?", messageID).Count(&count)
+	return count > 0
+}
+
+// markProcessed 将处理成功的消息记入台账（无消息ID的旧版本消息不记录）
+func markProcessed(exChangeName string, messageID string) {
+	if messageID == "" {
+		return
+	}
+	err := global.DB.Create(&models.ProcessedMessageModel{
+		MessageID: messageID,
+		Exchange:  exChangeName,
+	}).Error
+	if err != nil {
+		logrus.Errorf("记录消息台账失败 %s %s", messageID, err)
+	}
+}
@@ -0,0 +1,33 @@
+package port_service
+
+// File: service/port_service/emulate.go
+// Description: 内置模拟器的端口监听，诱捕端口配置了模拟器协议时由节点直接模拟协议交互，不经过服务端隧道
+
+import (
+	"honey_node/internal/models"
+	"honey_node/internal/service/emulator_service"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Emulate 启动受监管的本地TCP端口监听，每个客户端连接交由指定协议的内置模拟器处理
+// config为模拟的设备配置（JSON），同一端口上的会话共享设备状态
+func Emulate(localAddr, emulator, config string, onChange func(err error)) error {
+	instance, err := emulator_service.New(emulator, config)
+	if err != nil {
+		return err
+	}
+	err = start(localAddr, instance.Serve, onChange)
+	if err == nil {
+		logrus.Infof("内置模拟器启动，地址: %s 协议: %s", localAddr, emulator)
+	}
+	return err
+}
+
+// Bind 按端口配置启动隧道转发或内置模拟器
+func Bind(model models.PortModel) error {
+	if model.Emulator != "" {
+		return Emulate(model.LocalAddr, model.Emulator, model.EmulatorConfig, OnChange(model.LocalAddr))
+	}
+	return Tunnel(model.LocalAddr, model.TargetAddr, OnChange(model.LocalAddr))
+}
@@ -1,11 +1,14 @@
 package port_service
 
 // File: service/port_service/load_tunnel.go
-// Description: 提供端口转发配置的持久化加载功能，从数据库读取历史端口转发记录并自动启动对应的隧道服务
+// Description: 提供端口转发配置的持久化加载功能，从数据库读取历史端口转发记录并自动启动对应的隧道服务，启动后上报各端口的绑定状态
 
 import (
 	"honey_node/internal/global"
 	"honey_node/internal/models"
+	"honey_node/internal/rpc/node_rpc"
+	"net"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
 )
@@ -18,9 +21,17 @@ func LoadTunnel() {
 	global.DB.Find(&portList)
 	logrus.Infof("加载端口转发记录 %d", len(portList))
 
-	// 遍历端口转发记录，为每个配置启动独立的隧道服务
+	// 遍历端口转发记录，为每个配置启动受监管的隧道服务，并按IP汇总监听结果
+	var statusMap = map[string][]*node_rpc.BindPortStatusMessage{}
 	for _, model := range portList {
-		// 异步启动隧道，避免阻塞加载流程，支持多端口并发转发
-		go Tunnel(model.LocalAddr, model.TargetAddr)
+		err := Bind(model)
+		host, portStr, _ := net.SplitHostPort(model.LocalAddr)
+		port, _ := strconv.Atoi(portStr)
+		statusMap[host] = append(statusMap[host], PortStatus(port, err))
+	}
+
+	// 上报各IP的端口绑定状态，使服务端与节点实际状态保持一致
+	for ip, list := range statusMap {
+		ReportStatus(ip, list)
 	}
 }
@@ -0,0 +1,53 @@
+package port_service
+
+// File: service/port_service/report.go
+// Description: 端口绑定状态上报，将每个端口的实际监听结果通过gRPC上报到服务端
+
+import (
+	"context"
+	"honey_node/internal/global"
+	"honey_node/internal/rpc/node_rpc"
+	"net"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PortStatus 根据监听结果构建单个端口的绑定状态
+func PortStatus(port int, err error) *node_rpc.BindPortStatusMessage {
+	status := &node_rpc.BindPortStatusMessage{
+		Port:    int32(port),
+		Success: err == nil,
+	}
+	if err != nil {
+		status.ErrMsg = err.Error()
+	}
+	return status
+}
+
+// ReportStatus 上报指定IP上各端口的绑定状态
+func ReportStatus(ip string, portList []*node_rpc.BindPortStatusMessage) error {
+	if len(portList) == 0 {
+		return nil
+	}
+	_, err := global.GrpcClient.StatusBindPort(context.Background(), &node_rpc.StatusBindPortRequest{
+		NodeUid:  global.Config.System.Uid,
+		Ip:       ip,
+		PortList: portList,
+	})
+	if err != nil {
+		logrus.Errorf("上报端口绑定状态失败 %s: %v", ip, err)
+		return err
+	}
+	logrus.Infof("上报端口绑定状态成功 %s %d个端口", ip, len(portList))
+	return nil
+}
+
+// OnChange 返回端口监听状态变化时的上报回调，供Tunnel监管重启时使用
+func OnChange(localAddr string) func(err error) {
+	return func(err error) {
+		host, portStr, _ := net.SplitHostPort(localAddr)
+		port, _ := strconv.Atoi(portStr)
+		ReportStatus(host, []*node_rpc.BindPortStatusMessage{PortStatus(port, err)})
+	}
+}
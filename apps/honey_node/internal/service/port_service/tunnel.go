@@ -10,44 +10,145 @@ import (
 	"honey_node/internal/rpc/node_rpc"
 	"io"
 	"net"
-	"strings"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
 var tunnelStore = sync.Map{}
 
-// Tunnel 启动本地TCP端口监听并建立gRPC隧道转发
-// 实现本地端口到目标地址的TCP数据透传，支撑诱捕端口的代理功能
-func Tunnel(localAddr, targetAddr string) (err error) {
-	// 创建本地TCP监听：绑定指定的本地地址和端口
-	listener, err := net.Listen("tcp", localAddr)
+const (
+	minRestartDelay = time.Second      // 监听重启的初始退避时间
+	maxRestartDelay = 60 * time.Second // 监听重启的最大退避时间
+)
+
+// tunnel 受监管的端口监听
+type tunnel struct {
+	cancel   context.CancelFunc // 停止监管（关闭隧道时调用）
+	mu       sync.Mutex
+	listener net.Listener // 当前的监听器
+}
+
+// close 停止监管并关闭当前监听器
+func (t *tunnel) close() {
+	t.cancel()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.listener != nil {
+		t.listener.Close()
+	}
+}
+
+// Tunnel 启动受监管的本地TCP端口监听并建立gRPC隧道转发
+// 首次监听结果同步返回；之后监听失败或异常退出时按指数退避自动重启，
+// 监听状态发生变化（成功↔失败）时调用onChange通知，err为nil表示监听成功
+func Tunnel(localAddr, targetAddr string, onChange func(err error)) error {
+	err := start(localAddr, func(conn net.Conn) {
+		handleConnection(global.GrpcClient, conn, targetAddr)
+	}, onChange)
+	if err == nil {
+		logrus.Infof("本地监听启动，地址: %s 目标地址: %s", localAddr, targetAddr)
+	}
+	return err
+}
+
+// start 启动受监管的本地TCP端口监听，每个客户端连接交由handler处理
+func start(localAddr string, handler func(conn net.Conn), onChange func(err error)) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &tunnel{cancel: cancel}
+	// 同一地址已存在的隧道先关闭
+	if old, ok := tunnelStore.Swap(localAddr, t); ok {
+		old.(*tunnel).close()
+	}
+
+	listener, err := t.listen(localAddr)
 	if err != nil {
 		logrus.Errorf("创建本地监听失败: %v", err)
-		return
 	}
 
-	logrus.Infof("本地监听启动，地址: %s", localAddr)
-	logrus.Infof("目标地址: %s", targetAddr)
+	go t.supervise(ctx, localAddr, listener, err, handler, onChange)
+	return err
+}
 
-	tunnelStore.Store(localAddr, listener)
+// listen 创建监听器并记录到隧道中
+func (t *tunnel) listen(localAddr string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", localAddr)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	t.listener = listener
+	t.mu.Unlock()
+	return listener, nil
+}
 
-	// 循环接受客户端连接：持续监听端口，处理新的TCP连接请求
+// supervise 监管端口监听：处理连接，监听异常退出或创建失败时按退避策略重启
+func (t *tunnel) supervise(ctx context.Context, localAddr string, listener net.Listener, lastErr error, handler func(conn net.Conn), onChange func(err error)) {
+	delay := minRestartDelay
 	for {
-		clientConn, err := listener.Accept()
+		if listener != nil {
+			startTime := time.Now()
+			lastErr = serve(listener, handler)
+			if ctx.Err() != nil {
+				return // 隧道已主动关闭
+			}
+			logrus.Errorf("本地监听异常退出 %s: %v", localAddr, lastErr)
+			notify(onChange, lastErr)
+			// 稳定运行一段时间后退出的，重置退避时间
+			if time.Since(startTime) > maxRestartDelay {
+				delay = minRestartDelay
+			}
+		}
+
+		// 等待退避时间后重试
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRestartDelay)
+
+		var err error
+		listener, err = t.listen(localAddr)
 		if err != nil {
-			if strings.Contains(err.Error(), "closed") {
-				break
+			// 失败原因变化时才通知，避免重复上报
+			if lastErr == nil || lastErr.Error() != err.Error() {
+				notify(onChange, err)
 			}
-			logrus.Errorf("接受客户端连接失败: %v", err)
-			break
+			lastErr = err
+			logrus.Errorf("重启本地监听失败 %s: %v，%s后重试", localAddr, err, delay)
+			continue
+		}
+		if ctx.Err() != nil {
+			listener.Close()
+			return
+		}
+		logrus.Infof("本地监听已恢复，地址: %s", localAddr)
+		notify(onChange, nil)
+		delay = minRestartDelay
+	}
+}
+
+// serve 循环接受客户端连接，监听器关闭或出错时返回
+func serve(listener net.Listener, handler func(conn net.Conn)) error {
+	for {
+		clientConn, err := listener.Accept()
+		if err != nil {
+			return err
 		}
 
-		// 异步处理单个连接的转发逻辑：每个连接使用独立goroutine，支持高并发
-		go handleConnection(global.GrpcClient, clientConn, targetAddr)
+		// 异步处理单个连接：每个连接使用独立goroutine，支持高并发
+		go handler(clientConn)
+	}
+}
+
+// notify 调用状态变化回调
+func notify(onChange func(err error), err error) {
+	if onChange != nil {
+		onChange(err)
 	}
-	return nil
 }
 
 // CloseIpTunnel 关闭指定IP上的所有隧道监听服务
@@ -56,17 +157,18 @@ func CloseIpTunnel(ip string) {
 	tunnelStore.Range(func(key, value any) bool {
 		localAddr := key.(string)
 		// 检查当前隧道是否属于指定的IP地址
-		if strings.HasPrefix(localAddr, ip) {
+		host, _, _ := net.SplitHostPort(localAddr)
+		if host == ip {
 			// 从数据库中查找并删除对应端口模型记录
 			var model models.PortModel
 			global.DB.Find(&model, "local_addr = ?", localAddr)
 			if model.ID != 0 {
 				global.DB.Delete(&model)
 			}
-			// 记录日志并关闭监听器
-			logrus.Infof("清除%s上的全部服务", ip)
-			listener := value.(net.Listener)
-			listener.Close()
+			// 记录日志并停止监管、关闭监听器
+			logrus.Infof("清除%s上的服务 %s", ip, localAddr)
+			tunnelStore.Delete(localAddr)
+			value.(*tunnel).close()
 		}
 		return true
 	})
@@ -89,9 +191,14 @@ func handleConnection(client node_rpc.NodeServiceClient, localConn net.Conn, tar
 	}
 
 	// 发送初始消息：向服务端传递目标转发地址，触发服务端连接目标地址
+	// 同时携带会话信息，供服务端录制会话内容
 	if err := stream.Send(&node_rpc.TunnelData{
-		Chunk:   []byte{}, // 初始消息无业务数据，仅传递地址信息
-		Address: targetAddr,
+		Chunk:     []byte{}, // 初始消息无业务数据，仅传递地址信息
+		Address:   targetAddr,
+		NodeUid:   global.Config.System.Uid,
+		SessionID: uuid.New().String(),
+		SrcAddr:   localConn.RemoteAddr().String(),
+		LocalAddr: localConn.LocalAddr().String(),
 	}); err != nil {
 		logrus.Errorf("发送初始请求失败: %v", err)
 		return
@@ -1,11 +1,15 @@
 package cmd
 
 // File: utils/cmd/enter.go
-// Description: 提供系统命令执行的封装函数，支持普通命令执行、带路径的命令执行及获取命令输出等功能
+// Description: 提供系统命令执行的封装函数，支持普通命令执行、带路径的命令执行、获取命令输出及逐行流式输出等功能
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"io"
 	"os/exec"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -75,3 +79,40 @@ func PathCmd(path string, command string) (err error) {
 	logrus.Infof("命令输出 %s", stdout.String())
 	return nil
 }
+
+// maxLineSize StreamCommand 单行输出的最大字节数
+const maxLineSize = 1024 * 1024
+
+// StreamCommand 以参数列表方式执行系统命令（不经过shell解释），逐行回调标准输出与标准错误
+// 调用方必须自行保证name与args来自白名单，本函数不会对参数做任何拼接
+func StreamCommand(ctx context.Context, onLine func(line string), name string, args ...string) (err error) {
+	logrus.Infof("执行命令 %s %s", name, strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, name, args...)
+
+	// 合并标准输出和标准错误，便于调用方完整获取诊断输出
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	// 命令结束后关闭写端，通知读取协程退出
+	go func() {
+		writer.CloseWithError(cmd.Wait())
+	}()
+
+	// 按行读取命令输出并回调
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		onLine(scanner.Text())
+	}
+	if err = scanner.Err(); err != nil {
+		// 读取出错（如单行超长）时丢弃剩余输出，避免命令因管道写满阻塞导致Wait无法返回
+		io.Copy(io.Discard, reader)
+	}
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"honey_node/internal/global"
 	"honey_node/internal/service/command"
 	"honey_node/internal/service/cron_service"
+	"honey_node/internal/service/emulator_service"
 	"honey_node/internal/service/ip_service"
 	"honey_node/internal/service/mq_service"
 	"honey_node/internal/service/port_service"
@@ -44,18 +45,17 @@ func main() {
 	// 启动命令行参数处理服务：解析命令行参数，并执行相应的操作
 	flags.Run()
 
-	// 初始化消息队列：建立与RabbitMQ的连接，用于消费服务端下发的任务消息
-	global.Queue = core.InitMQ()
-
 	// 启动命令处理服务：监听并处理服务端通过gRPC下发的命令
 	nodeClient.StartCommandHandling()
 
 	// 启动定时任务服务：执行节点本地的周期性任务
 	cron_service.Run()
-	// 启动消息队列消费服务：消费RabbitMQ中的任务消息
+	// 启动消息消费服务：按配置的消息总线类型消费服务端下发的任务消息
 	mq_service.Run()
 	// 加载IP信息
 	ip_service.IPLoad()
+	// 启动内置模拟器交互会话上报
+	go emulator_service.RunReporter()
 	// 加载端口转发信息
 	port_service.LoadTunnel()
 
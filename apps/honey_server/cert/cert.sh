@@ -9,6 +9,9 @@ fi
 # 设置证书有效期（天）
 DAYS=3650
 
+# 客户端证书CN为节点UID（服务端以此识别节点），用法: bash cert.sh <节点UID>
+NODE_UID=${1:-MyClient}
+
 # 清理旧证书文件
 rm -f ca.key ca.crt ca.srl
 rm -f server.key server.csr server.crt
@@ -35,7 +38,7 @@ rm -f server.ext
 # 生成客户端私钥和证书
 openssl genrsa -out client.key 2048
 openssl req -new -key client.key -out client.csr \
-    -subj "/C=CN/ST=Beijing/L=Beijing/O=MyOrg/OU=IT/CN=MyClient"
+    -subj "/C=CN/ST=Beijing/L=Beijing/O=MyOrg/OU=IT/CN=$NODE_UID"
 openssl x509 -req -sha256 -in client.csr -CA ca.crt -CAkey ca.key \
     -out client.crt -days $DAYS
 
@@ -0,0 +1,2 @@
+// Package alert_api 告警记录查询与处理API
+package alert_api
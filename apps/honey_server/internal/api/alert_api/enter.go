@@ -0,0 +1,8 @@
+package alert_api
+
+// File: api/alert_api/enter.go
+// Description: 告警记录API入口
+
+// AlertApi 告警记录API入口
+type AlertApi struct {
+}
@@ -0,0 +1,69 @@
+package alert_api
+
+// File: api/alert_api/handle.go
+// Description: 告警处理API，确认或解决告警。已解决的告警不再参与重复告警合并
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/res"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleRequest 告警处理请求结构体
+type HandleRequest struct {
+	IdList []uint `json:"idList" binding:"required"` // 告警ID列表
+	Remark string `json:"remark" binding:"max=256"`  // 处理备注
+}
+
+// AckView 告警确认接口处理函数，只确认未处理的告警
+func (AlertApi) AckView(c *gin.Context) {
+	cr := middleware.GetBind[HandleRequest](c)
+	claims := middleware.GetAuth(c)
+
+	data := map[string]any{
+		"status":      2,
+		"ack_user_id": claims.UserID,
+		"ack_time":    time.Now(),
+	}
+	if cr.Remark != "" {
+		data["remark"] = cr.Remark
+	}
+	result := global.DB.Model(&models.AlertModel{}).
+		Where("id in ? and status = ?", cr.IdList, 1).
+		Updates(data)
+	if result.Error != nil {
+		res.FailWithMsg("确认告警失败", c)
+		return
+	}
+
+	res.OkWithMsg(fmt.Sprintf("确认成功 共%d个，成功%d个", len(cr.IdList), result.RowsAffected), c)
+}
+
+// ResolveView 告警解决接口处理函数，未处理与已确认的告警均可解决
+func (AlertApi) ResolveView(c *gin.Context) {
+	cr := middleware.GetBind[HandleRequest](c)
+	claims := middleware.GetAuth(c)
+
+	data := map[string]any{
+		"status":          3,
+		"resolve_user_id": claims.UserID,
+		"resolve_time":    time.Now(),
+	}
+	if cr.Remark != "" {
+		data["remark"] = cr.Remark
+	}
+	result := global.DB.Model(&models.AlertModel{}).
+		Where("id in ? and status <> ?", cr.IdList, 3).
+		Updates(data)
+	if result.Error != nil {
+		res.FailWithMsg("解决告警失败", c)
+		return
+	}
+
+	res.OkWithMsg(fmt.Sprintf("解决成功 共%d个，成功%d个", len(cr.IdList), result.RowsAffected), c)
+}
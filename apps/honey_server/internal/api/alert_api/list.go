@@ -0,0 +1,40 @@
+package alert_api
+
+// File: api/alert_api/list.go
+// Description: 告警记录列表查询API
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 告警记录列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	RuleID   uint `form:"ruleID"`   // 按规则筛选
+	Severity int8 `form:"severity"` // 按告警级别筛选
+	Status   int8 `form:"status"`   // 按状态筛选 1 未处理 2 已确认 3 已解决
+	NodeID   uint `form:"nodeID"`   // 按节点筛选
+}
+
+// ListView 告警记录列表查询接口处理函数
+func (AlertApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.AlertModel{
+		RuleID:   cr.RuleID,
+		Severity: cr.Severity,
+		Status:   cr.Status,
+		NodeID:   cr.NodeID,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"rule_title", "content", "group_key"},
+		Sort:     "last_time desc",
+	})
+
+	res.OkWithList(list, count, c)
+}
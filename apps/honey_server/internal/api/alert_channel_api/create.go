@@ -0,0 +1,44 @@
+package alert_channel_api
+
+// File: api/alert_channel_api/create.go
+// Description: 告警通知渠道创建API
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateView 告警通知渠道创建接口处理函数
+func (AlertChannelApi) CreateView(c *gin.Context) {
+	cr := middleware.GetBind[ChannelRequest](c)
+	log := middleware.GetLog(c)
+
+	if msg := cr.validate(); msg != "" {
+		res.FailWithMsg(msg, c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.AlertChannelModel{}).Where("title = ?", cr.Title).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("渠道名称不能重复", c)
+		return
+	}
+
+	model := models.AlertChannelModel{
+		Title:  cr.Title,
+		Type:   cr.Type,
+		Enable: cr.Enable,
+		Config: cr.Config,
+	}
+	if err := global.DB.Create(&model).Error; err != nil {
+		log.Errorf("创建告警通知渠道失败 %s", err)
+		res.FailWithMsg("创建告警通知渠道失败", c)
+		return
+	}
+
+	res.OkWithData(model.ID, c)
+}
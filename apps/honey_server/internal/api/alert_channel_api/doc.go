@@ -0,0 +1,2 @@
+// Package alert_channel_api 告警通知渠道管理API
+package alert_channel_api
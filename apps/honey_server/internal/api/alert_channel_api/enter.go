@@ -0,0 +1,70 @@
+package alert_channel_api
+
+// File: api/alert_channel_api/enter.go
+// Description: 告警通知渠道API入口
+
+import (
+	"honey_server/internal/models"
+	"net/url"
+	"strings"
+)
+
+// AlertChannelApi 告警通知渠道API入口
+type AlertChannelApi struct {
+}
+
+// secretMask 列表中返回的密钥掩码，更新时传回掩码表示不修改原密钥
+const secretMask = "******"
+
+// ChannelRequest 通知渠道的公共请求字段
+type ChannelRequest struct {
+	Title  string                    `json:"title" binding:"required,max=64" label:"渠道名称"`                    // 渠道名称
+	Type   string                    `json:"type" binding:"required,oneof=webhook email syslog" label:"渠道类型"` // 渠道类型
+	Enable bool                      `json:"enable"`                                                          // 是否启用
+	Config models.AlertChannelConfig `json:"config"`                                                          // 渠道配置
+}
+
+// validate 按渠道类型校验配置，返回错误信息
+func (cr ChannelRequest) validate() string {
+	config := cr.Config
+	switch cr.Type {
+	case "webhook":
+		u, err := url.Parse(config.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "Webhook地址格式错误"
+		}
+	case "email":
+		if config.Host == "" || config.Port <= 0 || config.Port > 65535 {
+			return "SMTP服务器地址或端口错误"
+		}
+		if !strings.Contains(config.From, "@") {
+			return "发件人格式错误"
+		}
+		if len(config.To) == 0 {
+			return "收件人不能为空"
+		}
+		for _, to := range config.To {
+			if !strings.Contains(to, "@") {
+				return "收件人格式错误 " + to
+			}
+		}
+	case "syslog":
+		if config.Network != "" && config.Network != "udp" && config.Network != "tcp" {
+			return "Syslog传输协议只支持udp、tcp"
+		}
+		if config.Addr == "" {
+			return "Syslog服务器地址不能为空"
+		}
+	}
+	return ""
+}
+
+// maskSecret 隐藏渠道配置中的密钥
+func maskSecret(config *models.AlertChannelConfig) {
+	if config.Secret != "" {
+		config.Secret = secretMask
+	}
+	if config.Password != "" {
+		config.Password = secretMask
+	}
+}
@@ -0,0 +1,37 @@
+package alert_channel_api
+
+// File: api/alert_channel_api/list.go
+// Description: 告警通知渠道列表查询API
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 告警通知渠道列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	Type string `form:"type"` // 按渠道类型筛选
+}
+
+// ListView 告警通知渠道列表查询接口处理函数，返回的配置中密钥以掩码代替
+func (AlertChannelApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.AlertChannelModel{
+		Type: cr.Type,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"title"},
+		Sort:     "created_at desc",
+	})
+	for i := range list {
+		maskSecret(&list[i].Config)
+	}
+
+	res.OkWithList(list, count, c)
+}
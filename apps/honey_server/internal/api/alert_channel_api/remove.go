@@ -0,0 +1,34 @@
+package alert_channel_api
+
+// File: api/alert_channel_api/remove.go
+// Description: 告警通知渠道删除API
+
+import (
+	"fmt"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RemoveView 告警通知渠道批量删除接口处理函数
+// 规则中引用的已删除渠道在发送通知时自动忽略
+func (AlertChannelApi) RemoveView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDListRequest](c)
+	log := middleware.GetLog(c)
+
+	successCount, err := common_service.Remove(models.AlertChannelModel{}, common_service.RemoveRequest{
+		IDList: cr.IdList,
+		Log:    log,
+		Msg:    "告警通知渠道",
+	})
+	if err != nil {
+		res.FailWithMsg(fmt.Sprintf("删除告警通知渠道失败 %s", err), c)
+		return
+	}
+
+	msg := fmt.Sprintf("删除成功 共%d个，成功%d个", len(cr.IdList), successCount)
+	res.OkWithMsg(msg, c)
+}
@@ -0,0 +1,45 @@
+package alert_channel_api
+
+// File: api/alert_channel_api/test.go
+// Description: 告警通知渠道测试API，通过指定渠道发送一条测试告警，用于验证渠道配置
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/alert_service"
+	"honey_server/internal/utils/res"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestView 告警通知渠道测试接口处理函数
+func (AlertChannelApi) TestView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	var model models.AlertChannelModel
+	if err := global.DB.Take(&model, cr.Id).Error; err != nil {
+		res.FailWithMsg("告警通知渠道不存在", c)
+		return
+	}
+
+	now := time.Now()
+	err := alert_service.Send(model, models.AlertModel{
+		RuleTitle: "测试告警",
+		Severity:  1,
+		EventType: "test",
+		Content:   fmt.Sprintf("这是一条来自通知渠道 %s 的测试告警", model.Title),
+		Count:     1,
+		FirstTime: now,
+		LastTime:  now,
+		Status:    1,
+	})
+	if err != nil {
+		res.FailWithMsg(fmt.Sprintf("发送测试告警失败 %s", err), c)
+		return
+	}
+
+	res.OkWithMsg("发送测试告警成功", c)
+}
@@ -0,0 +1,62 @@
+package alert_channel_api
+
+// File: api/alert_channel_api/update.go
+// Description: 告警通知渠道更新API
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateRequest 告警通知渠道更新请求结构体
+type UpdateRequest struct {
+	ID uint `json:"id" binding:"required"` // 渠道ID
+	ChannelRequest
+}
+
+// UpdateView 告警通知渠道更新接口处理函数
+func (AlertChannelApi) UpdateView(c *gin.Context) {
+	cr := middleware.GetBind[UpdateRequest](c)
+	log := middleware.GetLog(c)
+
+	var model models.AlertChannelModel
+	if err := global.DB.Take(&model, cr.ID).Error; err != nil {
+		res.FailWithMsg("告警通知渠道不存在", c)
+		return
+	}
+	if msg := cr.validate(); msg != "" {
+		res.FailWithMsg(msg, c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.AlertChannelModel{}).Where("title = ? and id <> ?", cr.Title, cr.ID).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("渠道名称不能重复", c)
+		return
+	}
+
+	// 传回掩码表示不修改原密钥
+	config := cr.Config
+	if config.Secret == secretMask {
+		config.Secret = model.Config.Secret
+	}
+	if config.Password == secretMask {
+		config.Password = model.Config.Password
+	}
+
+	model.Title = cr.Title
+	model.Type = cr.Type
+	model.Enable = cr.Enable
+	model.Config = config
+	if err := global.DB.Save(&model).Error; err != nil {
+		log.Errorf("更新告警通知渠道失败 %s", err)
+		res.FailWithMsg("更新告警通知渠道失败", c)
+		return
+	}
+
+	res.OkWithMsg("更新告警通知渠道成功", c)
+}
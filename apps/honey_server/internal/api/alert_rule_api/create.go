@@ -0,0 +1,41 @@
+package alert_rule_api
+
+// File: api/alert_rule_api/create.go
+// Description: 告警规则创建API
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/alert_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateView 告警规则创建接口处理函数
+func (AlertRuleApi) CreateView(c *gin.Context) {
+	cr := middleware.GetBind[RuleRequest](c)
+	log := middleware.GetLog(c)
+
+	if msg := cr.validate(); msg != "" {
+		res.FailWithMsg(msg, c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.AlertRuleModel{}).Where("title = ?", cr.Title).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("规则名称不能重复", c)
+		return
+	}
+
+	model := cr.model()
+	if err := global.DB.Create(&model).Error; err != nil {
+		log.Errorf("创建告警规则失败 %s", err)
+		res.FailWithMsg("创建告警规则失败", c)
+		return
+	}
+	alert_service.Reload()
+
+	res.OkWithData(model.ID, c)
+}
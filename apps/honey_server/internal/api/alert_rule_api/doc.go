@@ -0,0 +1,2 @@
+// Package alert_rule_api 告警规则管理API
+package alert_rule_api
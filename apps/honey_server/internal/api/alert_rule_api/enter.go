@@ -0,0 +1,72 @@
+package alert_rule_api
+
+// File: api/alert_rule_api/enter.go
+// Description: 告警规则API入口
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"net"
+)
+
+// AlertRuleApi 告警规则API入口
+type AlertRuleApi struct {
+}
+
+// RuleRequest 告警规则的公共请求字段
+type RuleRequest struct {
+	Title          string `json:"title" binding:"required,max=64" label:"规则名称"`                                                                                      // 规则名称
+	Enable         bool   `json:"enable"`                                                                                                                            // 是否启用
+	EventType      string `json:"eventType" binding:"required,oneof=attack_session host_change node_online node_offline honey_ip_status login_lockout" label:"事件类型"` // 匹配的事件类型
+	NodeID         uint   `json:"nodeID"`                                                                                                                            // 条件：节点ID
+	HoneyIpID      uint   `json:"honeyIpID"`                                                                                                                         // 条件：诱捕IP ID
+	Protocol       string `json:"protocol"`                                                                                                                          // 条件：模拟器协议
+	SrcIP          string `json:"srcIP"`                                                                                                                             // 条件：攻击者IP或网段
+	DstPort        int    `json:"dstPort" binding:"min=0,max=65535"`                                                                                                 // 条件：诱捕端口
+	Threshold      int    `json:"threshold" binding:"min=0" label:"阈值"`                                                                                              // 阈值，默认1
+	Window         int    `json:"window" binding:"min=0" label:"时间窗口"`                                                                                               // 时间窗口（秒）
+	GroupBy        string `json:"groupBy" binding:"omitempty,oneof=src_ip honey_ip node" label:"分组字段"`                                                               // 分组字段
+	Severity       int8   `json:"severity" binding:"required,min=1,max=4" label:"告警级别"`                                                                              // 告警级别
+	SuppressWindow int    `json:"suppressWindow" binding:"min=0" label:"抑制窗口"`                                                                                       // 抑制窗口（秒）
+	ChannelIDList  []uint `json:"channelIDList"`                                                                                                                     // 通知渠道ID列表
+}
+
+// validate 校验规则参数，返回错误信息
+func (cr RuleRequest) validate() string {
+	if cr.SrcIP != "" && net.ParseIP(cr.SrcIP) == nil {
+		if _, _, err := net.ParseCIDR(cr.SrcIP); err != nil {
+			return "攻击者IP格式错误，应为IP或CIDR网段"
+		}
+	}
+	if cr.Threshold > 1 && cr.Window == 0 {
+		return "阈值大于1时需要设置时间窗口"
+	}
+	if len(cr.ChannelIDList) > 0 {
+		var count int64
+		global.DB.Model(&models.AlertChannelModel{}).Where("id in ?", cr.ChannelIDList).Count(&count)
+		if int(count) != len(cr.ChannelIDList) {
+			return "通知渠道不存在"
+		}
+	}
+	return ""
+}
+
+// model 将请求转换为规则模型
+func (cr RuleRequest) model() models.AlertRuleModel {
+	return models.AlertRuleModel{
+		Title:          cr.Title,
+		Enable:         cr.Enable,
+		EventType:      cr.EventType,
+		NodeID:         cr.NodeID,
+		HoneyIpID:      cr.HoneyIpID,
+		Protocol:       cr.Protocol,
+		SrcIP:          cr.SrcIP,
+		DstPort:        cr.DstPort,
+		Threshold:      max(cr.Threshold, 1),
+		Window:         cr.Window,
+		GroupBy:        cr.GroupBy,
+		Severity:       cr.Severity,
+		SuppressWindow: cr.SuppressWindow,
+		ChannelIDList:  cr.ChannelIDList,
+	}
+}
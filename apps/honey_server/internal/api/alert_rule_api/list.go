@@ -0,0 +1,36 @@
+package alert_rule_api
+
+// File: api/alert_rule_api/list.go
+// Description: 告警规则列表查询API
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 告警规则列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	EventType string `form:"eventType"` // 按事件类型筛选
+	Severity  int8   `form:"severity"`  // 按告警级别筛选
+}
+
+// ListView 告警规则列表查询接口处理函数
+func (AlertRuleApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.AlertRuleModel{
+		EventType: cr.EventType,
+		Severity:  cr.Severity,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"title"},
+		Sort:     "created_at desc",
+	})
+
+	res.OkWithList(list, count, c)
+}
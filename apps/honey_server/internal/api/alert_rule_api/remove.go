@@ -0,0 +1,35 @@
+package alert_rule_api
+
+// File: api/alert_rule_api/remove.go
+// Description: 告警规则删除API
+
+import (
+	"fmt"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/alert_service"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RemoveView 告警规则批量删除接口处理函数
+func (AlertRuleApi) RemoveView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDListRequest](c)
+	log := middleware.GetLog(c)
+
+	successCount, err := common_service.Remove(models.AlertRuleModel{}, common_service.RemoveRequest{
+		IDList: cr.IdList,
+		Log:    log,
+		Msg:    "告警规则",
+	})
+	if err != nil {
+		res.FailWithMsg(fmt.Sprintf("删除告警规则失败 %s", err), c)
+		return
+	}
+	alert_service.Reload()
+
+	msg := fmt.Sprintf("删除成功 共%d个，成功%d个", len(cr.IdList), successCount)
+	res.OkWithMsg(msg, c)
+}
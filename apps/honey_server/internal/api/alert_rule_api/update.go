@@ -0,0 +1,53 @@
+package alert_rule_api
+
+// File: api/alert_rule_api/update.go
+// Description: 告警规则更新API
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/alert_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateRequest 告警规则更新请求结构体
+type UpdateRequest struct {
+	ID uint `json:"id" binding:"required"` // 规则ID
+	RuleRequest
+}
+
+// UpdateView 告警规则更新接口处理函数
+func (AlertRuleApi) UpdateView(c *gin.Context) {
+	cr := middleware.GetBind[UpdateRequest](c)
+	log := middleware.GetLog(c)
+
+	var model models.AlertRuleModel
+	if err := global.DB.Take(&model, cr.ID).Error; err != nil {
+		res.FailWithMsg("告警规则不存在", c)
+		return
+	}
+	if msg := cr.validate(); msg != "" {
+		res.FailWithMsg(msg, c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.AlertRuleModel{}).Where("title = ? and id <> ?", cr.Title, cr.ID).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("规则名称不能重复", c)
+		return
+	}
+
+	newModel := cr.model()
+	newModel.Model = model.Model
+	if err := global.DB.Save(&newModel).Error; err != nil {
+		log.Errorf("更新告警规则失败 %s", err)
+		res.FailWithMsg("更新告警规则失败", c)
+		return
+	}
+	alert_service.Reload()
+
+	res.OkWithMsg("更新告警规则成功", c)
+}
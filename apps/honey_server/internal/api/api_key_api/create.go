@@ -0,0 +1,53 @@
+package api_key_api
+
+// File: api/api_key_api/create.go
+// Description: API密钥创建API，为当前用户创建API密钥，完整密钥只在创建时返回一次
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/service/api_key_service"
+	"honey_server/internal/utils/res"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRequest API密钥创建请求结构体
+type CreateRequest struct {
+	Title     string   `json:"title" binding:"required,max=32" label:"名称"`      // 名称
+	ScopeList []string `json:"scopeList" binding:"required,min=1" label:"授权范围"` // 授权范围，为权限码列表
+	Expires   int      `json:"expires" binding:"min=0,max=3650" label:"有效天数"`   // 有效天数，0 表示永不过期
+}
+
+// CreateResponse API密钥创建响应结构体
+type CreateResponse struct {
+	ID        uint       `json:"id"`        // API密钥ID
+	Prefix    string     `json:"prefix"`    // 密钥前缀
+	Key       string     `json:"key"`       // 完整密钥，只返回一次
+	ExpiresAt *time.Time `json:"expiresAt"` // 过期时间
+}
+
+// CreateView API密钥创建接口处理函数
+func (ApiKeyApi) CreateView(c *gin.Context) {
+	cr := middleware.GetBind[CreateRequest](c)
+	log := middleware.GetLog(c)
+
+	model, key, err := api_key_service.Create(api_key_service.CreateRequest{
+		UserID:    middleware.GetAuth(c).UserID,
+		Title:     cr.Title,
+		ScopeList: cr.ScopeList,
+		Expires:   cr.Expires,
+	})
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	log.Infof("创建API密钥 %s(%s) 授权范围 %v", model.Title, model.Prefix, model.ScopeList)
+
+	res.Ok(CreateResponse{
+		ID:        model.ID,
+		Prefix:    model.Prefix,
+		Key:       key,
+		ExpiresAt: model.ExpiresAt,
+	}, "创建成功，请妥善保存密钥，关闭后将无法再次查看", c)
+}
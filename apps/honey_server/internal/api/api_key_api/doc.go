@@ -0,0 +1,2 @@
+// Package api_key_api API密钥管理API
+package api_key_api
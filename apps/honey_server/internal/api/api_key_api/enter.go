@@ -0,0 +1,24 @@
+package api_key_api
+
+// File: api/api_key_api/enter.go
+// Description: API密钥API入口
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/service/role_service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApiKeyApi API密钥API入口
+type ApiKeyApi struct {
+}
+
+// keyUserID 确定操作的用户，为空时为当前用户，拥有 perm 权限才能操作其他用户的API密钥
+func keyUserID(c *gin.Context, userID uint, perm string) (uint, bool) {
+	auth := middleware.GetAuth(c)
+	if userID == 0 || userID == auth.UserID {
+		return auth.UserID, true
+	}
+	return userID, role_service.HasPermission(auth.UserID, perm)
+}
@@ -0,0 +1,37 @@
+package api_key_api
+
+// File: api/api_key_api/list.go
+// Description: API密钥列表查询API，没有用户查看权限时只能查询自己的API密钥
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest API密钥列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	UserID uint `form:"userID"` // 用户ID，为空时查询当前用户，有用户查看权限时可查询其他用户
+}
+
+// ListView API密钥列表查询接口处理函数
+func (ApiKeyApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+	userID, ok := keyUserID(c, cr.UserID, "user:read")
+	if !ok {
+		res.FailWithMsg("无权限访问", c)
+		return
+	}
+
+	list, count, _ := common_service.QueryList(models.ApiKeyModel{UserID: userID}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"title", "prefix"},
+		Sort:     "created_at desc",
+	})
+
+	res.OkWithList(list, count, c)
+}
@@ -0,0 +1,45 @@
+package api_key_api
+
+// File: api/api_key_api/remove.go
+// Description: API密钥吊销API，吊销后立即失效。没有用户管理权限时只能吊销自己的API密钥
+
+import (
+	"fmt"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RemoveRequest API密钥吊销请求结构体
+type RemoveRequest struct {
+	UserID uint   `json:"userID"`                                          // 用户ID，为空时为当前用户，有用户管理权限时可吊销其他用户的API密钥
+	IDList []uint `json:"idList" binding:"required,min=1" label:"API密钥ID"` // 吊销的API密钥ID列表
+}
+
+// RemoveView API密钥吊销接口处理函数
+func (ApiKeyApi) RemoveView(c *gin.Context) {
+	cr := middleware.GetBind[RemoveRequest](c)
+	log := middleware.GetLog(c)
+	userID, ok := keyUserID(c, cr.UserID, "user:write")
+	if !ok {
+		res.FailWithMsg("无权限访问", c)
+		return
+	}
+
+	successCount, err := common_service.Remove(models.ApiKeyModel{UserID: userID}, common_service.RemoveRequest{
+		IDList:   cr.IDList,
+		Log:      log,
+		Msg:      "API密钥",
+		Unscoped: true,
+	})
+	if err != nil {
+		res.FailWithMsg(fmt.Sprintf("吊销API密钥失败 %s", err), c)
+		return
+	}
+
+	msg := fmt.Sprintf("吊销成功 共%d个，成功%d个", len(cr.IDList), successCount)
+	res.OkWithMsg(msg, c)
+}
@@ -0,0 +1,54 @@
+package credential_api
+
+// File: api/credential_api/attacker.go
+// Description: 攻击者凭据API，按攻击者IP汇总其尝试过的全部凭据
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/utils/res"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttackerRequest 攻击者凭据请求结构体
+type AttackerRequest struct {
+	TimeWindow
+	SrcIP string `form:"srcIP" binding:"required"` // 攻击者IP
+}
+
+// AttackerItem 攻击者尝试过的单个凭据
+type AttackerItem struct {
+	Protocol  string    `json:"protocol"`  // 协议
+	Username  string    `json:"username"`  // 用户名
+	Password  string    `json:"password"`  // 口令
+	Hash      string    `json:"hash"`      // 口令摘要（无明文时）
+	Count     int64     `json:"count"`     // 尝试次数
+	FirstTime time.Time `json:"firstTime"` // 首次尝试时间
+	LastTime  time.Time `json:"lastTime"`  // 最近尝试时间
+}
+
+// AttackerResponse 攻击者凭据响应结构体
+type AttackerResponse struct {
+	SrcIP        string         `json:"srcIP"`        // 攻击者IP
+	AttemptCount int64          `json:"attemptCount"` // 总尝试次数
+	List         []AttackerItem `json:"list"`         // 凭据列表（按最近尝试时间倒序）
+}
+
+// AttackerView 攻击者凭据接口处理函数
+func (CredentialApi) AttackerView(c *gin.Context) {
+	cr := middleware.GetBind[AttackerRequest](c)
+
+	data := AttackerResponse{
+		SrcIP: cr.SrcIP,
+		List:  make([]AttackerItem, 0),
+	}
+	cr.TimeWindow.query().Where("src_ip = ?", cr.SrcIP).
+		Select("protocol, username, password, hash, count(*) as count, min(attempt_time) as first_time, max(attempt_time) as last_time").
+		Group("protocol, username, password, hash").Order("last_time desc").Scan(&data.List)
+	for _, item := range data.List {
+		data.AttemptCount += item.Count
+	}
+
+	res.OkWithData(data, c)
+}
@@ -0,0 +1,2 @@
+// Package credential_api 凭据尝试查询与分析API
+package credential_api
@@ -0,0 +1,34 @@
+package credential_api
+
+// File: api/credential_api/enter.go
+// Description: 凭据尝试API入口
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CredentialApi 凭据尝试API入口
+type CredentialApi struct {
+}
+
+// TimeWindow 统计时间窗口，为空时不限制
+type TimeWindow struct {
+	StartTime time.Time `form:"startTime" time_format:"2006-01-02 15:04:05"` // 开始时间
+	EndTime   time.Time `form:"endTime" time_format:"2006-01-02 15:04:05"`   // 结束时间
+}
+
+// query 按时间窗口构建凭据尝试的查询条件
+func (w TimeWindow) query() *gorm.DB {
+	query := global.DB.Model(&models.CredentialAttemptModel{})
+	if !w.StartTime.IsZero() {
+		query = query.Where("attempt_time >= ?", w.StartTime)
+	}
+	if !w.EndTime.IsZero() {
+		query = query.Where("attempt_time < ?", w.EndTime)
+	}
+	return query
+}
@@ -0,0 +1,42 @@
+package credential_api
+
+// File: api/credential_api/list.go
+// Description: 凭据尝试列表查询API
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 凭据尝试列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	TimeWindow
+	HoneyIpID uint   `form:"honeyIpID"` // 按诱捕IP筛选
+	Protocol  string `form:"protocol"`  // 按协议筛选
+	SrcIP     string `form:"srcIP"`     // 按攻击者IP筛选
+	Username  string `form:"username"`  // 按用户名筛选
+}
+
+// ListView 凭据尝试列表查询接口处理函数
+func (CredentialApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.CredentialAttemptModel{
+		HoneyIpID: cr.HoneyIpID,
+		Protocol:  cr.Protocol,
+		SrcIP:     cr.SrcIP,
+		Username:  cr.Username,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Where:    cr.TimeWindow.query(),
+		Likes:    []string{"username", "password"},
+		Sort:     "attempt_time desc",
+	})
+
+	res.OkWithList(list, count, c)
+}
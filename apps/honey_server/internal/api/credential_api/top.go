@@ -0,0 +1,82 @@
+package credential_api
+
+// File: api/credential_api/top.go
+// Description: 凭据排行API，统计时间窗口内尝试次数最多的用户名、口令及用户名口令组合
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TopRequest 凭据排行请求结构体
+type TopRequest struct {
+	TimeWindow
+	Protocol string `form:"protocol"`                          // 按协议筛选
+	Limit    int    `form:"limit" binding:"omitempty,max=100"` // 返回条数，默认10
+}
+
+// TopItem 用户名或口令排行项
+type TopItem struct {
+	Value   string `json:"value"`   // 用户名或口令
+	Count   int64  `json:"count"`   // 尝试次数
+	IPCount int64  `json:"ipCount"` // 尝试过的攻击者IP数
+}
+
+// TopPairItem 用户名口令组合排行项
+type TopPairItem struct {
+	Username string `json:"username"` // 用户名
+	Password string `json:"password"` // 口令
+	Count    int64  `json:"count"`    // 尝试次数
+	IPCount  int64  `json:"ipCount"`  // 尝试过的攻击者IP数
+}
+
+// top 按字段统计排行
+func top(cr TopRequest, column string) []TopItem {
+	if cr.Limit <= 0 {
+		cr.Limit = 10
+	}
+	query := cr.TimeWindow.query()
+	if cr.Protocol != "" {
+		query = query.Where("protocol = ?", cr.Protocol)
+	}
+	// 只有口令摘要的尝试（如MySQL）不参与口令排行
+	if column == "password" {
+		query = query.Where("hash = ''")
+	}
+
+	var list = make([]TopItem, 0)
+	query.Select(column + " as value, count(*) as count, count(distinct src_ip) as ip_count").
+		Group(column).Order("count desc").Limit(cr.Limit).Scan(&list)
+	return list
+}
+
+// TopUsernameView 用户名排行接口处理函数
+func (CredentialApi) TopUsernameView(c *gin.Context) {
+	cr := middleware.GetBind[TopRequest](c)
+	res.OkWithData(top(cr, "username"), c)
+}
+
+// TopPasswordView 口令排行接口处理函数
+func (CredentialApi) TopPasswordView(c *gin.Context) {
+	cr := middleware.GetBind[TopRequest](c)
+	res.OkWithData(top(cr, "password"), c)
+}
+
+// TopPairView 用户名口令组合排行接口处理函数
+func (CredentialApi) TopPairView(c *gin.Context) {
+	cr := middleware.GetBind[TopRequest](c)
+	if cr.Limit <= 0 {
+		cr.Limit = 10
+	}
+	query := cr.TimeWindow.query().Where("hash = ''")
+	if cr.Protocol != "" {
+		query = query.Where("protocol = ?", cr.Protocol)
+	}
+
+	var list = make([]TopPairItem, 0)
+	query.Select("username, password, count(*) as count, count(distinct src_ip) as ip_count").
+		Group("username, password").Order("count desc").Limit(cr.Limit).Scan(&list)
+	res.OkWithData(list, c)
+}
@@ -0,0 +1,2 @@
+// Package dead_letter_api 死信消息管理API
+package dead_letter_api
@@ -0,0 +1,8 @@
+package dead_letter_api
+
+// File: api/dead_letter_api/enter.go
+// Description: 死信消息API入口
+
+// DeadLetterApi 死信消息API入口
+type DeadLetterApi struct {
+}
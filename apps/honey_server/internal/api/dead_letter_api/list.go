@@ -0,0 +1,38 @@
+package dead_letter_api
+
+// File: api/dead_letter_api/list.go
+// Description: 死信消息列表查询API
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 死信消息列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	Status   int8   `form:"status"`   // 按状态筛选（1 待处理 2 已重放 3 已忽略）
+	NodeUID  string `form:"nodeUID"`  // 按节点UID筛选
+	Exchange string `form:"exchange"` // 按业务交换器筛选
+}
+
+// ListView 死信消息列表查询接口处理函数
+func (DeadLetterApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.MqDeadLetterModel{
+		Status:   cr.Status,
+		NodeUID:  cr.NodeUID,
+		Exchange: cr.Exchange,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"message_id", "error_msg"},
+		Sort:     "created_at desc",
+	})
+
+	res.OkWithList(list, count, c)
+}
@@ -0,0 +1,59 @@
+package dead_letter_api
+
+// File: api/dead_letter_api/replay.go
+// Description: 死信消息重放与忽略API
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/mq_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ReplayView 重放死信消息，重新投递到原节点
+func (DeadLetterApi) ReplayView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDListRequest](c)
+
+	// 仅重放待处理（状态码1）的死信，避免重复投递
+	var list []models.MqDeadLetterModel
+	global.DB.Find(&list, "id in ? and status = ?", cr.IdList, 1)
+	if len(list) == 0 {
+		res.FailWithMsg("没有可重放的死信消息", c)
+		return
+	}
+
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		for _, model := range list {
+			if err := mq_service.ReplayDeadLetter(tx, model); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		res.FailWithMsg("重放死信消息失败", c)
+		return
+	}
+
+	res.OkWithMsg(fmt.Sprintf("重放死信消息%d个", len(list)), c)
+}
+
+// IgnoreView 忽略死信消息，标记为已忽略（状态码3）后不再重放
+func (DeadLetterApi) IgnoreView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDListRequest](c)
+
+	result := global.DB.Model(&models.MqDeadLetterModel{}).
+		Where("id in ? and status = ?", cr.IdList, 1).
+		Update("status", 3)
+	if result.Error != nil {
+		res.FailWithMsg("忽略死信消息失败", c)
+		return
+	}
+
+	res.OkWithMsg(fmt.Sprintf("忽略死信消息%d个", result.RowsAffected), c)
+}
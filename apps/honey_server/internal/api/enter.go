@@ -4,28 +4,50 @@ package api
 // Description: 定义API入口，包含各个子模块的API实例。
 
 import (
+	"honey_server/internal/api/alert_api"
+	"honey_server/internal/api/alert_channel_api"
+	"honey_server/internal/api/alert_rule_api"
+	"honey_server/internal/api/api_key_api"
 	"honey_server/internal/api/captcha_api"
+	"honey_server/internal/api/credential_api"
+	"honey_server/internal/api/dead_letter_api"
+	"honey_server/internal/api/event_api"
 	"honey_server/internal/api/honey_ip_api"
 	"honey_server/internal/api/honey_port_api"
 	"honey_server/internal/api/host_api"
+	"honey_server/internal/api/interaction_api"
 	"honey_server/internal/api/log_api"
 	"honey_server/internal/api/net_api"
 	"honey_server/internal/api/node_api"
 	"honey_server/internal/api/node_network_api"
+	"honey_server/internal/api/outbox_api"
+	"honey_server/internal/api/role_api"
+	"honey_server/internal/api/transcript_api"
 	"honey_server/internal/api/user_api"
 )
 
 // Api 结构体包含各个子模块的API实例。
 type Api struct {
-	UserApi        user_api.UserApi
-	CaptchaApi     captcha_api.CaptchaApi
-	LogApi         log_api.LogApi
-	NodeApi        node_api.NodeApi
-	NodeNetworkApi node_network_api.NodeNetworkApi
-	NetApi         net_api.NetApi
-	HostApi        host_api.HostApi
-	HoneyIPApi     honey_ip_api.HoneyIPApi
-	HoneyPortApi   honey_port_api.HoneyPortApi
+	UserApi         user_api.UserApi
+	CaptchaApi      captcha_api.CaptchaApi
+	LogApi          log_api.LogApi
+	NodeApi         node_api.NodeApi
+	NodeNetworkApi  node_network_api.NodeNetworkApi
+	NetApi          net_api.NetApi
+	HostApi         host_api.HostApi
+	HoneyIPApi      honey_ip_api.HoneyIPApi
+	HoneyPortApi    honey_port_api.HoneyPortApi
+	OutboxApi       outbox_api.OutboxApi
+	DeadLetterApi   dead_letter_api.DeadLetterApi
+	InteractionApi  interaction_api.InteractionApi
+	CredentialApi   credential_api.CredentialApi
+	TranscriptApi   transcript_api.TranscriptApi
+	EventApi        event_api.EventApi
+	AlertRuleApi    alert_rule_api.AlertRuleApi
+	AlertChannelApi alert_channel_api.AlertChannelApi
+	AlertApi        alert_api.AlertApi
+	RoleApi         role_api.RoleApi
+	ApiKeyApi       api_key_api.ApiKeyApi
 }
 
 var App = Api{}
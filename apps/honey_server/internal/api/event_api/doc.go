@@ -0,0 +1,2 @@
+// Package event_api 实时事件推送API
+package event_api
@@ -0,0 +1,8 @@
+package event_api
+
+// File: api/event_api/enter.go
+// Description: 实时事件API入口
+
+// EventApi 实时事件API入口
+type EventApi struct {
+}
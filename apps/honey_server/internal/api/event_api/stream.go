@@ -0,0 +1,107 @@
+package event_api
+
+// File: api/event_api/stream.go
+// Description: 实时事件流API，通过Server-Sent Events推送节点上下线、诱捕IP状态、扫描进度、攻击会话等事件，
+// 支持按事件类型及节点过滤，只推送订阅者有权查看的事件，断线重连时根据Last-Event-ID补发错过的事件。
+// 浏览器先通过票据接口换取一次性票据，再以 ?ticket= 建立事件流；票据只能使用一次，EventSource 的自动重连会认证失败，
+// 浏览器需在连接出错时关闭 EventSource，重新换取票据并以 ?ticket=新票据&lastEventID=最后收到的事件ID 重新建立事件流
+
+import (
+	"encoding/json"
+	"fmt"
+	"honey_server/internal/middleware"
+	"honey_server/internal/service/api_key_service"
+	"honey_server/internal/service/event_service"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/service/session_service"
+	"honey_server/internal/utils/res"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const streamPingInterval = 30 * time.Second // 心跳间隔，避免代理断开空闲连接
+
+// StreamRequest 实时事件流请求结构体
+type StreamRequest struct {
+	Types       string `form:"types"`       // 订阅的事件类型，多个用逗号分隔，为空时订阅全部
+	NodeID      uint   `form:"nodeID"`      // 只订阅指定节点的事件
+	LastEventID string `form:"lastEventID"` // 最后收到的事件ID，使用票据重新建立事件流时通过该参数携带，请求头方式的重连会通过Last-Event-ID请求头自动携带
+}
+
+// TicketView 签发订阅事件流的一次性票据（有效期30秒），API密钥可直接通过请求头订阅，无需票据
+func (EventApi) TicketView(c *gin.Context) {
+	if middleware.GetApiKey(c) != nil {
+		res.FailWithMsg("API密钥请通过请求头订阅事件流", c)
+		return
+	}
+	ticket, err := session_service.CreateTicket(middleware.GetAuth(c))
+	if err != nil {
+		res.FailWithMsg("签发票据失败", c)
+		return
+	}
+	res.OkWithData(ticket, c)
+}
+
+// streamPermissionMap 查询订阅者拥有的事件相关权限，使用API密钥时还需在密钥的授权范围内
+func streamPermissionMap(c *gin.Context) map[string]bool {
+	permissionMap := map[string]bool{}
+	apiKey := middleware.GetApiKey(c)
+	userID := middleware.GetAuth(c).UserID
+	for _, code := range event_service.PermissionList() {
+		if apiKey != nil {
+			permissionMap[code] = api_key_service.HasScope(apiKey, code)
+		} else {
+			permissionMap[code] = role_service.HasPermission(userID, code)
+		}
+	}
+	return permissionMap
+}
+
+// StreamView 实时事件流接口处理函数
+func (EventApi) StreamView(c *gin.Context) {
+	cr := middleware.GetBind[StreamRequest](c)
+
+	filter := event_service.Filter{NodeID: cr.NodeID, PermissionMap: streamPermissionMap(c)}
+	for _, t := range strings.Split(cr.Types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			filter.TypeList = append(filter.TypeList, t)
+		}
+	}
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = cr.LastEventID
+	}
+
+	subscriber := event_service.Subscribe(filter, lastEventID)
+	defer event_service.Unsubscribe(subscriber)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // 关闭nginx缓冲
+	// 使用票据的连接不下发重连间隔：票据已被使用，需由客户端换取新票据后重新建立事件流
+	if c.Query("ticket") == "" {
+		fmt.Fprintf(c.Writer, "retry: 3000\n\n")
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-subscriber.Done:
+			// 接收过慢被断开，客户端携带最后收到的事件ID重连后从该事件继续
+			return
+		case <-ticker.C:
+			fmt.Fprintf(c.Writer, ": ping\n\n")
+		case event := <-subscriber.C:
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		}
+		c.Writer.Flush()
+	}
+}
@@ -0,0 +1,215 @@
+package honey_ip_api
+
+// File: api/honey_ip_api/bulk_create.go
+// Description: 诱捕IP批量创建API，支持IP列表、IP范围表达式及随机N个空闲IP三种方式
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/service/honey_ip_service"
+	"honey_server/internal/service/honey_port_service"
+	"honey_server/internal/utils/ip"
+	"honey_server/internal/utils/res"
+	"math/rand"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// BulkCreateRequest 诱捕IP批量创建请求结构体（IpList、IpRange、Count三选一）
+type BulkCreateRequest struct {
+	NetID          uint     `json:"netID" binding:"required"`                 // 网络ID
+	IpList         []string `json:"ipList"`                                   // IP列表
+	IpRange        string   `json:"ipRange"`                                  // IP范围表达式，如 192.168.1.10-20,192.168.1.30
+	Count          int      `json:"count" binding:"omitempty,min=1,max=1024"` // 随机选取的空闲IP数量
+	HostTemplateID uint     `json:"hostTemplateID"`                           // 主机模板ID（可选），指定时按模板配置诱捕端口
+}
+
+// BulkCreateItem 单个IP的创建结果
+type BulkCreateItem struct {
+	IP        string `json:"ip"`        // 诱捕IP
+	HoneyIPID uint   `json:"honeyIpID"` // 创建成功时的诱捕IP ID
+	Success   bool   `json:"success"`   // 是否创建成功
+	Msg       string `json:"msg"`       // 失败原因
+}
+
+// BulkCreateResponse 诱捕IP批量创建响应结构体
+type BulkCreateResponse struct {
+	Total        int              `json:"total"`        // 请求的IP总数
+	SuccessCount int              `json:"successCount"` // 创建成功的数量
+	List         []BulkCreateItem `json:"list"`         // 每个IP的创建结果
+}
+
+// BulkCreateView 诱捕IP批量创建接口处理函数
+func (HoneyIPApi) BulkCreateView(c *gin.Context) {
+	cr := middleware.GetBind[BulkCreateRequest](c)
+
+	// 三种方式只能选择一种
+	var modeCount int
+	if len(cr.IpList) > 0 {
+		modeCount++
+	}
+	if cr.IpRange != "" {
+		modeCount++
+	}
+	if cr.Count > 0 {
+		modeCount++
+	}
+	if modeCount != 1 {
+		res.FailWithMsg("ipList、ipRange、count需且只能指定一个", c)
+		return
+	}
+
+	// 校验网络、节点状态
+	var netModel models.NetModel
+	err := global.DB.Preload("NodeModel").Take(&netModel, cr.NetID).Error
+	if err != nil {
+		res.FailWithMsg("网络不存在", c)
+		return
+	}
+	canUseList, err := netModel.IpRange()
+	if err != nil || len(canUseList) == 0 {
+		res.FailWithMsg("网络未配置可用ip范围", c)
+		return
+	}
+	if netModel.NodeModel.Status != 1 {
+		res.FailWithMsg("节点未运行", c)
+		return
+	}
+	if _, ok := grpc_service.GetNodeCommand(netModel.NodeModel.Uid); !ok {
+		res.FailWithMsg("节点离线中", c)
+		return
+	}
+
+	// 指定主机模板时校验模板是否存在
+	var template models.HostTemplateModel
+	if cr.HostTemplateID != 0 {
+		if err = global.DB.Take(&template, cr.HostTemplateID).Error; err != nil {
+			res.FailWithMsg("主机模板不存在", c)
+			return
+		}
+	}
+
+	// 已占用的IP：主机IP与已有诱捕IP
+	var hostIPList, honeyIPList []string
+	global.DB.Model(&models.HostModel{}).Where("net_id = ?", cr.NetID).Pluck("ip", &hostIPList)
+	global.DB.Model(&models.HoneyIpModel{}).Where("net_id = ?", cr.NetID).Pluck("ip", &honeyIPList)
+	var usedMap = map[string]string{}
+	for _, s := range hostIPList {
+		usedMap[s] = "当前ip是主机ip"
+	}
+	for _, s := range honeyIPList {
+		usedMap[s] = "当前ip已使用"
+	}
+	var canUseMap = map[string]struct{}{}
+	for _, s := range canUseList {
+		canUseMap[s] = struct{}{}
+	}
+
+	// 根据请求方式得到候选IP列表
+	var ipList []string
+	switch {
+	case len(cr.IpList) > 0:
+		ipList = cr.IpList
+	case cr.IpRange != "":
+		ipList, err = ip.ParseIPRange(cr.IpRange)
+		if err != nil {
+			res.FailWithMsg(fmt.Sprintf("ip范围解析失败 %s", err), c)
+			return
+		}
+	default:
+		// 从空闲IP中随机选取
+		var freeList []string
+		for _, s := range canUseList {
+			if _, ok := usedMap[s]; !ok {
+				freeList = append(freeList, s)
+			}
+		}
+		rand.Shuffle(len(freeList), func(i, j int) {
+			freeList[i], freeList[j] = freeList[j], freeList[i]
+		})
+		if cr.Count > len(freeList) {
+			res.FailWithMsg(fmt.Sprintf("空闲ip不足 需要%d个 仅剩%d个", cr.Count, len(freeList)), c)
+			return
+		}
+		ipList = freeList[:cr.Count]
+	}
+	if len(ipList) > 1024 {
+		res.FailWithMsg("单次最多创建1024个诱捕ip", c)
+		return
+	}
+
+	// 逐个校验候选IP，记录每个IP的结果
+	var data = BulkCreateResponse{Total: len(ipList)}
+	var modelList []models.HoneyIpModel
+	var seenMap = map[string]struct{}{}
+	for _, s := range ipList {
+		item := BulkCreateItem{IP: s}
+		if _, ok := seenMap[s]; ok {
+			item.Msg = "ip重复"
+		} else if _, ok := canUseMap[s]; !ok {
+			item.Msg = "当前ip不存在可部署ip列表里面"
+		} else if msg, ok := usedMap[s]; ok {
+			item.Msg = msg
+		} else {
+			item.Success = true
+			modelList = append(modelList, models.HoneyIpModel{
+				NodeID:    netModel.NodeID,
+				NetID:     netModel.ID,
+				IP:        s,
+				Status:    1, // 创建中
+				Operation: 1, // 待完成的操作为创建
+			})
+		}
+		seenMap[s] = struct{}{}
+		data.List = append(data.List, item)
+	}
+	if len(modelList) == 0 {
+		res.Ok(data, "没有可创建的诱捕ip", c)
+		return
+	}
+
+	// 诱捕IP批量入库与创建消息写入发件箱在同一事务中完成，同一节点的创建消息合并为一条
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&modelList).Error; err != nil {
+			return err
+		}
+		for i := range modelList {
+			modelList[i].NetModel = netModel
+			modelList[i].NodeModel = netModel.NodeModel
+		}
+		if err := honey_ip_service.SendCreateBatchMsg(tx, modelList); err != nil {
+			return err
+		}
+		// 应用主机模板的端口配置
+		if cr.HostTemplateID != 0 {
+			for _, model := range modelList {
+				if _, err := honey_port_service.ApplyTemplate(tx, model, template); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		res.FailWithMsg("批量创建诱捕ip失败", c)
+		return
+	}
+
+	// 回填创建成功的诱捕IP ID
+	var idMap = map[string]uint{}
+	for _, model := range modelList {
+		idMap[model.IP] = model.ID
+	}
+	for i, item := range data.List {
+		if item.Success {
+			data.List[i].HoneyIPID = idMap[item.IP]
+			data.SuccessCount++
+		}
+	}
+
+	res.Ok(data, fmt.Sprintf("批量创建诱捕ip 共%d个，成功%d个", data.Total, data.SuccessCount), c)
+}
@@ -8,17 +8,20 @@ import (
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
 	"honey_server/internal/service/grpc_service"
-	"honey_server/internal/service/mq_service"
+	"honey_server/internal/service/honey_ip_service"
+	"honey_server/internal/service/honey_port_service"
 	"honey_server/internal/utils"
 	"honey_server/internal/utils/res"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // CreateRequest 诱捕IP创建请求结构体
 type CreateRequest struct {
-	NetID uint   `json:"netID" binding:"required"` // 网络ID
-	IP    string `json:"ip" binding:"required"`    // 诱捕IP地址
+	NetID          uint   `json:"netID" binding:"required"` // 网络ID
+	IP             string `json:"ip" binding:"required"`    // 诱捕IP地址
+	HostTemplateID uint   `json:"hostTemplateID"`           // 主机模板ID（可选），指定时按模板配置诱捕端口
 }
 
 // CreateView 诱捕IP创建接口处理函数
@@ -74,33 +77,46 @@ func (HoneyIPApi) CreateView(c *gin.Context) {
 		return
 	}
 
+	// 合法性校验7：指定主机模板时校验模板是否存在
+	var template models.HostTemplateModel
+	if cr.HostTemplateID != 0 {
+		if err = global.DB.Take(&template, cr.HostTemplateID).Error; err != nil {
+			res.FailWithMsg("主机模板不存在", c)
+			return
+		}
+	}
+
 	// 构建诱捕IP模型并入库
 	var model = models.HoneyIpModel{
-		NodeID: netModel.NodeID, // 关联节点ID
-		NetID:  netModel.ID,     // 关联网络ID
-		IP:     cr.IP,           // 诱捕IP地址
-		Status: 1,               // 状态设为启用
+		NodeID:    netModel.NodeID, // 关联节点ID
+		NetID:     netModel.ID,     // 关联网络ID
+		IP:        cr.IP,           // 诱捕IP地址
+		Status:    1,               // 状态设为启用
+		Operation: 1,               // 待完成的操作为创建
 	}
-	err = global.DB.Create(&model).Error
+	// 诱捕IP入库与创建消息写入发件箱在同一事务中完成，保证两者同时成功或同时失败
+	err = global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		// 发送创建IP消息给节点
+		model.NetModel = netModel
+		model.NodeModel = netModel.NodeModel
+		if err := honey_ip_service.SendCreateMsg(tx, model); err != nil {
+			return err
+		}
+		// 应用主机模板的端口配置（节点侧端口监听会在诱捕IP创建完成后自动恢复）
+		if cr.HostTemplateID != 0 {
+			_, err := honey_port_service.ApplyTemplate(tx, model, template)
+			return err
+		}
+		return nil
+	})
 	if err != nil {
 		res.FailWithMsg("创建诱捕ip失败", c)
 		return
 	}
 
-	var isTan bool
-	if netModel.IP == model.IP {
-		isTan = true
-	}
-
-	// 发送创建IP消息给节点
-	mq_service.SendCreateIPMsg(netModel.NodeModel.Uid, mq_service.CreateIPRequest{
-		HoneyIPID: model.ID,
-		IP:        model.IP,
-		Mask:      netModel.Mask,
-		Network:   netModel.Network,
-		IsTan:     isTan,
-	})
-
 	// 创建成功，返回诱捕IP记录ID
 	res.OkWithData(model.ID, c)
 }
@@ -8,10 +8,11 @@ import (
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
 	"honey_server/internal/service/grpc_service"
-	"honey_server/internal/service/mq_service"
+	"honey_server/internal/service/honey_ip_service"
 	"honey_server/internal/utils/res"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // RemoveView 诱捕IP批量删除接口处理函数
@@ -44,26 +45,17 @@ func (HoneyIPApi) RemoveView(c *gin.Context) {
 		return
 	}
 
-	// 下发批量删除的任务到消息队列（异步处理实际删除逻辑）
-	req := mq_service.DeleteIPRequest{
-		LogID: "",
-	}
-	for _, model := range honeyIPList {
-		var isTan bool
-		if model.NetModel.IP == model.IP {
-			isTan = true
+	// 删除消息写入发件箱与状态更新为删除中（状态码4）在同一事务中完成
+	err := global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := honey_ip_service.SendDeleteMsg(tx, nodeModel.Uid, honeyIPList); err != nil {
+			return err
 		}
-		req.IpList = append(req.IpList, mq_service.IpInfo{
-			HoneyIPID: model.ID,
-			IP:        model.IP,
-			Network:   model.Network,
-			IsTan:     isTan,
-		})
+		return tx.Model(&honeyIPList).Updates(map[string]any{"status": 4, "operation": 2}).Error
+	})
+	if err != nil {
+		res.FailWithMsg("删除诱捕ip失败", c)
+		return
 	}
-	mq_service.SendDeleteIPMsg(nodeModel.Uid, req)
-
-	// 更新诱捕IP状态为删除中（状态码4）
-	global.DB.Model(&honeyIPList).Update("status", 4)
 
 	// 返回删除任务启动成功的提示
 	res.OkWithMsg("批量删除中", c)
@@ -0,0 +1,49 @@
+package honey_ip_api
+
+// File: api/honey_ip_api/retry.go
+// Description: 诱捕IP手动重试API，重新下发创建中、删除中或失败状态诱捕IP的消息
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/service/honey_ip_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// RetryView 诱捕IP手动重试接口处理函数
+// 按待完成的操作重新下发：创建中、创建失败的诱捕IP重新下发创建消息；删除中、删除失败的诱捕IP重新下发删除消息；运行中的诱捕IP跳过
+func (HoneyIPApi) RetryView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDListRequest](c)
+
+	var honeyIPList []models.HoneyIpModel
+	global.DB.Preload("NodeModel").Preload("NetModel").
+		Find(&honeyIPList, "id in ? and status in ?", cr.IdList, []int8{1, 3, 4})
+	if len(honeyIPList) == 0 {
+		res.FailWithMsg("未找到可重试的诱捕ip", c)
+		return
+	}
+
+	var successCount int
+	for _, model := range honeyIPList {
+		// 节点未运行或离线时无法重试
+		if model.NodeModel.Status != 1 {
+			continue
+		}
+		if _, ok := grpc_service.GetNodeCommand(model.NodeModel.Uid); !ok {
+			continue
+		}
+		if err := honey_ip_service.Retry(model, false); err != nil {
+			logrus.Errorf("诱捕ip %s 重试失败 %s", model.IP, err)
+			continue
+		}
+		successCount++
+	}
+
+	res.OkWithMsg(fmt.Sprintf("重试诱捕ip 共%d个，成功%d个", len(honeyIPList), successCount), c)
+}
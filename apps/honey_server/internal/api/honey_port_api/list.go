@@ -16,12 +16,14 @@ import (
 type ListRequest struct {
 	models.PageInfo
 	HoneyIPID uint `form:"honeyIpID" binding:"required"` // 关联的诱捕IP ID
+	Status    int8 `form:"status"`                       // 按状态筛选（1 绑定中 2 监听中 3 绑定失败）
 }
 
 // ListResponse 诱捕端口列表查询响应结构体
 type ListResponse struct {
 	models.HoneyPortModel
 	ServiceTitle string `json:"serviceTitle"` // 关联服务的名称
+	Failed       bool   `json:"failed"`       // 是否绑定失败
 }
 
 // ListView 诱捕端口列表查询接口处理函数
@@ -30,9 +32,9 @@ func (HoneyPortApi) ListView(c *gin.Context) {
 	cr := middleware.GetBind[ListRequest](c)
 
 	// 调用通用查询服务获取诱捕端口数据
-	_list, count, _ := common_service.QueryList(models.HoneyPortModel{HoneyIpID: cr.HoneyIPID}, common_service.QueryListRequest{
+	_list, count, _ := common_service.QueryList(models.HoneyPortModel{HoneyIpID: cr.HoneyIPID, Status: cr.Status}, common_service.QueryListRequest{
 		PageInfo: cr.PageInfo,
-		Sort:     "created_at desc",
+		Sort:     "status = 3 desc, created_at desc", // 绑定失败的端口排在前面
 		Preload:  []string{"ServiceModel"},
 	})
 
@@ -42,6 +44,7 @@ func (HoneyPortApi) ListView(c *gin.Context) {
 		list = append(list, ListResponse{
 			HoneyPortModel: model,
 			ServiceTitle:   model.ServiceModel.Title, // 从关联的ServiceModel获取服务名称
+			Failed:         model.Status == 3,
 		})
 	}
 
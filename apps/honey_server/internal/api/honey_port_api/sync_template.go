@@ -0,0 +1,41 @@
+package honey_port_api
+
+// File: api/honey_port_api/sync_template.go
+// Description: 主机模板同步API，主机模板修改后将新的端口配置重新下发到所有使用该模板的诱捕IP
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/honey_port_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SyncTemplateRequest 主机模板同步请求结构体
+type SyncTemplateRequest struct {
+	HostTemplateID uint `json:"hostTemplateID" binding:"required"` // 主机模板ID
+}
+
+// SyncTemplateView 主机模板同步接口处理函数，返回每个诱捕IP的同步结果
+func (HoneyPortApi) SyncTemplateView(c *gin.Context) {
+	cr := middleware.GetBind[SyncTemplateRequest](c)
+
+	var template models.HostTemplateModel
+	if err := global.DB.Take(&template, cr.HostTemplateID).Error; err != nil {
+		res.FailWithMsg("主机模板不存在", c)
+		return
+	}
+
+	list := honey_port_service.PropagateTemplate(template)
+	var successCount int
+	for _, item := range list {
+		if item.Success {
+			successCount++
+		}
+	}
+
+	res.Ok(list, fmt.Sprintf("同步诱捕ip 共%d个，成功%d个", len(list), successCount), c)
+}
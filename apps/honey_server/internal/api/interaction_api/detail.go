@@ -0,0 +1,25 @@
+package interaction_api
+
+// File: api/interaction_api/detail.go
+// Description: 交互会话详情API
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DetailView 根据ID获取交互会话详情（包含会话内的全部交互事件）
+func (InteractionApi) DetailView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDRequest](c)
+	var model models.InteractionModel
+	err := global.DB.Take(&model, cr.Id).Error
+	if err != nil {
+		res.FailWithMsg("交互会话不存在", c)
+		return
+	}
+	res.OkWithData(model, c)
+}
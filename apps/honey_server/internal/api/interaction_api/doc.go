@@ -0,0 +1,2 @@
+// Package interaction_api 内置模拟器交互会话API
+package interaction_api
@@ -0,0 +1,8 @@
+package interaction_api
+
+// File: api/interaction_api/enter.go
+// Description: 交互会话API入口
+
+// InteractionApi 交互会话API入口
+type InteractionApi struct {
+}
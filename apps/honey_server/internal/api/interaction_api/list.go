@@ -0,0 +1,43 @@
+package interaction_api
+
+// File: api/interaction_api/list.go
+// Description: 交互会话列表查询API
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 交互会话列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	NodeID    uint   `form:"nodeID"`    // 按节点筛选
+	HoneyIpID uint   `form:"honeyIpID"` // 按诱捕IP筛选
+	Protocol  string `form:"protocol"`  // 按模拟器协议筛选
+	SrcIP     string `form:"srcIP"`     // 按攻击者IP筛选
+}
+
+// ListView 交互会话列表查询接口处理函数，列表不返回事件明细，明细通过详情接口查看
+func (InteractionApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.InteractionModel{
+		NodeID:    cr.NodeID,
+		HoneyIpID: cr.HoneyIpID,
+		Protocol:  cr.Protocol,
+		SrcIP:     cr.SrcIP,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"src_ip", "dst_ip"},
+		Sort:     "start_time desc",
+	})
+	for i := range list {
+		list[i].EventList = nil
+	}
+
+	res.OkWithList(list, count, c)
+}
@@ -5,12 +5,14 @@ package log_api
 
 import (
 	"fmt"
+	"honey_server/internal/global"
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
 	"honey_server/internal/service/common_service"
 	"honey_server/internal/utils/res"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // LogApi 日志接口结构体
@@ -20,24 +22,40 @@ type LogApi struct {
 // LogListRequest 日志列表查询参数
 type LogListRequest struct {
 	models.PageInfo
-	Type int8   `form:"type"` // 日志类型（1 登录日志）
-	IP   string `form:"ip"`   // 按 IP 精确搜索
-	Addr string `form:"addr"` // 按归属地精确搜索
+	Type        int8   `form:"type"`        // 日志类型（1 登录日志 2 操作审计日志）
+	IP          string `form:"ip"`          // 按 IP 精确搜索
+	Addr        string `form:"addr"`        // 按归属地精确搜索
+	UserID      uint   `form:"userID"`      // 按操作用户筛选
+	Level       int8   `form:"level"`       // 按日志级别筛选（审计日志 1 成功 2 失败）
+	ServiceName string `form:"serviceName"` // 按服务名称筛选
+	Method      string `form:"method"`      // 按请求方法筛选
+	Path        string `form:"path"`        // 按请求路由前缀筛选
 }
 
-// LogListView 查询日志列表，支持分页、模糊搜索 username、按 IP/Addr 筛选
+// LogListView 查询日志列表，支持分页、模糊搜索 username/path、按 IP/Addr、操作用户、请求方法与路由等筛选
 func (LogApi) LogListView(c *gin.Context) {
 	cr := middleware.GetBind[LogListRequest](c) // 绑定查询参数
 
+	// 请求路由按前缀匹配
+	var where *gorm.DB
+	if cr.Path != "" {
+		where = global.DB.Where("path like ?", cr.Path+"%")
+	}
+
 	// 根据筛选条件与分页参数查询列表
 	list, count, _ := common_service.QueryList(models.LogModel{
-		Type: cr.Type,
-		IP:   cr.IP,
-		Addr: cr.Addr,
+		Type:        cr.Type,
+		IP:          cr.IP,
+		Addr:        cr.Addr,
+		UserID:      cr.UserID,
+		Level:       cr.Level,
+		ServiceName: cr.ServiceName,
+		Method:      cr.Method,
 	}, common_service.QueryListRequest{
-		Likes:    []string{"username"}, // 用户名模糊搜索
-		PageInfo: cr.PageInfo,          // 分页参数
-		Sort:     "created_at desc",    // 按创建时间倒序
+		Likes:    []string{"username", "path"}, // 用户名、请求路由模糊搜索
+		Where:    where,                        // 请求路由前缀筛选
+		PageInfo: cr.PageInfo,                  // 分页参数
+		Sort:     "created_at desc",            // 按创建时间倒序
 	})
 
 	res.OkWithList(list, count, c)
@@ -10,6 +10,7 @@ import (
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
 	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/event_service"
 	"honey_server/internal/service/grpc_service"
 	"honey_server/internal/utils/res"
 	"sync"
@@ -141,6 +142,11 @@ func (NetApi) ScanView(c *gin.Context) {
 				if message.Ip != "" {
 					netScanMsg = append(netScanMsg, message)
 					netProgressMap.Store(uint(message.NetID), float64(message.Progress))
+					event_service.Publish(event_service.NetScanProgress, netModel.NodeID, event_service.NetScanData{
+						NetID:    uint(message.NetID),
+						Progress: float64(message.Progress),
+						IP:       message.Ip,
+					})
 					fmt.Printf("网络扫描 %s %s %s %.2f\n", message.Ip, message.Mac, message.Manuf, message.Progress)
 				}
 
@@ -170,6 +176,10 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 			"scan_status":   1,
 		})
 		netProgressMap.Delete(netModel.ID)
+		event_service.Publish(event_service.NetScanComplete, netModel.NodeID, event_service.NetScanData{
+			NetID:    netModel.ID,
+			Progress: 100,
+		})
 	}()
 
 	// 查询当前网络下的所有主机信息
@@ -196,6 +206,7 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 	// 3. 对比两个映射，确定新增、更新和删除的主机
 	var newHosts []models.HostModel     // 新增的主机
 	var deletedHostIDs []uint           // 需删除的主机ID
+	var deletedHosts []models.HostModel // 需删除的主机
 	var updatedHosts []models.HostModel // 需更新的主机
 
 	// 处理新增和更新的主机
@@ -223,6 +234,7 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 	// 收集需删除的主机ID（数据库中存在但扫描结果中不存在的主机）
 	for _, dbHost := range dbHostMap {
 		deletedHostIDs = append(deletedHostIDs, dbHost.ID)
+		deletedHosts = append(deletedHosts, dbHost)
 	}
 
 	// 打印扫描结果统计信息
@@ -267,5 +279,21 @@ func processScanResult(netModel models.NetModel, scanMsgs []*node_rpc.NetScanOut
 		logrus.Errorf("更新网络 %d 的扫描结果失败: %v", netModel.ID, err)
 	} else {
 		logrus.Infof("成功更新网络 %d 的扫描结果", netModel.ID)
+		publishHostChange(netModel, "new", newHosts)
+		publishHostChange(netModel, "update", updatedHosts)
+		publishHostChange(netModel, "delete", deletedHosts)
+	}
+}
+
+// publishHostChange 发布主机变化事件
+func publishHostChange(netModel models.NetModel, change string, hostList []models.HostModel) {
+	for _, host := range hostList {
+		event_service.Publish(event_service.HostChange, netModel.NodeID, event_service.HostChangeData{
+			NetID:  netModel.ID,
+			Change: change,
+			IP:     host.IP,
+			Mac:    host.Mac,
+			Manuf:  host.Manuf,
+		})
 	}
 }
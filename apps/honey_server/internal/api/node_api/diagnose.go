@@ -0,0 +1,144 @@
+package node_api
+
+// File: api/node_api/diagnose.go
+// Description: 节点远程诊断接口，向节点下发白名单内的诊断命令并汇总节点流式返回的输出
+
+import (
+	"context"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/utils/res"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// DiagnoseRequest 节点远程诊断请求参数结构体
+type DiagnoseRequest struct {
+	ID      uint   `uri:"id" json:"-"`                                                                    // 节点ID
+	Type    string `json:"type" binding:"required,oneof=arping tcp_connect link addr listen neigh route"` // 诊断类型
+	IP      string `json:"ip" binding:"omitempty,ip"`                                                     // 目标IP（arping、tcp_connect必填）
+	Port    int32  `json:"port" binding:"omitempty,min=1,max=65535"`                                      // 目标端口（tcp_connect必填）
+	Network string `json:"network"`                                                                       // 网卡名称（arping必填，其余可选）
+}
+
+// DiagnoseResponse 节点远程诊断响应结构体
+type DiagnoseResponse struct {
+	Output string `json:"output"` // 诊断输出
+	ErrMsg string `json:"errMsg"` // 节点返回的错误信息
+}
+
+// diagnoseTypeMap 诊断类型名称 → 节点诊断类型枚举
+var diagnoseTypeMap = map[string]node_rpc.DiagnoseType{
+	"arping":      node_rpc.DiagnoseType_diagnoseArpingType,
+	"tcp_connect": node_rpc.DiagnoseType_diagnoseTcpConnectType,
+	"link":        node_rpc.DiagnoseType_diagnoseLinkListType,
+	"addr":        node_rpc.DiagnoseType_diagnoseAddrListType,
+	"listen":      node_rpc.DiagnoseType_diagnoseListenListType,
+	"neigh":       node_rpc.DiagnoseType_diagnoseNeighListType,
+	"route":       node_rpc.DiagnoseType_diagnoseRouteListType,
+}
+
+// DiagnoseView 节点远程诊断接口处理函数
+func (NodeApi) DiagnoseView(c *gin.Context) {
+	cr := middleware.GetBind[DiagnoseRequest](c)
+
+	// 按诊断类型校验必填参数
+	switch cr.Type {
+	case "arping":
+		if cr.IP == "" || cr.Network == "" {
+			res.FailWithMsg("arping需要指定ip和网卡", c)
+			return
+		}
+	case "tcp_connect":
+		if cr.IP == "" || cr.Port == 0 {
+			res.FailWithMsg("tcp连接测试需要指定ip和端口", c)
+			return
+		}
+	}
+
+	// 根据ID查询节点信息，验证节点是否存在
+	var model models.NodeModel
+	if err := global.DB.Take(&model, cr.ID).Error; err != nil {
+		res.FailWithMsg("节点不存在", c)
+		return
+	}
+
+	// 验证节点是否处于运行状态（状态1表示运行中）
+	if model.Status != 1 {
+		res.FailWithMsg("节点未运行", c)
+		return
+	}
+
+	// 获取节点对应的命令交互实例，验证节点是否在线
+	cmd, ok := grpc_service.GetNodeCommand(model.Uid)
+	if !ok {
+		res.FailWithMsg("节点离线中", c)
+		return
+	}
+
+	// 构建诊断命令请求，参数以结构化字段下发，节点侧按白名单执行
+	taskID := fmt.Sprintf("diagnose-%d", time.Now().UnixNano())
+	req := &node_rpc.CmdRequest{
+		CmdType: node_rpc.CmdType_cmdDiagnoseType,
+		TaskID:  taskID,
+		DiagnoseInMessage: &node_rpc.DiagnoseInMessage{
+			DiagnoseType: diagnoseTypeMap[cr.Type],
+			Ip:           cr.IP,
+			Port:         cr.Port,
+			Network:      cr.Network,
+		},
+	}
+
+	// 创建带30秒超时的上下文，控制命令发送和响应接收的超时时间
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	select {
+	case cmd.ReqChan <- req:
+		logrus.Debugf("已向节点[%s]发送诊断请求，任务ID: %s", model.Uid, taskID)
+	case <-ctx.Done():
+		res.FailWithMsg("发送命令超时", c)
+		return
+	}
+
+	// 汇总节点分批返回的诊断输出，直到收到结束标识
+	var outputList []string
+	var data DiagnoseResponse
+	for {
+		select {
+		case response := <-cmd.ResChan:
+			// 非当前任务的响应放回通道，避免影响其他任务
+			if response.TaskID != taskID {
+				select {
+				case cmd.ResChan <- response:
+				case <-ctx.Done():
+				}
+				continue
+			}
+			message := response.DiagnoseOutMessage
+			if message == nil {
+				continue
+			}
+			if message.Output != "" {
+				outputList = append(outputList, message.Output)
+			}
+			if !message.End {
+				continue
+			}
+			data.Output = strings.Join(outputList, "\n")
+			data.ErrMsg = message.ErrMsg
+			res.OkWithData(data, c)
+			return
+		case <-ctx.Done():
+			res.FailWithMsg("获取响应超时", c)
+			return
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package outbox_api 消息发件箱管理API
+package outbox_api
@@ -0,0 +1,8 @@
+package outbox_api
+
+// File: api/outbox_api/enter.go
+// Description: 消息发件箱API入口
+
+// OutboxApi 消息发件箱API入口
+type OutboxApi struct {
+}
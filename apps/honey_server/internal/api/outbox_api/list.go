@@ -0,0 +1,36 @@
+package outbox_api
+
+// File: api/outbox_api/list.go
+// Description: 消息发件箱列表查询API
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 发件箱列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	Status     int8   `form:"status"`     // 按状态筛选（1 待发送 2 已发送 3 发送失败）
+	RoutingKey string `form:"routingKey"` // 按目标节点UID筛选
+}
+
+// ListView 发件箱列表查询接口处理函数，用于排查未投递或投递失败的消息
+func (OutboxApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.MqOutboxModel{
+		Status:     cr.Status,
+		RoutingKey: cr.RoutingKey,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"message_id"},
+		Sort:     "created_at desc",
+	})
+
+	res.OkWithList(list, count, c)
+}
@@ -0,0 +1,35 @@
+package outbox_api
+
+// File: api/outbox_api/retry.go
+// Description: 发送失败消息的手动重试API
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/res"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RetryView 将发送失败的消息重置为待发送，由发件箱投递器重新投递
+func (OutboxApi) RetryView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDListRequest](c)
+
+	// 仅处理发送失败（状态码3）的消息，重置重试次数并立即投递
+	result := global.DB.Model(&models.MqOutboxModel{}).
+		Where("id in ? and status = ?", cr.IdList, 3).
+		Updates(map[string]any{
+			"status":        1,
+			"retry_count":   0,
+			"next_retry_at": time.Now(),
+		})
+	if result.Error != nil {
+		res.FailWithMsg("重试消息失败", c)
+		return
+	}
+
+	res.OkWithMsg(fmt.Sprintf("已重新加入发送队列 共%d个", result.RowsAffected), c)
+}
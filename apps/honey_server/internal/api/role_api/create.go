@@ -0,0 +1,43 @@
+package role_api
+
+// File: api/role_api/create.go
+// Description: 角色创建API
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateView 角色创建接口处理函数
+func (RoleApi) CreateView(c *gin.Context) {
+	cr := middleware.GetBind[RoleRequest](c)
+	log := middleware.GetLog(c)
+
+	if msg := cr.validate(); msg != "" {
+		res.FailWithMsg(msg, c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.RoleModel{}).Where("title = ?", cr.Title).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("角色名称不能重复", c)
+		return
+	}
+
+	model := models.RoleModel{
+		Title:          cr.Title,
+		PermissionList: cr.PermissionList,
+		Remark:         cr.Remark,
+	}
+	if err := global.DB.Create(&model).Error; err != nil {
+		log.Errorf("创建角色失败 %s", err)
+		res.FailWithMsg("创建角色失败", c)
+		return
+	}
+
+	res.OkWithData(model.ID, c)
+}
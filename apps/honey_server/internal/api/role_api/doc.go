@@ -0,0 +1,2 @@
+// Package role_api 角色管理API
+package role_api
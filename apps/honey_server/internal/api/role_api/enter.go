@@ -0,0 +1,30 @@
+package role_api
+
+// File: api/role_api/enter.go
+// Description: 角色API入口
+
+import (
+	"fmt"
+	"honey_server/internal/service/role_service"
+)
+
+// RoleApi 角色API入口
+type RoleApi struct {
+}
+
+// RoleRequest 角色的公共请求字段
+type RoleRequest struct {
+	Title          string   `json:"title" binding:"required,max=32" label:"角色名称"`       // 角色名称
+	PermissionList []string `json:"permissionList" binding:"required,min=1" label:"权限"` // 权限列表
+	Remark         string   `json:"remark" binding:"max=128" label:"备注"`                // 备注
+}
+
+// validate 校验权限码，返回错误信息
+func (cr RoleRequest) validate() string {
+	for _, code := range cr.PermissionList {
+		if !role_service.ValidPermission(code) {
+			return fmt.Sprintf("权限 %s 不存在", code)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package role_api
+
+// File: api/role_api/list.go
+// Description: 角色列表、角色选项及权限清单查询API
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 角色列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+}
+
+// ListView 角色列表查询接口处理函数
+func (RoleApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.RoleModel{}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"title"},
+		Sort:     "id asc",
+	})
+
+	res.OkWithList(list, count, c)
+}
+
+// OptionsResponse 角色选项
+type OptionsResponse struct {
+	Label string `json:"label"` // 角色名称
+	Value uint   `json:"value"` // 角色ID
+}
+
+// OptionsView 角色选项接口处理函数，用于给用户分配角色
+func (RoleApi) OptionsView(c *gin.Context) {
+	var list = make([]OptionsResponse, 0)
+	global.DB.Model(models.RoleModel{}).Order("id asc").
+		Select("title as label", "id as value").Scan(&list)
+	res.OkWithData(list, c)
+}
+
+// PermissionListView 权限清单接口处理函数
+func (RoleApi) PermissionListView(c *gin.Context) {
+	res.OkWithData(role_service.PermissionList, c)
+}
@@ -0,0 +1,48 @@
+package role_api
+
+// File: api/role_api/remove.go
+// Description: 角色删除API，内置角色及仍有用户使用的角色不能删除
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RemoveView 角色批量删除接口处理函数
+func (RoleApi) RemoveView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDListRequest](c)
+	log := middleware.GetLog(c)
+
+	var count int64
+	global.DB.Model(&models.RoleModel{}).Where("id in ? and builtin = ?", cr.IdList, true).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("内置角色不能删除", c)
+		return
+	}
+	global.DB.Model(&models.UserModel{}).Where("role_id in ?", cr.IdList).Count(&count)
+	if count > 0 {
+		res.FailWithMsg(fmt.Sprintf("有%d个用户使用了待删除的角色，请先修改用户角色", count), c)
+		return
+	}
+
+	successCount, err := common_service.Remove(models.RoleModel{}, common_service.RemoveRequest{
+		IDList: cr.IdList,
+		Log:    log,
+		Msg:    "角色",
+	})
+	if err != nil {
+		res.FailWithMsg(fmt.Sprintf("删除角色失败 %s", err), c)
+		return
+	}
+	role_service.Invalidate()
+
+	msg := fmt.Sprintf("删除成功 共%d个，成功%d个", len(cr.IdList), successCount)
+	res.OkWithMsg(msg, c)
+}
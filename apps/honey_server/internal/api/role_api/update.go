@@ -0,0 +1,58 @@
+package role_api
+
+// File: api/role_api/update.go
+// Description: 角色更新API
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateRequest 角色更新请求结构体
+type UpdateRequest struct {
+	ID uint `json:"id" binding:"required"` // 角色ID
+	RoleRequest
+}
+
+// UpdateView 角色更新接口处理函数
+func (RoleApi) UpdateView(c *gin.Context) {
+	cr := middleware.GetBind[UpdateRequest](c)
+	log := middleware.GetLog(c)
+
+	var model models.RoleModel
+	if err := global.DB.Take(&model, cr.ID).Error; err != nil {
+		res.FailWithMsg("角色不存在", c)
+		return
+	}
+	if model.Builtin {
+		res.FailWithMsg("内置角色不能修改", c)
+		return
+	}
+	if msg := cr.validate(); msg != "" {
+		res.FailWithMsg(msg, c)
+		return
+	}
+	var count int64
+	global.DB.Model(&models.RoleModel{}).Where("title = ? and id <> ?", cr.Title, cr.ID).Count(&count)
+	if count > 0 {
+		res.FailWithMsg("角色名称不能重复", c)
+		return
+	}
+
+	model.Title = cr.Title
+	model.PermissionList = cr.PermissionList
+	model.Remark = cr.Remark
+	if err := global.DB.Save(&model).Error; err != nil {
+		log.Errorf("更新角色失败 %s", err)
+		res.FailWithMsg("更新角色失败", c)
+		return
+	}
+	role_service.Invalidate()
+
+	res.OkWithMsg("更新角色成功", c)
+}
@@ -0,0 +1,2 @@
+// Package transcript_api 隧道会话录制查询、下载与回放API
+package transcript_api
@@ -0,0 +1,58 @@
+package transcript_api
+
+// File: api/transcript_api/download.go
+// Description: 会话录制下载API，支持下载原始数据或重建的pcap文件
+
+import (
+	"bytes"
+	"fmt"
+	"honey_server/internal/middleware"
+	"honey_server/internal/service/transcript_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadRequest 会话录制下载请求结构体
+type DownloadRequest struct {
+	ID        uint   `form:"id" binding:"required"`                      // 会话录制ID
+	Format    string `form:"format" binding:"omitempty,oneof=raw pcap"`  // 下载格式 raw（默认）原始数据 pcap 重建的抓包文件
+	Direction string `form:"direction" binding:"omitempty,oneof=in out"` // 原始数据的方向 in 攻击者发送 out 诱捕服务返回，为空时双向按时间顺序拼接
+}
+
+// DownloadView 会话录制下载接口处理函数
+func (TranscriptApi) DownloadView(c *gin.Context) {
+	cr := middleware.GetBind[DownloadRequest](c)
+
+	model, frameList, msg := readTranscript(cr.ID)
+	if msg != "" {
+		res.FailWithMsg(msg, c)
+		return
+	}
+
+	var buf bytes.Buffer
+	var fileName string
+	switch cr.Format {
+	case "pcap":
+		if err := transcript_service.WritePcap(&buf, model, frameList); err != nil {
+			res.FailWithMsg("生成pcap文件失败", c)
+			return
+		}
+		fileName = model.SessionID + ".pcap"
+	default:
+		for _, frame := range frameList {
+			if (cr.Direction == "in" && frame.Direction != transcript_service.DirectionIn) ||
+				(cr.Direction == "out" && frame.Direction != transcript_service.DirectionOut) {
+				continue
+			}
+			buf.Write(frame.Data)
+		}
+		fileName = model.SessionID + ".bin"
+		if cr.Direction != "" {
+			fileName = fmt.Sprintf("%s_%s.bin", model.SessionID, cr.Direction)
+		}
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Data(200, "application/octet-stream", buf.Bytes())
+}
@@ -0,0 +1,26 @@
+package transcript_api
+
+// File: api/transcript_api/enter.go
+// Description: 会话录制API入口
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/transcript_service"
+)
+
+// TranscriptApi 会话录制API入口
+type TranscriptApi struct {
+}
+
+// readTranscript 读取会话录制索引及录制的数据块
+func readTranscript(id uint) (model models.TranscriptModel, frameList []transcript_service.Frame, msg string) {
+	if err := global.DB.Take(&model, id).Error; err != nil {
+		return model, nil, "会话录制不存在"
+	}
+	frameList, err := transcript_service.Read(model.FilePath)
+	if err != nil {
+		return model, nil, "读取录制文件失败"
+	}
+	return model, frameList, ""
+}
@@ -0,0 +1,38 @@
+package transcript_api
+
+// File: api/transcript_api/list.go
+// Description: 会话录制列表查询API
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRequest 会话录制列表查询请求结构体
+type ListRequest struct {
+	models.PageInfo
+	NodeID    uint   `form:"nodeID"`    // 按节点筛选
+	HoneyIpID uint   `form:"honeyIpID"` // 按诱捕IP筛选
+	SrcIP     string `form:"srcIP"`     // 按攻击者IP筛选
+}
+
+// ListView 会话录制列表查询接口处理函数
+func (TranscriptApi) ListView(c *gin.Context) {
+	cr := middleware.GetBind[ListRequest](c)
+
+	list, count, _ := common_service.QueryList(models.TranscriptModel{
+		NodeID:    cr.NodeID,
+		HoneyIpID: cr.HoneyIpID,
+		SrcIP:     cr.SrcIP,
+	}, common_service.QueryListRequest{
+		PageInfo: cr.PageInfo,
+		Likes:    []string{"src_ip", "dst_ip"},
+		Sort:     "start_time desc",
+	})
+
+	res.OkWithList(list, count, c)
+}
@@ -0,0 +1,144 @@
+package transcript_api
+
+// File: api/transcript_api/live.go
+// Description: 会话实时查看API，通过WebSocket推送进行中的隧道会话的双向数据，
+// 浏览器先通过票据接口换取一次性票据，再以 ?ticket= 建立连接
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/service/session_service"
+	"honey_server/internal/service/transcript_service"
+	"honey_server/internal/utils"
+	"honey_server/internal/utils/res"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	liveWriteTimeout = 10 * time.Second // 单条消息写超时
+	livePingInterval = 30 * time.Second // 心跳间隔
+)
+
+// LiveRequest 会话实时查看请求结构体，按会话ID或诱捕IP（及端口）订阅
+type LiveRequest struct {
+	SessionID string `form:"sessionID"` // 会话ID
+	HoneyIpID uint   `form:"honeyIpID"` // 诱捕IP ID，订阅该诱捕IP上的全部会话（包括之后新建的会话）
+	Port      int    `form:"port"`      // 诱捕端口，与honeyIpID配合使用，为空时不限端口
+}
+
+// DroppedMessage 查看者接收过慢时的丢弃通知
+type DroppedMessage struct {
+	Type    string `json:"type"`    // 固定为 dropped
+	Dropped int64  `json:"dropped"` // 丢弃的消息数量
+}
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: checkOrigin,
+}
+
+// checkOrigin 校验 WebSocket 请求的来源，票据通过 query 参数传递，需防止其他站点借用户浏览器建立连接
+// 只允许同源及配置的前端站点，未携带 Origin 的非浏览器客户端不受限制
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if utils.InList(global.Config.System.SiteOrigins, origin) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// LiveTicketView 签发会话实时查看的一次性票据（有效期30秒），API密钥可直接通过请求头连接，无需票据
+func (TranscriptApi) LiveTicketView(c *gin.Context) {
+	if middleware.GetApiKey(c) != nil {
+		res.FailWithMsg("API密钥请通过请求头连接", c)
+		return
+	}
+	ticket, err := session_service.CreateTicket(middleware.GetAuth(c))
+	if err != nil {
+		res.FailWithMsg("签发票据失败", c)
+		return
+	}
+	res.OkWithData(ticket, c)
+}
+
+// LiveView 会话实时查看接口处理函数
+func (TranscriptApi) LiveView(c *gin.Context) {
+	cr := middleware.GetBind[LiveRequest](c)
+	if cr.SessionID == "" && cr.HoneyIpID == 0 {
+		res.FailWithMsg("请指定会话ID或诱捕IP", c)
+		return
+	}
+
+	viewer, err := transcript_service.Subscribe(transcript_service.LiveFilter{
+		SessionID: cr.SessionID,
+		HoneyIpID: cr.HoneyIpID,
+		Port:      cr.Port,
+	})
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	defer transcript_service.Unsubscribe(viewer)
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logrus.Errorf("websocket升级失败 %s", err)
+		return
+	}
+	defer conn.Close()
+
+	// 读取客户端消息以感知连接关闭
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(livePingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+			if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case msg, ok := <-viewer.C:
+			conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+			// 订阅的会话已结束（结束消息可能因接收过慢被丢弃），关闭连接
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			// 先告知此前因接收过慢丢弃的消息数量
+			if dropped := viewer.Dropped(); dropped > 0 {
+				if err = conn.WriteJSON(DroppedMessage{Type: "dropped", Dropped: dropped}); err != nil {
+					return
+				}
+			}
+			if err = conn.WriteJSON(msg); err != nil {
+				return
+			}
+			// 按会话ID订阅时，会话结束后关闭连接
+			if cr.SessionID != "" && msg.Type == transcript_service.LiveEnd {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package transcript_api
+
+// File: api/transcript_api/remove.go
+// Description: 会话录制删除API，同时删除磁盘上的录制文件
+
+import (
+	"fmt"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/common_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RemoveView 会话录制批量删除接口处理函数
+func (TranscriptApi) RemoveView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDListRequest](c)
+	log := middleware.GetLog(c)
+
+	// 录制文件随记录一起删除，使用硬删除避免残留无文件的记录
+	successCount, err := common_service.Remove(models.TranscriptModel{}, common_service.RemoveRequest{
+		IDList:   cr.IdList,
+		Log:      log,
+		Msg:      "会话录制",
+		Unscoped: true,
+	})
+	if err != nil {
+		res.FailWithMsg(fmt.Sprintf("删除会话录制失败 %s", err), c)
+		return
+	}
+
+	msg := fmt.Sprintf("删除成功 共%d个，成功%d个", len(cr.IdList), successCount)
+	res.OkWithMsg(msg, c)
+}
@@ -0,0 +1,55 @@
+package transcript_api
+
+// File: api/transcript_api/replay.go
+// Description: 会话录制回放API，返回带相对时间的双向数据块，由前端按原始时间间隔回放
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/transcript_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReplayFrame 回放数据块
+type ReplayFrame struct {
+	Offset    int64  `json:"offset"`    // 相对会话开始的时间，单位: 毫秒
+	Direction string `json:"direction"` // 数据方向 in 攻击者发送 out 诱捕服务返回
+	Data      []byte `json:"data"`      // 数据内容（base64编码）
+}
+
+// ReplayResponse 会话录制回放响应结构体
+type ReplayResponse struct {
+	models.TranscriptModel
+	FrameList []ReplayFrame `json:"frameList"` // 按时间顺序的数据块列表
+}
+
+// ReplayView 会话录制回放接口处理函数
+func (TranscriptApi) ReplayView(c *gin.Context) {
+	cr := middleware.GetBind[models.IDRequest](c)
+
+	model, frameList, msg := readTranscript(cr.Id)
+	if msg != "" {
+		res.FailWithMsg(msg, c)
+		return
+	}
+
+	data := ReplayResponse{
+		TranscriptModel: model,
+		FrameList:       make([]ReplayFrame, 0, len(frameList)),
+	}
+	for _, frame := range frameList {
+		item := ReplayFrame{
+			Offset:    frame.Time.Sub(model.StartTime).Milliseconds(),
+			Direction: "in",
+			Data:      frame.Data,
+		}
+		if frame.Direction == transcript_service.DirectionOut {
+			item.Direction = "out"
+		}
+		data.FrameList = append(data.FrameList, item)
+	}
+
+	res.OkWithData(data, c)
+}
@@ -16,7 +16,7 @@ import (
 type CreateRequest struct {
 	Username string `json:"username" binding:"required" label:"用户名"` // 用户名（必填）
 	Password string `json:"password" binding:"required" label:"密码"`  // 密码（必填）
-	Role     int8   `json:"role" binding:"required,ne=1" label:"角色"` // 角色（必填，不能为1）
+	RoleID   uint   `json:"roleID" binding:"required" label:"角色"`    // 角色ID（必填）
 }
 
 // CreateView 处理创建用户的 HTTP 请求
@@ -34,7 +34,7 @@ func (UserApi) CreateView(c *gin.Context) {
 	user, err := us.Create(user_service.UserCreateRequest{
 		Username: cr.Username,
 		Password: cr.Password,
-		Role:     cr.Role,
+		RoleID:   cr.RoleID,
 	})
 	if err != nil {
 		// 创建失败记录日志并返回错误响应
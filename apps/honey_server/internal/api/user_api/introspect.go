@@ -0,0 +1,72 @@
+package user_api
+
+// File: api/user_api/introspect.go
+// Description: 会话校验接口，供 image_server 等内部服务校验用户凭据。
+// 内部服务原样转发请求携带的 token 或 API密钥，本服务按与认证中间件相同的规则校验（含会话吊销），
+// 并返回用户的有效权限，内部服务据此鉴权，无需共享签名密钥
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/api_key_service"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/utils/res"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IntrospectResponse 会话校验结果
+type IntrospectResponse struct {
+	UserID         uint     `json:"userID"`         // 用户ID
+	Username       string   `json:"username"`       // 用户名
+	RoleID         uint     `json:"roleID"`         // 角色ID
+	SessionID      string   `json:"sessionID"`      // 会话ID，使用API密钥时为空
+	ApiKeyID       uint     `json:"apiKeyID"`       // API密钥ID，使用登录token时为0
+	PermissionList []string `json:"permissionList"` // 有效权限，* 表示全部权限；使用API密钥时为授权范围与用户权限的交集
+	ExpiresAt      int64    `json:"expiresAt"`      // 凭据过期时间（Unix秒），0 表示不过期
+}
+
+// IntrospectView 校验请求携带的凭据，返回用户信息与有效权限
+func (UserApi) IntrospectView(c *gin.Context) {
+	claims, apiKey, err := middleware.Authenticate(c)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	var user models.UserModel
+	if err = global.DB.Select("id", "username").Take(&user, claims.UserID).Error; err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	data := IntrospectResponse{
+		UserID:         user.ID,
+		Username:       user.Username,
+		RoleID:         claims.RoleID,
+		SessionID:      claims.SessionID,
+		PermissionList: []string{},
+	}
+	if apiKey != nil {
+		data.ApiKeyID = apiKey.ID
+		for _, code := range apiKey.ScopeList {
+			if api_key_service.HasScope(apiKey, code) {
+				data.PermissionList = append(data.PermissionList, code)
+			}
+		}
+		if apiKey.ExpiresAt != nil {
+			data.ExpiresAt = apiKey.ExpiresAt.Unix()
+		}
+	} else {
+		for code := range role_service.Permissions(claims.UserID) {
+			data.PermissionList = append(data.PermissionList, code)
+		}
+		sort.Strings(data.PermissionList)
+		if claims.ExpiresAt != nil {
+			data.ExpiresAt = claims.ExpiresAt.Unix()
+		}
+	}
+
+	res.OkWithData(data, c)
+}
@@ -0,0 +1,43 @@
+package user_api
+
+// File: api/user_api/lockout.go
+// Description: 登录锁定管理接口，查询因登录失败次数过多被锁定的用户名与客户端IP，并解除锁定
+
+import (
+	"fmt"
+	"honey_server/internal/middleware"
+	"honey_server/internal/service/login_limit_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnlockRequest 解除登录锁定请求参数
+type UnlockRequest struct {
+	Kind  string `json:"kind" binding:"required,oneof=user ip" label:"锁定对象类型"` // 锁定对象类型 user 用户名 ip 客户端IP
+	Value string `json:"value" binding:"required" label:"锁定对象"`                // 用户名或客户端IP
+}
+
+// LockoutListView 查询当前的登录锁定
+func (UserApi) LockoutListView(c *gin.Context) {
+	list, err := login_limit_service.List()
+	if err != nil {
+		res.FailWithMsg("查询登录锁定失败", c)
+		return
+	}
+	res.OkWithList(list, int64(len(list)), c)
+}
+
+// UnlockView 解除登录锁定
+func (UserApi) UnlockView(c *gin.Context) {
+	cr := middleware.GetBind[UnlockRequest](c)
+	log := middleware.GetLog(c)
+
+	if err := login_limit_service.Unlock(cr.Kind, cr.Value); err != nil {
+		res.FailWithMsg(fmt.Sprintf("解除锁定失败 %s", err), c)
+		return
+	}
+	log.Infof("解除登录锁定 %s %s", cr.Kind, cr.Value)
+
+	res.OkWithMsg("解除锁定成功", c)
+}
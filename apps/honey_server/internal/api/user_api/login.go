@@ -8,6 +8,10 @@ import (
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
 	"honey_server/internal/service/log_service"
+	"honey_server/internal/service/login_limit_service"
+	"honey_server/internal/service/session_service"
+	"honey_server/internal/service/totp_service"
+	"honey_server/internal/service/user_service"
 	"honey_server/internal/utils/captcha"
 	"honey_server/internal/utils/jwts"
 	"honey_server/internal/utils/pwd"
@@ -26,6 +30,31 @@ type LoginRequest struct {
 	CaptchaCode string `json:"captchaCode" binding:"required" label:"验证码"`
 }
 
+// LoginResponse 登录及刷新token的响应
+// 需要两步验证时只返回 challenge，使用 challenge 和验证码调用两步验证登录接口完成登录；
+// 需要修改密码时使用 challenge 和新密码调用登录修改密码接口，之后按需继续两步验证
+type LoginResponse struct {
+	Token                  string   `json:"token"`                            // access token
+	ExpiresAt              int64    `json:"expiresAt"`                        // access token 过期时间（Unix秒）
+	RefreshToken           string   `json:"refreshToken"`                     // refresh token，仅可使用一次
+	RefreshExpiresAt       int64    `json:"refreshExpiresAt"`                 // refresh token 过期时间（Unix秒）
+	PasswordChangeRequired bool     `json:"passwordChangeRequired,omitempty"` // 需要先修改密码
+	TwoFactorRequired      bool     `json:"twoFactorRequired,omitempty"`      // 需要输入两步验证码
+	EnrollRequired         bool     `json:"enrollRequired,omitempty"`         // 必须先绑定两步验证
+	Challenge              string   `json:"challenge,omitempty"`              // 修改密码或两步登录的凭证
+	RecoveryCodes          []string `json:"recoveryCodes,omitempty"`          // 登录时绑定两步验证生成的恢复码，只返回一次
+}
+
+// newLoginResponse 构造登录响应
+func newLoginResponse(token string, claims *jwts.Claims, refreshToken string, session *session_service.Session) LoginResponse {
+	return LoginResponse{
+		Token:            token,
+		ExpiresAt:        claims.ExpiresAt.Unix(),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt,
+	}
+}
+
 // 用户登录接口
 func (UserApi) LoginView(c *gin.Context) {
 	// 从请求中绑定并获取登录参数（如用户名、密码、验证码）
@@ -35,14 +64,21 @@ func (UserApi) LoginView(c *gin.Context) {
 
 	// 校验验证码是否为空
 	if cr.CaptchaID == "" || cr.CaptchaCode == "" {
-		loginLog.FailLog(cr.Username, "", "未输入图片验证码")
+		loginLog.FailLog(cr.Username, "未输入图片验证码")
 		res.FailWithMsg("请输入图片验证码", c)
 		return
 	}
 
+	// 检查用户名与客户端IP是否因失败次数过多被锁定
+	if err := login_limit_service.Check(cr.Username, c.ClientIP()); err != nil {
+		loginLog.FailLog(cr.Username, "登录被锁定")
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+
 	// 验证图片验证码是否正确（第三个参数true表示验证后删除验证码）
 	if !captcha.CaptchaStore.Verify(cr.CaptchaID, cr.CaptchaCode, true) {
-		loginLog.FailLog(cr.Username, "", "图片验证码验证失败")
+		loginLog.FailLog(cr.Username, "图片验证码验证失败")
 		res.FailWithMsg("图片验证码验证失败", c)
 		return
 	}
@@ -52,26 +88,81 @@ func (UserApi) LoginView(c *gin.Context) {
 	err := global.DB.Take(&user, "username = ?", cr.Username).Error
 	if err != nil {
 		// 用户不存在或查询出错，返回错误提示
-		loginLog.FailLog(cr.Username, cr.Password, "用户名不存在")
+		loginLog.FailLog(cr.Username, "用户名不存在")
+		login_limit_service.Fail(cr.Username, c.ClientIP())
 		res.FailWithMsg("用户名或密码错误", c)
 		return
 	}
 
 	// 校验用户密码是否匹配
 	if !pwd.CompareHashAndPassword(user.Password, cr.Password) {
-		loginLog.FailLog(cr.Username, cr.Password, "密码错误")
+		loginLog.FailLog(cr.Username, "密码错误")
+		login_limit_service.Fail(cr.Username, c.ClientIP())
 		res.FailWithMsg("用户名或密码错误", c)
 		return
 	}
 
-	// 生成 JWT Token，包含用户ID和角色信息
-	token, err := jwts.GetToken(jwts.ClaimsUserInfo{
+	// 管理员重置密码后，签发强制修改密码凭证，修改密码后才继续登录
+	if user.MustChangePassword {
+		challenge, err := user_service.CreatePasswordChallenge(user.ID)
+		if err != nil {
+			logrus.Errorf("签发修改密码凭证失败 %s", err)
+			res.FailWithMsg("登录失败", c)
+			return
+		}
+		res.OkWithData(LoginResponse{
+			PasswordChangeRequired: true,
+			Challenge:              challenge,
+		}, c)
+		return
+	}
+
+	passwordVerified(c, user, loginLog)
+}
+
+// passwordVerified 密码校验通过后继续登录：需要两步验证时签发两步登录凭证，否则完成登录
+func passwordVerified(c *gin.Context, user models.UserModel, loginLog *log_service.LoginLogService) {
+	// 已启用两步验证，或必须启用两步验证但尚未绑定时，签发两步登录凭证，验证码校验通过后才完成登录
+	if user.TotpEnable || totp_service.RequireTotp(user) {
+		challenge, err := totp_service.CreateChallenge(user.ID)
+		if err != nil {
+			logrus.Errorf("签发两步登录凭证失败 %s", err)
+			res.FailWithMsg("登录失败", c)
+			return
+		}
+		res.OkWithData(LoginResponse{
+			TwoFactorRequired: user.TotpEnable,
+			EnrollRequired:    !user.TotpEnable,
+			Challenge:         challenge,
+		}, c)
+		return
+	}
+
+	loginSuccess(c, user, loginLog, nil)
+}
+
+// loginSuccess 完成登录：清除失败计数、创建会话并签发 token，recoveryCodes 为登录时绑定两步验证生成的恢复码
+func loginSuccess(c *gin.Context, user models.UserModel, loginLog *log_service.LoginLogService, recoveryCodes []string) {
+	// 登录成功，清除该用户名的失败计数
+	login_limit_service.Success(user.Username)
+
+	// 创建服务端会话，注销或被吊销后token立即失效
+	session, refreshToken, err := session_service.Create(c, user.ID)
+	if err != nil {
+		logrus.Errorf("创建会话失败 %s", err)
+		res.FailWithMsg("登录失败", c)
+		return
+	}
+
+	// 生成 access token，包含用户ID、角色信息及会话ID
+	token, claims, err := jwts.GetToken(jwts.ClaimsUserInfo{
 		UserID: user.ID,
-		Role:   user.Role,
-	})
+		RoleID: user.RoleID,
+	}, session.ID)
 	if err != nil {
 		// Token 生成失败记录日志并返回错误
 		logrus.Errorf("生成token失败 %s", err)
+		session_service.Revoke(user.ID, session.ID)
 		res.FailWithMsg("登录失败", c)
 		return
 	}
@@ -79,7 +170,9 @@ func (UserApi) LoginView(c *gin.Context) {
 	now := time.Now().Format(time.DateTime)
 	global.DB.Model(&user).Update("last_login_date", now) // 更新最后登录时间
 
-	// 登录成功，返回生成的 Token，并记录登录成功日志
-	loginLog.SuccessLog(user.ID, cr.Username)
-	res.OkWithData(token, c)
+	// 登录成功，返回 access token 与 refresh token，并记录登录成功日志
+	loginLog.SuccessLog(user.ID, user.Username)
+	data := newLoginResponse(token, claims, refreshToken, session)
+	data.RecoveryCodes = recoveryCodes
+	res.OkWithData(data, c)
 }
@@ -0,0 +1,148 @@
+package user_api
+
+// File: api/user_api/oidc_login.go
+// Description: 单点登录接口。浏览器访问登录接口跳转身份提供方，认证完成后回调接口关联或创建用户，
+// 再携带一次性票据跳转回前端，前端使用票据换取 token。单点登录用户的多因素认证由身份提供方负责
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/log_service"
+	"honey_server/internal/service/oidc_service"
+	"honey_server/internal/utils/res"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// OidcInfoResponse 单点登录配置
+type OidcInfoResponse struct {
+	Enable bool `json:"enable"` // 是否启用单点登录
+}
+
+// OidcCallbackRequest 身份提供方回调参数
+type OidcCallbackRequest struct {
+	Code             string `form:"code"`
+	State            string `form:"state"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
+
+// OidcTokenRequest 票据换取token请求参数
+type OidcTokenRequest struct {
+	Ticket string `json:"ticket" binding:"required" label:"登录票据"`
+}
+
+// OidcInfoView 查询是否启用单点登录，用于前端展示单点登录入口
+func (UserApi) OidcInfoView(c *gin.Context) {
+	res.OkWithData(OidcInfoResponse{Enable: oidc_service.Enable()}, c)
+}
+
+// setStateCookie 设置或清除保存 state 摘要的 Cookie，仅回调接口可读取
+// 身份提供方回调是跨站的顶层跳转，SameSite 需为 Lax 才会携带
+func setStateCookie(c *gin.Context, value string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidc_service.StateCookie, value, maxAge, "/honey_server/oidc/callback", "", c.Request.TLS != nil, true)
+}
+
+// OidcLoginView 跳转身份提供方登录，并将 state 绑定到当前浏览器
+func (UserApi) OidcLoginView(c *gin.Context) {
+	if !oidc_service.Enable() {
+		res.FailWithMsg("未启用单点登录", c)
+		return
+	}
+	authURL, state, err := oidc_service.AuthURL()
+	if err != nil {
+		logrus.Errorf("生成单点登录地址失败 %s", err)
+		res.FailWithMsg("单点登录暂不可用", c)
+		return
+	}
+	setStateCookie(c, oidc_service.StateHash(state), oidc_service.StateMaxAge)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// oidcRedirect 跳转回前端，未配置前端地址时直接返回结果
+func oidcRedirect(c *gin.Context, ticket string, msg string) {
+	frontendURL := global.Config.Oidc.FrontendURL
+	if frontendURL == "" {
+		if ticket == "" {
+			res.FailWithMsg(msg, c)
+			return
+		}
+		res.OkWithData(ticket, c)
+		return
+	}
+	query := url.Values{}
+	if ticket != "" {
+		query.Set("ticket", ticket)
+	} else {
+		query.Set("error", msg)
+	}
+	c.Redirect(http.StatusFound, frontendURL+"?"+query.Encode())
+}
+
+// OidcCallbackView 身份提供方回调，校验身份后签发一次性登录票据
+func (UserApi) OidcCallbackView(c *gin.Context) {
+	cr := middleware.GetBind[OidcCallbackRequest](c)
+	loginLog := log_service.NewLoginLog(c)
+
+	if !oidc_service.Enable() {
+		res.FailWithMsg("未启用单点登录", c)
+		return
+	}
+	// state 必须由当前浏览器发起，防止攻击者诱导用户以攻击者的身份登录
+	cookie, _ := c.Cookie(oidc_service.StateCookie)
+	setStateCookie(c, "", -1)
+	if cr.Error == "" && !oidc_service.CheckState(cr.State, cookie) {
+		logrus.Warnf("单点登录回调与发起登录的浏览器不一致")
+		oidcRedirect(c, "", "登录请求无效或已过期，请重新登录")
+		return
+	}
+	if cr.Error != "" {
+		logrus.Warnf("身份提供方返回错误 %s %s", cr.Error, cr.ErrorDescription)
+		oidcRedirect(c, "", "单点登录失败 "+cr.Error)
+		return
+	}
+
+	identity, err := oidc_service.Exchange(cr.State, cr.Code)
+	if err != nil {
+		logrus.Errorf("单点登录校验失败 %s", err)
+		oidcRedirect(c, "", "单点登录失败，请重新登录")
+		return
+	}
+	user, err := oidc_service.Provision(identity)
+	if err != nil {
+		loginLog.FailLog(identity.Username, "单点登录失败 "+err.Error())
+		oidcRedirect(c, "", err.Error())
+		return
+	}
+
+	ticket, err := oidc_service.CreateTicket(user.ID)
+	if err != nil {
+		logrus.Errorf("签发单点登录票据失败 %s", err)
+		oidcRedirect(c, "", "单点登录失败，请重新登录")
+		return
+	}
+	oidcRedirect(c, ticket, "")
+}
+
+// OidcTokenView 使用一次性登录票据换取token，完成单点登录
+func (UserApi) OidcTokenView(c *gin.Context) {
+	cr := middleware.GetBind[OidcTokenRequest](c)
+	loginLog := log_service.NewLoginLog(c)
+
+	userID, err := oidc_service.UseTicket(cr.Ticket)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	var user models.UserModel
+	if err = global.DB.Take(&user, userID).Error; err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	loginSuccess(c, user, loginLog, nil)
+}
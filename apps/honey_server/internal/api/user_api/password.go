@@ -0,0 +1,76 @@
+package user_api
+
+// File: api/user_api/password.go
+// Description: 密码修改接口：用户修改自己的密码，管理员重置其他用户的密码。
+// 修改成功后吊销用户的其他会话，重置密码后用户下次登录时必须修改密码
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/session_service"
+	"honey_server/internal/service/user_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordChangeRequest 修改密码请求参数
+type PasswordChangeRequest struct {
+	OldPassword string `json:"oldPassword" binding:"required" label:"原密码"`
+	Password    string `json:"password" binding:"required" label:"新密码"`
+}
+
+// PasswordResetRequest 重置密码请求参数
+type PasswordResetRequest struct {
+	UserID   uint   `json:"userID" binding:"required" label:"用户ID"`
+	Password string `json:"password" binding:"required" label:"新密码"` // 临时密码，用户下次登录时必须修改
+}
+
+// PasswordChangeView 修改自己的密码，保留当前会话，吊销其他会话
+func (UserApi) PasswordChangeView(c *gin.Context) {
+	cr := middleware.GetBind[PasswordChangeRequest](c)
+	log := middleware.GetLog(c)
+	auth := middleware.GetAuth(c)
+	user, ok := currentUser(c)
+	if !ok {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	us := user_service.NewUserService(log)
+	if err := us.ChangePassword(&user, cr.OldPassword, cr.Password); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if err := session_service.RevokeUser(user.ID, auth.SessionID); err != nil {
+		log.Errorf("吊销用户 %d 的会话失败 %s", user.ID, err)
+	}
+	res.OkWithMsg("修改密码成功", c)
+}
+
+// PasswordResetView 管理员重置用户密码，并吊销其全部会话；不能重置权限高于自己的用户
+func (UserApi) PasswordResetView(c *gin.Context) {
+	cr := middleware.GetBind[PasswordResetRequest](c)
+	log := middleware.GetLog(c)
+
+	var user models.UserModel
+	if err := global.DB.Take(&user, cr.UserID).Error; err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if !canManage(c, user.ID) {
+		res.FailWithMsg("不能重置权限高于自己的用户的密码", c)
+		return
+	}
+
+	us := user_service.NewUserService(log)
+	if err := us.ResetPassword(&user, cr.Password); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if err := session_service.RevokeUser(user.ID, ""); err != nil {
+		log.Errorf("吊销用户 %d 的会话失败 %s", user.ID, err)
+	}
+	res.OkWithMsg("重置密码成功，用户下次登录时需修改密码", c)
+}
@@ -0,0 +1,60 @@
+package user_api
+
+// File: api/user_api/password_login.go
+// Description: 登录时强制修改密码接口。管理员重置密码后，用户使用登录返回的凭证提交新密码，
+// 修改成功后继续登录流程（需要两步验证时返回两步登录凭证）
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/log_service"
+	"honey_server/internal/service/user_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordLoginRequest 登录时修改密码请求参数
+type PasswordLoginRequest struct {
+	Challenge string `json:"challenge" binding:"required" label:"登录凭证"`
+	Password  string `json:"password" binding:"required" label:"新密码"`
+}
+
+// PasswordLoginView 登录时修改密码
+func (UserApi) PasswordLoginView(c *gin.Context) {
+	cr := middleware.GetBind[PasswordLoginRequest](c)
+	log := middleware.GetLog(c)
+	loginLog := log_service.NewLoginLog(c)
+
+	userID, err := user_service.GetPasswordChallenge(cr.Challenge)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	var user models.UserModel
+	if err = global.DB.Take(&user, userID).Error; err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	us := user_service.NewUserService(log)
+	if err = us.ForceChangePassword(&user, cr.Password); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	user_service.RemovePasswordChallenge(cr.Challenge)
+
+	// 白名单接口不经过审计中间件，单独记录审计日志
+	log_service.SaveAudit(models.LogModel{
+		IP:          c.ClientIP(),
+		UserID:      user.ID,
+		Username:    user.Username,
+		Title:       "登录时修改密码",
+		Level:       1,
+		ServiceName: "honey_server",
+		Method:      c.Request.Method,
+		Path:        c.FullPath(),
+	})
+	passwordVerified(c, user, loginLog)
+}
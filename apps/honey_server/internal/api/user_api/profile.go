@@ -0,0 +1,41 @@
+package user_api
+
+// File: api/user_api/profile.go
+// Description: 个人资料修改接口
+
+import (
+	"honey_server/internal/middleware"
+	"honey_server/internal/service/user_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileUpdateRequest 修改个人资料请求参数
+type ProfileUpdateRequest struct {
+	Nickname string `json:"nickname" binding:"max=32" label:"昵称"`
+	Email    string `json:"email" binding:"omitempty,email,max=128" label:"邮箱"`
+}
+
+// ProfileUpdateView 修改自己的个人资料
+func (UserApi) ProfileUpdateView(c *gin.Context) {
+	cr := middleware.GetBind[ProfileUpdateRequest](c)
+	log := middleware.GetLog(c)
+	user, ok := currentUser(c)
+	if !ok {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	us := user_service.NewUserService(log)
+	err := us.UpdateProfile(&user, user_service.ProfileUpdateRequest{
+		Nickname: cr.Nickname,
+		Email:    cr.Email,
+	})
+	if err != nil {
+		log.Errorf("修改个人资料失败 %s", err)
+		res.FailWithMsg("修改个人资料失败", c)
+		return
+	}
+	res.OkWithMsg("修改个人资料成功", c)
+}
@@ -0,0 +1,53 @@
+package user_api
+
+// File: api/user_api/refresh.go
+// Description: 刷新token接口，使用 refresh token 换取新的 access token，同时轮换 refresh token
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/session_service"
+	"honey_server/internal/utils/jwts"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// RefreshRequest 刷新token请求参数
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required" label:"refresh token"`
+}
+
+// RefreshView 刷新token
+// 旧的 refresh token 立即失效；已失效的 refresh token 再次使用时吊销整个会话
+func (UserApi) RefreshView(c *gin.Context) {
+	cr := middleware.GetBind[RefreshRequest](c)
+
+	session, refreshToken, err := session_service.Refresh(cr.RefreshToken)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	// 重新查询用户，确认用户仍然存在
+	var user models.UserModel
+	if err = global.DB.Take(&user, session.UserID).Error; err != nil {
+		session_service.Revoke(session.UserID, session.ID)
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+
+	token, claims, err := jwts.GetToken(jwts.ClaimsUserInfo{
+		UserID: user.ID,
+		RoleID: user.RoleID,
+	}, session.ID)
+	if err != nil {
+		logrus.Errorf("生成token失败 %s", err)
+		res.FailWithMsg("刷新token失败", c)
+		return
+	}
+
+	res.OkWithData(newLoginResponse(token, claims, refreshToken, session), c)
+}
@@ -5,9 +5,11 @@ package user_api
 
 import (
 	"fmt"
+	"honey_server/internal/global"
 	"honey_server/internal/middleware"
 	"honey_server/internal/models"
 	"honey_server/internal/service/common_service"
+	"honey_server/internal/service/session_service"
 	"honey_server/internal/utils/res"
 
 	"github.com/gin-gonic/gin"
@@ -40,6 +42,16 @@ func (UserApi) UserRemoveView(c *gin.Context) {
 		return
 	}
 
+	// 吊销被删除用户的全部会话，使其token立即失效
+	for _, id := range cr.IDList {
+		if err = session_service.RevokeUser(id, ""); err != nil {
+			log.Errorf("吊销用户 %d 的会话失败 %s", id, err)
+		}
+	}
+
+	// 删除被删除用户的API密钥
+	global.DB.Where("user_id in ?", cr.IDList).Delete(&models.ApiKeyModel{})
+
 	// 删除成功
 	msg := fmt.Sprintf("删除成功 共%d个，成功%d个", len(cr.IDList), successCount)
 	res.OkWithMsg(msg, c)
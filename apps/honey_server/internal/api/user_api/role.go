@@ -0,0 +1,85 @@
+package user_api
+
+// File: api/user_api/role.go
+// Description: 用户角色分配接口
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/api_key_service"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserRoleRequest 用户角色分配请求参数
+type UserRoleRequest struct {
+	UserID uint `json:"userID" binding:"required" label:"用户ID"` // 用户ID
+	RoleID uint `json:"roleID" binding:"required" label:"角色ID"` // 角色ID
+}
+
+// UserRoleView 为用户分配角色，立即生效
+func (UserApi) UserRoleView(c *gin.Context) {
+	cr := middleware.GetBind[UserRoleRequest](c)
+	log := middleware.GetLog(c)
+
+	var user models.UserModel
+	if err := global.DB.Take(&user, cr.UserID).Error; err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	var role models.RoleModel
+	if err := global.DB.Take(&role, cr.RoleID).Error; err != nil {
+		res.FailWithMsg("角色不存在", c)
+		return
+	}
+	// 只能为权限不高于自己的用户分配不超出自己权限的角色
+	if !canManage(c, user.ID) || !canGrant(c, role.PermissionList) {
+		res.FailWithMsg("不能分配超出自己权限的角色", c)
+		return
+	}
+	// 避免管理员误操作导致系统中没有管理员
+	if user.RoleID == role_service.AdminRoleID && role.ID != role_service.AdminRoleID {
+		var count int64
+		global.DB.Model(&models.UserModel{}).Where("role_id = ?", role_service.AdminRoleID).Count(&count)
+		if count <= 1 {
+			res.FailWithMsg("不能修改最后一个管理员的角色", c)
+			return
+		}
+	}
+
+	if err := global.DB.Model(&user).Update("role_id", role.ID).Error; err != nil {
+		log.Errorf("分配角色失败 %s", err)
+		res.FailWithMsg("分配角色失败", c)
+		return
+	}
+	role_service.Invalidate()
+	log.Infof("用户 %s 的角色修改为 %s", user.Username, role.Title)
+
+	res.OkWithMsg("分配角色成功", c)
+}
+
+// canGrant 判断当前请求是否拥有权限列表中的全部权限（使用API密钥时还需在密钥的授权范围内），
+// 用于防止拥有用户管理权限的用户通过操作权限更高的用户提升自身权限
+func canGrant(c *gin.Context, permissionList []string) bool {
+	if apiKey := middleware.GetApiKey(c); apiKey != nil {
+		for _, code := range permissionList {
+			if !api_key_service.HasScope(apiKey, code) {
+				return false
+			}
+		}
+		return true
+	}
+	return role_service.Covers(middleware.GetAuth(c).UserID, permissionList)
+}
+
+// canManage 判断当前请求能否管理目标用户（重置密码、重置两步验证等），目标用户的权限不能超出操作者
+func canManage(c *gin.Context, userID uint) bool {
+	var permissionList []string
+	for code := range role_service.Permissions(userID) {
+		permissionList = append(permissionList, code)
+	}
+	return canGrant(c, permissionList)
+}
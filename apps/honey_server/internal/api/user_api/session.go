@@ -0,0 +1,104 @@
+package user_api
+
+// File: api/user_api/session.go
+// Description: 用户登录会话接口，查询用户的有效会话，吊销指定会话或全部会话。没有用户管理权限时只能管理自己的会话。
+
+import (
+	"fmt"
+	"honey_server/internal/middleware"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/service/session_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionListRequest 会话列表请求参数
+type SessionListRequest struct {
+	UserID uint `form:"userID"` // 用户ID，为空时查询当前用户，有用户管理权限时可查询其他用户
+}
+
+// SessionItem 会话列表项
+type SessionItem struct {
+	session_service.Session
+	Current bool `json:"current"` // 是否为当前请求所用的会话
+}
+
+// SessionRevokeRequest 会话吊销请求参数
+type SessionRevokeRequest struct {
+	UserID    uint   `json:"userID"`    // 用户ID，为空时为当前用户，有用户管理权限时可吊销其他用户的会话
+	SessionID string `json:"sessionID"` // 吊销的会话ID
+	All       bool   `json:"all"`       // 吊销全部会话（吊销当前用户的全部会话时保留当前会话）
+}
+
+// sessionUserID 确定操作的用户，拥有用户管理权限才能操作其他用户的会话
+func sessionUserID(c *gin.Context, userID uint) (uint, bool) {
+	auth := middleware.GetAuth(c)
+	if userID == 0 || userID == auth.UserID {
+		return auth.UserID, true
+	}
+	return userID, role_service.HasPermission(auth.UserID, "user:write")
+}
+
+// SessionListView 查询用户的有效会话
+func (UserApi) SessionListView(c *gin.Context) {
+	cr := middleware.GetBind[SessionListRequest](c)
+	userID, ok := sessionUserID(c, cr.UserID)
+	if !ok {
+		res.FailWithMsg("无权限访问", c)
+		return
+	}
+
+	sessionList, err := session_service.List(userID)
+	if err != nil {
+		res.FailWithMsg("查询会话失败", c)
+		return
+	}
+	currentID := middleware.GetAuth(c).SessionID
+	list := make([]SessionItem, 0, len(sessionList))
+	for _, session := range sessionList {
+		list = append(list, SessionItem{
+			Session: session,
+			Current: session.ID == currentID,
+		})
+	}
+
+	res.OkWithList(list, int64(len(list)), c)
+}
+
+// SessionRevokeView 吊销用户的指定会话或全部会话
+func (UserApi) SessionRevokeView(c *gin.Context) {
+	cr := middleware.GetBind[SessionRevokeRequest](c)
+	log := middleware.GetLog(c)
+	userID, ok := sessionUserID(c, cr.UserID)
+	if !ok {
+		res.FailWithMsg("无权限访问", c)
+		return
+	}
+
+	if cr.All {
+		// 吊销自己的全部会话时保留当前会话，避免操作后立即被登出
+		var except string
+		if userID == middleware.GetAuth(c).UserID {
+			except = middleware.GetAuth(c).SessionID
+		}
+		if err := session_service.RevokeUser(userID, except); err != nil {
+			res.FailWithMsg(fmt.Sprintf("吊销会话失败 %s", err), c)
+			return
+		}
+		log.Infof("吊销用户 %d 的全部会话", userID)
+		res.OkWithMsg("吊销成功", c)
+		return
+	}
+
+	if cr.SessionID == "" {
+		res.FailWithMsg("请指定要吊销的会话", c)
+		return
+	}
+	if err := session_service.Revoke(userID, cr.SessionID); err != nil {
+		res.FailWithMsg(fmt.Sprintf("吊销会话失败 %s", err), c)
+		return
+	}
+	log.Infof("吊销用户 %d 的会话 %s", userID, cr.SessionID)
+	res.OkWithMsg("吊销成功", c)
+}
@@ -0,0 +1,177 @@
+package user_api
+
+// File: api/user_api/totp.go
+// Description: 两步验证管理接口：获取密钥、绑定启用、停用、重新生成恢复码，以及管理员重置其他用户的两步验证
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/session_service"
+	"honey_server/internal/service/totp_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TotpCodeRequest 两步验证码请求参数
+type TotpCodeRequest struct {
+	Code string `json:"code" binding:"required" label:"验证码"` // 验证码，停用和重新生成恢复码时也可使用恢复码
+}
+
+// TotpResetRequest 重置两步验证请求参数
+type TotpResetRequest struct {
+	UserID uint `json:"userID" binding:"required" label:"用户ID"`
+}
+
+// totpEnroll 生成两步验证密钥并临时保存，验证码校验通过后才生效
+func totpEnroll(c *gin.Context, user models.UserModel) {
+	key, err := totp_service.Generate(user.Username)
+	if err != nil {
+		res.FailWithMsg(fmt.Sprintf("生成密钥失败 %s", err), c)
+		return
+	}
+	if err = totp_service.SetPendingSecret(user.ID, key.Secret); err != nil {
+		res.FailWithMsg(fmt.Sprintf("保存密钥失败 %s", err), c)
+		return
+	}
+	res.OkWithData(key, c)
+}
+
+// currentUser 查询当前登录的用户
+func currentUser(c *gin.Context) (user models.UserModel, ok bool) {
+	err := global.DB.Take(&user, middleware.GetAuth(c).UserID).Error
+	return user, err == nil
+}
+
+// TotpEnrollView 获取两步验证密钥
+func (UserApi) TotpEnrollView(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if user.TotpEnable {
+		res.FailWithMsg("已启用两步验证，请先停用", c)
+		return
+	}
+	totpEnroll(c, user)
+}
+
+// TotpEnableView 提交验证码，绑定并启用两步验证，返回恢复码
+func (UserApi) TotpEnableView(c *gin.Context) {
+	cr := middleware.GetBind[TotpCodeRequest](c)
+	log := middleware.GetLog(c)
+	user, ok := currentUser(c)
+	if !ok {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if user.TotpEnable {
+		res.FailWithMsg("已启用两步验证", c)
+		return
+	}
+	secret, err := totp_service.GetPendingSecret(user.ID)
+	if err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+	if !totp_service.Validate(user.ID, secret, cr.Code) {
+		res.FailWithMsg("验证码错误", c)
+		return
+	}
+
+	codes, err := totp_service.Enable(&user, secret)
+	if err != nil {
+		log.Errorf("启用两步验证失败 %s", err)
+		res.FailWithMsg("启用两步验证失败", c)
+		return
+	}
+	log.Infof("用户 %s 启用两步验证", user.Username)
+	res.Ok(codes, "启用两步验证成功，请妥善保存恢复码", c)
+}
+
+// TotpDisableView 停用两步验证
+func (UserApi) TotpDisableView(c *gin.Context) {
+	cr := middleware.GetBind[TotpCodeRequest](c)
+	log := middleware.GetLog(c)
+	user, ok := currentUser(c)
+	if !ok {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if !user.TotpEnable {
+		res.FailWithMsg("未启用两步验证", c)
+		return
+	}
+	if totp_service.RequireTotp(user) {
+		res.FailWithMsg("管理员必须启用两步验证", c)
+		return
+	}
+	if !totp_service.Verify(&user, cr.Code) {
+		res.FailWithMsg("验证码错误", c)
+		return
+	}
+
+	if err := totp_service.Disable(user.ID); err != nil {
+		log.Errorf("停用两步验证失败 %s", err)
+		res.FailWithMsg("停用两步验证失败", c)
+		return
+	}
+	log.Infof("用户 %s 停用两步验证", user.Username)
+	res.OkWithMsg("停用两步验证成功", c)
+}
+
+// TotpRecoveryCodesView 重新生成恢复码，原有恢复码全部作废
+func (UserApi) TotpRecoveryCodesView(c *gin.Context) {
+	cr := middleware.GetBind[TotpCodeRequest](c)
+	log := middleware.GetLog(c)
+	user, ok := currentUser(c)
+	if !ok {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if !user.TotpEnable {
+		res.FailWithMsg("未启用两步验证", c)
+		return
+	}
+	if !totp_service.Verify(&user, cr.Code) {
+		res.FailWithMsg("验证码错误", c)
+		return
+	}
+
+	codes, err := totp_service.Enable(&user, user.TotpSecret)
+	if err != nil {
+		log.Errorf("生成恢复码失败 %s", err)
+		res.FailWithMsg("生成恢复码失败", c)
+		return
+	}
+	res.Ok(codes, "生成恢复码成功，请妥善保存", c)
+}
+
+// TotpResetView 管理员重置其他用户的两步验证，并吊销其全部会话；必须启用两步验证的用户下次登录时重新绑定，不能重置权限高于自己的用户
+func (UserApi) TotpResetView(c *gin.Context) {
+	cr := middleware.GetBind[TotpResetRequest](c)
+	log := middleware.GetLog(c)
+
+	var user models.UserModel
+	if err := global.DB.Take(&user, cr.UserID).Error; err != nil {
+		res.FailWithMsg("用户不存在", c)
+		return
+	}
+	if !canManage(c, user.ID) {
+		res.FailWithMsg("不能重置权限高于自己的用户的两步验证", c)
+		return
+	}
+	if err := totp_service.Disable(user.ID); err != nil {
+		log.Errorf("重置两步验证失败 %s", err)
+		res.FailWithMsg("重置两步验证失败", c)
+		return
+	}
+	if err := session_service.RevokeUser(user.ID, ""); err != nil {
+		log.Errorf("吊销用户 %d 的会话失败 %s", user.ID, err)
+	}
+	log.Infof("重置用户 %s 的两步验证", user.Username)
+	res.OkWithMsg("重置两步验证成功", c)
+}
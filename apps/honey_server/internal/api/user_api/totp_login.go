@@ -0,0 +1,111 @@
+package user_api
+
+// File: api/user_api/totp_login.go
+// Description: 两步验证登录接口。密码校验通过后使用两步登录凭证提交验证码或恢复码完成登录；
+// 必须启用两步验证但尚未绑定的用户，先使用凭证获取密钥，再提交验证码完成绑定与登录
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+	"honey_server/internal/service/log_service"
+	"honey_server/internal/service/login_limit_service"
+	"honey_server/internal/service/totp_service"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// TotpLoginRequest 两步验证登录请求参数
+type TotpLoginRequest struct {
+	Challenge string `json:"challenge" binding:"required" label:"登录凭证"`
+	Code      string `json:"code" binding:"required" label:"验证码"` // 验证码或恢复码
+}
+
+// TotpEnrollLoginRequest 登录时绑定两步验证请求参数
+type TotpEnrollLoginRequest struct {
+	Challenge string `json:"challenge" binding:"required" label:"登录凭证"`
+}
+
+// challengeUser 查询登录凭证对应的用户
+func challengeUser(challenge string) (user models.UserModel, err error) {
+	userID, err := totp_service.GetChallenge(challenge)
+	if err != nil {
+		return
+	}
+	err = global.DB.Take(&user, userID).Error
+	return
+}
+
+// TotpLoginView 两步验证登录
+func (UserApi) TotpLoginView(c *gin.Context) {
+	cr := middleware.GetBind[TotpLoginRequest](c)
+	loginLog := log_service.NewLoginLog(c)
+
+	user, err := challengeUser(cr.Challenge)
+	if err != nil {
+		res.FailWithMsg("登录凭证无效或已过期，请重新登录", c)
+		return
+	}
+	if err = login_limit_service.Check(user.Username, c.ClientIP()); err != nil {
+		loginLog.FailLog(user.Username, "登录被锁定")
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	var recoveryCodes []string
+	switch {
+	case user.TotpEnable:
+		if !totp_service.Verify(&user, cr.Code) {
+			totpLoginFail(c, cr.Challenge, user, loginLog)
+			return
+		}
+	case totp_service.RequireTotp(user):
+		// 登录时绑定两步验证
+		secret, err := totp_service.GetPendingSecret(user.ID)
+		if err != nil {
+			res.FailWithMsg(err.Error(), c)
+			return
+		}
+		if !totp_service.Validate(user.ID, secret, cr.Code) {
+			totpLoginFail(c, cr.Challenge, user, loginLog)
+			return
+		}
+		if recoveryCodes, err = totp_service.Enable(&user, secret); err != nil {
+			logrus.Errorf("启用两步验证失败 %s", err)
+			res.FailWithMsg("启用两步验证失败", c)
+			return
+		}
+	default:
+		res.FailWithMsg("未启用两步验证", c)
+		return
+	}
+
+	totp_service.RemoveChallenge(cr.Challenge)
+	loginSuccess(c, user, loginLog, recoveryCodes)
+}
+
+// totpLoginFail 两步验证码错误，计入登录失败次数
+func totpLoginFail(c *gin.Context, challenge string, user models.UserModel, loginLog *log_service.LoginLogService) {
+	totp_service.FailChallenge(challenge)
+	login_limit_service.Fail(user.Username, c.ClientIP())
+	loginLog.FailLog(user.Username, "两步验证码错误")
+	res.FailWithMsg("验证码错误", c)
+}
+
+// TotpEnrollLoginView 登录时获取两步验证密钥，用于必须启用两步验证但尚未绑定的用户
+func (UserApi) TotpEnrollLoginView(c *gin.Context) {
+	cr := middleware.GetBind[TotpEnrollLoginRequest](c)
+
+	user, err := challengeUser(cr.Challenge)
+	if err != nil {
+		res.FailWithMsg("登录凭证无效或已过期，请重新登录", c)
+		return
+	}
+	if user.TotpEnable {
+		res.FailWithMsg("已启用两步验证", c)
+		return
+	}
+	totpEnroll(c, user)
+}
@@ -14,10 +14,15 @@ import (
 
 // UserInfoResponse 用户信息返回结构体
 type UserInfoResponse struct {
-	UserID        uint   `json:"userID"`        // 用户ID
-	Username      string `json:"username"`      // 用户名
-	Role          int8   `json:"role"`          // 用户角色 1管理员 2普通用户
-	LastLoginDate string `json:"lastLoginDate"` // 最近登录时间
+	UserID         uint     `json:"userID"`         // 用户ID
+	Username       string   `json:"username"`       // 用户名
+	RoleID         uint     `json:"roleID"`         // 角色ID
+	RoleTitle      string   `json:"roleTitle"`      // 角色名称
+	PermissionList []string `json:"permissionList"` // 权限列表，* 表示全部权限
+	Nickname       string   `json:"nickname"`       // 昵称
+	Email          string   `json:"email"`          // 邮箱
+	LastLoginDate  string   `json:"lastLoginDate"`  // 最近登录时间
+	TotpEnable     bool     `json:"totpEnable"`     // 是否启用两步验证
 }
 
 // UserInfoView 获取当前登录用户信息
@@ -31,12 +36,20 @@ func (UserApi) UserInfoView(c *gin.Context) {
 		return
 	}
 
+	var role models.RoleModel
+	global.DB.Take(&role, user.RoleID)
+
 	// 封装返回数据
 	data := UserInfoResponse{
-		UserID:        user.ID,
-		Username:      user.Username,
-		Role:          user.Role,
-		LastLoginDate: user.LastLoginDate,
+		UserID:         user.ID,
+		Username:       user.Username,
+		RoleID:         user.RoleID,
+		RoleTitle:      role.Title,
+		PermissionList: role.PermissionList,
+		Nickname:       user.Nickname,
+		Email:          user.Email,
+		LastLoginDate:  user.LastLoginDate,
+		TotpEnable:     user.TotpEnable,
 	}
 
 	res.OkWithData(data, c)
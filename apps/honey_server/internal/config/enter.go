@@ -7,13 +7,18 @@ import "fmt"
 
 // 统一管理配置结构体
 type Config struct {
-	DB        DB       `yaml:"db"`
-	Logger    Logger   `yaml:"logger"`
-	Redis     Redis    `yaml:"redis"`
-	System    System   `yaml:"system"`
-	Jwt       Jwt      `yaml:"jwt"`
-	WhiteList []string `yaml:"whiteList"`
-	MQ        MQ       `yaml:"mq"`
+	DB         DB         `yaml:"db"`
+	Logger     Logger     `yaml:"logger"`
+	Redis      Redis      `yaml:"redis"`
+	System     System     `yaml:"system"`
+	Jwt        Jwt        `yaml:"jwt"`
+	WhiteList  []string   `yaml:"whiteList"`
+	MQ         MQ         `yaml:"mq"`
+	Transcript Transcript `yaml:"transcript"`
+	Login      Login      `yaml:"login"`
+	Totp       Totp       `yaml:"totp"`
+	Oidc       Oidc       `yaml:"oidc"`
+	Password   Password   `yaml:"password"`
 }
 
 // 数据库配置
@@ -55,16 +60,81 @@ type Redis struct {
 
 // 系统配置
 type System struct {
-	WebAddr  string `yaml:"webAddr"`
-	GrpcAddr string `yaml:"grpcAddr"`
-	Mode     string `yaml:"mode"`
+	WebAddr        string   `yaml:"webAddr"`
+	GrpcAddr       string   `yaml:"grpcAddr"`
+	Mode           string   `yaml:"mode"`
+	TrustedProxies []string `yaml:"trustedProxies"` // 受信任的反向代理地址，仅信任来自这些地址的 X-Forwarded-For，为空时客户端IP取连接地址
+	SiteOrigins    []string `yaml:"siteOrigins"`    // 前端站点的源（如 https://honey.example.com），允许这些源建立 WebSocket 连接，同源请求始终允许
 }
 
 // Jwt 配置
 type Jwt struct {
-	Expires int    `yaml:"expires"` // 过期时间，单位: 秒
-	Issuer  string `yaml:"issuer"`  // 签发者
-	Secret  string `yaml:"secret"`  // 密钥
+	Expires        int      `yaml:"expires"`        // access token 过期时间，单位: 秒
+	RefreshExpires int      `yaml:"refreshExpires"` // refresh token 过期时间，单位: 秒，每次刷新后重新计算
+	Issuer         string   `yaml:"issuer"`         // 签发者
+	Secret         string   `yaml:"secret"`         // 密钥，未配置 keys 时使用
+	KeyID          string   `yaml:"keyID"`          // 当前用于签发的密钥ID
+	Keys           []JwtKey `yaml:"keys"`           // 签名密钥列表，列表中的密钥均可用于校验，轮换时先加入新密钥再切换 keyID
+}
+
+// JwtKey 签名密钥
+type JwtKey struct {
+	ID     string `yaml:"id"`     // 密钥ID，签发时写入 token 头的 kid
+	Secret string `yaml:"secret"` // 密钥
+}
+
+// 会话录制配置
+type Transcript struct {
+	Dir     string `yaml:"dir"`     // 录制文件保存目录，默认 transcript
+	MaxSize int    `yaml:"maxSize"` // 单个会话最大录制字节数，单位: KB，默认 1024，超出部分不再录制
+}
+
+// 登录防暴力破解配置
+type Login struct {
+	MaxFail     int `yaml:"maxFail"`     // 同一用户名在统计窗口内允许的失败次数，达到后锁定，默认 5
+	IPMaxFail   int `yaml:"ipMaxFail"`   // 同一IP在统计窗口内允许的失败次数，达到后锁定，默认 20
+	Window      int `yaml:"window"`      // 失败次数统计窗口，单位: 秒，默认 900
+	LockTime    int `yaml:"lockTime"`    // 首次锁定时长，单位: 秒，默认 900，再次锁定时加倍
+	MaxLockTime int `yaml:"maxLockTime"` // 最长锁定时长，单位: 秒，默认 86400
+	Delay       int `yaml:"delay"`       // 每次失败后递增的等待时长，单位: 秒，默认 1，最长等待 30 秒
+}
+
+// 两步验证配置
+type Totp struct {
+	Issuer       string `yaml:"issuer"`       // 身份验证器中显示的签发者名称，默认 honey_server
+	RequireAdmin bool   `yaml:"requireAdmin"` // 管理员是否必须启用两步验证，未启用的管理员登录时需先绑定
+}
+
+// 密码策略配置
+type Password struct {
+	MinLength     int  `yaml:"minLength"`     // 最小长度，默认 8
+	RequireUpper  bool `yaml:"requireUpper"`  // 必须包含大写字母
+	RequireLower  bool `yaml:"requireLower"`  // 必须包含小写字母
+	RequireDigit  bool `yaml:"requireDigit"`  // 必须包含数字
+	RequireSymbol bool `yaml:"requireSymbol"` // 必须包含特殊字符
+	History       int  `yaml:"history"`       // 新密码不能与最近几次使用过的密码相同，0 表示不限制
+}
+
+// 单点登录配置，使用 OpenID Connect 授权码模式（PKCE）对接企业身份提供方
+type Oidc struct {
+	Enable        bool              `yaml:"enable"`        // 是否启用单点登录
+	Issuer        string            `yaml:"issuer"`        // 身份提供方地址，通过 /.well-known/openid-configuration 发现端点
+	ClientID      string            `yaml:"clientID"`      // 客户端ID
+	ClientSecret  string            `yaml:"clientSecret"`  // 客户端密钥，公共客户端可为空
+	RedirectURL   string            `yaml:"redirectURL"`   // 回调地址，指向 /honey_server/oidc/callback
+	FrontendURL   string            `yaml:"frontendURL"`   // 登录完成后跳转的前端地址，附带一次性的 ticket 参数
+	Scopes        []string          `yaml:"scopes"`        // 申请的 scope，默认 openid profile email
+	UsernameClaim string            `yaml:"usernameClaim"` // 作为用户名的声明，默认 preferred_username
+	RoleClaim     string            `yaml:"roleClaim"`     // 用于映射角色的声明，值为字符串或字符串数组，默认 groups
+	RoleMapping   []OidcRoleMapping `yaml:"roleMapping"`   // 声明值到角色的映射，按顺序匹配第一个
+	DefaultRoleID uint              `yaml:"defaultRoleID"` // 没有匹配的映射时分配的角色ID，为0时拒绝登录
+	AutoCreate    bool              `yaml:"autoCreate"`    // 首次登录时是否自动创建用户
+}
+
+// OidcRoleMapping 声明值到角色的映射
+type OidcRoleMapping struct {
+	Value  string `yaml:"value"`  // 声明值，如身份提供方中的组名
+	RoleID uint   `yaml:"roleID"` // 角色ID
 }
 
 // rabbitMQ 配置
@@ -80,6 +150,16 @@ type MQ struct {
 	ClientCertificate    string `yaml:"clientCertificate"`    // 客户端证书路径
 	ClientKey            string `yaml:"clientKey"`            // 客户端密钥路径
 	CaCertificate        string `yaml:"caCertificate"`        // CA证书路径
+	OutboxMaxRetry       int    `yaml:"outboxMaxRetry"`       // 发件箱消息最大重试次数，超过后标记为发送失败
+	Bus                  string `yaml:"bus"`                  // 消息总线类型 rabbitmq（默认）、grpc、memory
+}
+
+// BusType 返回消息总线类型，未配置时默认使用RabbitMQ
+func (m MQ) BusType() string {
+	if m.Bus == "" {
+		return "rabbitmq"
+	}
+	return m.Bus
 }
 
 // 构造并返回RabbitMQ服务器的连接地址
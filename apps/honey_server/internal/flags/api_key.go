@@ -0,0 +1,95 @@
+package flags
+
+// File: flags/api_key.go
+// Description: 提供通过命令行创建、列出和吊销API密钥的功能，用于初始化自动化系统的接入凭据
+
+import (
+	"encoding/json"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/api_key_service"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ApiKey struct {
+}
+
+// ApiKeyCreateRequest 命令行创建API密钥的参数
+type ApiKeyCreateRequest struct {
+	Username  string   `json:"username"`  // 所属用户名
+	Title     string   `json:"title"`     // 名称
+	ScopeList []string `json:"scopeList"` // 授权范围
+	Expires   int      `json:"expires"`   // 有效天数，0 表示永不过期
+}
+
+// Create 为指定用户创建API密钥并输出完整密钥
+func (ApiKey) Create(value string) {
+	var cr ApiKeyCreateRequest
+	if err := json.Unmarshal([]byte(value), &cr); err != nil {
+		logrus.Fatalf("API密钥信息错误 %s", err)
+	}
+	if cr.Title == "" {
+		logrus.Fatalf("API密钥名称不能为空")
+	}
+	var user models.UserModel
+	if err := global.DB.Take(&user, "username = ?", cr.Username).Error; err != nil {
+		logrus.Fatalf("用户 %s 不存在", cr.Username)
+	}
+
+	model, key, err := api_key_service.Create(api_key_service.CreateRequest{
+		UserID:    user.ID,
+		Title:     cr.Title,
+		ScopeList: cr.ScopeList,
+		Expires:   cr.Expires,
+	})
+	if err != nil {
+		logrus.Fatalf("创建API密钥失败 %s", err)
+	}
+	logrus.Infof("创建API密钥成功 id：%d 前缀：%s", model.ID, model.Prefix)
+	fmt.Printf("密钥（只显示一次，请妥善保存）：%s\n", key)
+}
+
+// List 列出全部API密钥
+func (ApiKey) List() {
+	var list []models.ApiKeyModel
+	global.DB.Order("created_at desc").Find(&list)
+
+	for _, model := range list {
+		expiresAt, lastUsedAt := "永不过期", "未使用"
+		if model.ExpiresAt != nil {
+			expiresAt = model.ExpiresAt.Format("2006-01-02 15:04:05")
+		}
+		if model.LastUsedAt != nil {
+			lastUsedAt = model.LastUsedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("id：%d  名称：%s 前缀：%s 用户ID：%d 授权范围：%v 过期时间：%s 最近使用：%s %s\n",
+			model.ID,
+			model.Title,
+			model.Prefix,
+			model.UserID,
+			model.ScopeList,
+			expiresAt,
+			lastUsedAt,
+			model.LastUsedIP,
+		)
+	}
+}
+
+// Revoke 吊销指定ID的API密钥
+func (ApiKey) Revoke(value string) {
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		logrus.Fatalf("API密钥ID错误 %s", value)
+	}
+	result := global.DB.Unscoped().Delete(&models.ApiKeyModel{}, id)
+	if result.Error != nil {
+		logrus.Fatalf("吊销API密钥失败 %s", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		logrus.Fatalf("API密钥 %d 不存在", id)
+	}
+	logrus.Infof("已吊销API密钥 %d", id)
+}
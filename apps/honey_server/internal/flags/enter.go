@@ -38,7 +38,7 @@ func init() {
 	flag.BoolVar(&Options.Version, "vv", false, "打印当前版本")
 	flag.BoolVar(&Options.Help, "h", false, "帮助信息")
 	flag.BoolVar(&Options.DB, "db", false, "迁移表结构")
-	flag.StringVar(&Options.Menu, "m", "", "菜单 user")
+	flag.StringVar(&Options.Menu, "m", "", "菜单 user api_key")
 	flag.StringVar(&Options.Type, "t", "", "类型 create list")
 	flag.StringVar(&Options.Value, "v", "", "值")
 	flag.Parse()
@@ -51,11 +51,22 @@ func init() {
 // 后续若有新的菜单或子命令，只需在这里添加 registerCommand 调用即可。
 func RegisterCommand() {
 	var user User
+	var apiKey ApiKey
 
 	registerCommand("user", "create", "创建用户 -v 传入json数据", func() {
 		user.Create(Options.Value)
 	})
 	registerCommand("user", "list", "用户列表", user.List)
+	registerCommand("user", "totp_reset", "重置用户的两步验证 -v 传入用户名", func() {
+		user.TotpReset(Options.Value)
+	})
+	registerCommand("api_key", "create", "创建API密钥 -v 传入json数据", func() {
+		apiKey.Create(Options.Value)
+	})
+	registerCommand("api_key", "list", "API密钥列表", apiKey.List)
+	registerCommand("api_key", "revoke", "吊销API密钥 -v 传入API密钥ID", func() {
+		apiKey.Revoke(Options.Value)
+	})
 }
 
 // runBaseCommand 执行基础命令，其优先级最高。
@@ -131,6 +142,7 @@ func runCommand() {
 // ./main -m user -t list
 // ./main -m user -t create
 // ./main -m user -t create -v '{"username":"admin","password":"admin"}'
+// ./main -m api_key -t create -v '{"username":"admin","title":"soar","scopeList":["alert:read"],"expires":90}'
 
 // Command 命令结构体。
 // 一个完整命令由：菜单、子命令、帮助信息与执行函数组成。
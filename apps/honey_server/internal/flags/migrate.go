@@ -6,6 +6,7 @@ package flags
 import (
 	"honey_server/internal/global"
 	"honey_server/internal/models"
+	"honey_server/internal/service/role_service"
 
 	"github.com/sirupsen/logrus"
 )
@@ -18,21 +19,40 @@ func Migrate() {
 	}
 
 	err := global.DB.AutoMigrate(
-		&models.HoneyIpModel{},        // 诱捕IP
-		&models.HoneyPortModel{},      // 诱捕端口
-		&models.HostModel{},           // 存活主机
-		&models.HostTemplateModel{},   // 主机模板
-		&models.ImageModel{},          // 镜像
-		&models.LogModel{},            // 日志
-		&models.MatrixTemplateModel{}, // 矩阵模板
-		&models.NetModel{},            // 网络
-		&models.NodeModel{},           // 节点
-		&models.NodeNetworkModel{},    // 节点网络
-		&models.ServiceModel{},        // 服务
-		&models.UserModel{},           // 用户
+		&models.AlertChannelModel{},      // 告警通知渠道
+		&models.AlertModel{},             // 告警记录
+		&models.AlertRuleModel{},         // 告警规则
+		&models.ApiKeyModel{},            // API密钥
+		&models.CredentialAttemptModel{}, // 凭据尝试
+		&models.HoneyIpModel{},           // 诱捕IP
+		&models.HoneyPortModel{},         // 诱捕端口
+		&models.HostModel{},              // 存活主机
+		&models.HostTemplateModel{},      // 主机模板
+		&models.ImageModel{},             // 镜像
+		&models.InteractionModel{},       // 模拟器交互会话
+		&models.LogModel{},               // 日志
+		&models.MatrixTemplateModel{},    // 矩阵模板
+		&models.MqDeadLetterModel{},      // 死信消息
+		&models.MqOutboxModel{},          // 消息发件箱
+		&models.NetModel{},               // 网络
+		&models.NodeModel{},              // 节点
+		&models.NodeNetworkModel{},       // 节点网络
+		&models.RoleModel{},              // 角色
+		&models.ServiceModel{},           // 服务
+		&models.TranscriptModel{},        // 会话录制
+		&models.UserModel{},              // 用户
 	)
 	if err != nil {
 		logrus.Fatalf("表结构迁移失败 %s", err)
 	}
+	// 旧版本的登录日志记录了尝试的明文密码，删除该字段
+	if global.DB.Migrator().HasColumn(&models.LogModel{}, "pwd") {
+		if err = global.DB.Migrator().DropColumn(&models.LogModel{}, "pwd"); err != nil {
+			logrus.Fatalf("删除登录日志密码字段失败 %s", err)
+		}
+	}
+	if err = role_service.InitBuiltin(); err != nil {
+		logrus.Fatalf("内置角色初始化失败 %s", err)
+	}
 	logrus.Infof("表结构迁移成功")
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"honey_server/internal/global"
 	"honey_server/internal/models"
+	"honey_server/internal/service/totp_service"
 	"honey_server/internal/service/user_service"
 	"os"
 
@@ -31,19 +32,18 @@ func (User) Create(value string) {
 		}
 	} else {
 		// 否则，使用命令行交互方式创建用户
-		fmt.Println("请选择角色： 1 管理员 2 普通用户")
-		_, err := fmt.Scanln(&userInfo.Role)
+		var roleList []models.RoleModel
+		global.DB.Order("id asc").Find(&roleList)
+		fmt.Println("请选择角色：")
+		for _, role := range roleList {
+			fmt.Printf("%d %s\n", role.ID, role.Title)
+		}
+		_, err := fmt.Scanln(&userInfo.RoleID)
 		if err != nil {
 			fmt.Println("输入错误", err)
 			return
 		}
 
-		// 校验角色输入是否正确
-		if !(userInfo.Role == 1 || userInfo.Role == 2) {
-			fmt.Println("用户角色输入错误", err)
-			return
-		}
-
 		// 输入用户名
 		fmt.Println("请输入用户名")
 		fmt.Scanln(&userInfo.Username)
@@ -90,11 +90,23 @@ func (User) List() {
 
 	// 输出用户信息
 	for _, model := range userList {
-		fmt.Printf("用户id：%d  用户名：%s 用户角色：%d 创建时间：%s\n",
+		fmt.Printf("用户id：%d  用户名：%s 用户角色ID：%d 创建时间：%s\n",
 			model.ID,
 			model.Username,
-			model.Role,
+			model.RoleID,
 			model.CreatedAt.Format("2006-01-02 15:04:05"),
 		)
 	}
 }
+
+// TotpReset 重置用户的两步验证，用于管理员丢失身份验证器且恢复码用尽时恢复登录
+func (User) TotpReset(username string) {
+	var user models.UserModel
+	if err := global.DB.Take(&user, "username = ?", username).Error; err != nil {
+		logrus.Fatalf("用户 %s 不存在", username)
+	}
+	if err := totp_service.Disable(user.ID); err != nil {
+		logrus.Fatalf("重置两步验证失败 %s", err)
+	}
+	logrus.Infof("已重置用户 %s 的两步验证", username)
+}
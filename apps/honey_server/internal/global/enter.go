@@ -23,5 +23,5 @@ var (
 	Config *config.Config // 配置实例
 	Log    *logrus.Entry  // 日志实例
 	Redis  *redis.Client  // Redis 客户端实例
-	Queue  *amqp.Channel  // rabbitMQ 实例
+	Queue  *amqp.Channel  // rabbitMQ 实例（启动时建立，之后不再替换）
 )
@@ -0,0 +1,191 @@
+package middleware
+
+// File: middleware/audit_middleware.go
+// Description: 操作审计中间件，记录 POST/PUT/DELETE 请求的操作人、路由、请求体（敏感字段脱敏）、
+// 响应状态码、耗时、影响的记录ID，登记了审计模型的路由还会记录变更前后的记录快照
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/log_service"
+	"honey_server/internal/utils"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const auditBodyLimit = 64 << 10 // 记录的请求体与响应体的最大长度
+
+// auditIDKeyList 从请求体中提取影响的记录ID的字段
+var auditIDKeyList = []string{"id", "idList", "userID"}
+
+// auditModelMap 审计模型表，键为 "方法 路由"，值为需要记录快照的模型
+var auditModelMap = map[string]any{}
+
+// RegisterAuditModel 登记需要记录变更前后快照的路由及对应的模型
+func RegisterAuditModel(modelMap map[string]any) {
+	for route, model := range modelMap {
+		auditModelMap[route] = model
+	}
+}
+
+// auditWriter 记录响应体的 ResponseWriter
+type auditWriter struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *auditWriter) Write(b []byte) (int, error) {
+	if w.buf.Len() < auditBodyLimit {
+		w.buf.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *auditWriter) WriteString(s string) (int, error) {
+	if w.buf.Len() < auditBodyLimit {
+		w.buf.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// AuditMiddleware 操作审计中间件，需在 AuthMiddleware 之后使用
+func AuditMiddleware(c *gin.Context) {
+	method := c.Request.Method
+	if (method != http.MethodPost && method != http.MethodPut && method != http.MethodDelete) ||
+		c.FullPath() == "" || utils.InList(global.Config.WhiteList, c.Request.URL.Path) {
+		c.Next()
+		return
+	}
+
+	start := time.Now()
+	route := method + " " + c.FullPath()
+	body := readAuditBody(c)
+	idList := auditIDList(c, body)
+	model, snapshot := auditModelMap[route]
+	var before string
+	if snapshot {
+		before = auditSnapshot(model, idList)
+	}
+
+	writer := &auditWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	c.Next()
+
+	var response struct {
+		Code int    `json:"code"`
+		Data any    `json:"data"`
+		Msg  string `json:"msg"`
+	}
+	json.Unmarshal(writer.buf.Bytes(), &response)
+	// 创建类接口成功时返回新记录的ID
+	if id, ok := response.Data.(float64); ok && len(idList) == 0 && response.Code == 0 {
+		idList = append(idList, uint(id))
+	}
+	var after string
+	if snapshot && response.Code == 0 {
+		after = auditSnapshot(model, idList)
+	}
+
+	var userID uint
+	if _, ok := c.Get("claims"); ok {
+		userID = GetAuth(c).UserID
+	}
+	var content string
+	if apiKey := GetApiKey(c); apiKey != nil {
+		content = fmt.Sprintf("通过API密钥 %s(%s) 操作", apiKey.Title, apiKey.Prefix)
+	}
+	level := int8(1)
+	if response.Code != 0 {
+		level = 2
+	}
+	var bodyText string
+	if len(body) > 0 {
+		bodyText = log_service.Redact(body)
+	}
+	log_service.SaveAudit(models.LogModel{
+		IP:          c.ClientIP(),
+		UserID:      userID,
+		Title:       truncateRunes(response.Msg, 64),
+		Content:     content,
+		Level:       level,
+		ServiceName: "honey_server",
+		Method:      method,
+		Path:        c.FullPath(),
+		Body:        bodyText,
+		Code:        response.Code,
+		Latency:     time.Since(start).Milliseconds(),
+		IDList:      idList,
+		Before:      before,
+		After:       after,
+	})
+}
+
+// readAuditBody 读取 JSON 请求体并重新放回，供后续绑定使用
+func readAuditBody(c *gin.Context) []byte {
+	if c.Request.Body == nil || !strings.Contains(c.ContentType(), "json") {
+		return nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil || len(body) > auditBodyLimit {
+		return nil
+	}
+	return body
+}
+
+// auditIDList 从路径参数和请求体中提取影响的记录ID
+func auditIDList(c *gin.Context, body []byte) []uint {
+	var idList []uint
+	if id, err := strconv.ParseUint(c.Param("id"), 10, 64); err == nil {
+		idList = append(idList, uint(id))
+	}
+
+	var data map[string]any
+	if json.Unmarshal(body, &data) != nil {
+		return idList
+	}
+	for _, key := range auditIDKeyList {
+		switch value := data[key].(type) {
+		case float64:
+			idList = append(idList, uint(value))
+		case []any:
+			for _, item := range value {
+				if id, ok := item.(float64); ok {
+					idList = append(idList, uint(id))
+				}
+			}
+		}
+	}
+	return idList
+}
+
+// auditSnapshot 查询记录快照，返回脱敏后的 JSON
+func auditSnapshot(model any, idList []uint) string {
+	if len(idList) == 0 {
+		return ""
+	}
+	list := reflect.New(reflect.SliceOf(reflect.TypeOf(model)))
+	if err := global.DB.Model(model).Where("id in ?", idList).Find(list.Interface()).Error; err != nil {
+		return ""
+	}
+	byteData, _ := json.Marshal(list.Elem().Interface())
+	return log_service.Redact(byteData)
+}
+
+// truncateRunes 按字符截断字符串
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
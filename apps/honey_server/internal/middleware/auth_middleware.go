@@ -4,12 +4,18 @@ package middleware
 // Description: 提供认证相关的中间件功能。
 
 import (
+	"errors"
 	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/api_key_service"
+	"honey_server/internal/service/session_service"
 	"honey_server/internal/utils"
 	"honey_server/internal/utils/jwts"
 	"honey_server/internal/utils/res"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 )
 
 // AuthMiddleware 通用认证中间件
@@ -21,43 +27,88 @@ func AuthMiddleware(c *gin.Context) {
 		c.Next()
 		return
 	}
-	token := c.GetHeader("token") // 从请求头获取 token
-	claims, err := jwts.ParseToken(token)
+
+	claims, apiKey, err := Authenticate(c)
 	if err != nil {
-		// token 无效或解析失败
-		res.FailWithMsg("认证失败", c)
+		res.FailWithMsg(err.Error(), c)
 		c.Abort() // 阻止后续处理函数执行
 		return
 	}
+	if apiKey != nil {
+		c.Set("apiKey", apiKey)
+	}
 
 	// 解析成功，继续执行下一个中间件或处理函数
 	c.Set("claims", claims) // 将解析后的用户信息存入上下文，供后续使用
 	c.Next()
 }
 
-// GetAuth 获取当前请求的用户信息
-func GetAuth(c *gin.Context) *jwts.Claims {
-	return c.MustGet("claims").(*jwts.Claims)
+// getToken 获取请求头中的 token
+func getToken(c *gin.Context) string {
+	return c.GetHeader("token")
 }
 
-// AdminMiddleware 管理员权限中间件
-func AdminMiddleware(c *gin.Context) {
-	token := c.GetHeader("token") // 从请求头获取 token
-	claims, err := jwts.ParseToken(token)
+// getTicket 获取请求携带的一次性访问票据，仅 EventSource 及 WebSocket 请求（浏览器无法设置请求头）使用
+func getTicket(c *gin.Context) string {
+	if c.GetHeader("token") != "" {
+		return ""
+	}
+	if !strings.Contains(c.GetHeader("Accept"), "text/event-stream") && !websocket.IsWebSocketUpgrade(c.Request) {
+		return ""
+	}
+	return c.Query("ticket")
+}
+
+// Authenticate 校验请求携带的凭据，返回用户信息，使用API密钥时一并返回API密钥
+// 供认证中间件及 image_server 的会话校验接口使用
+func Authenticate(c *gin.Context) (*jwts.Claims, *models.ApiKeyModel, error) {
+	// 携带API密钥的请求，由API密钥所属用户的身份访问，权限受授权范围限制
+	if key := getApiKey(c); key != "" {
+		model, user, err := api_key_service.Authenticate(key, c.ClientIP())
+		if err != nil {
+			return nil, nil, err
+		}
+		return &jwts.Claims{ClaimsUserInfo: jwts.ClaimsUserInfo{UserID: user.ID, RoleID: user.RoleID}}, model, nil
+	}
+
+	var claims *jwts.Claims
+	var err error
+	if ticket := getTicket(c); ticket != "" {
+		claims, err = session_service.UseTicket(ticket)
+	} else {
+		claims, err = jwts.ParseToken(getToken(c))
+	}
 	if err != nil {
-		// token 无效
-		res.FailWithMsg("认证失败", c)
-		c.Abort()
-		return
+		// token 或票据无效、解析失败
+		return nil, nil, errors.New("认证失败")
 	}
 
-	// 校验角色是否为管理员
-	if claims.Role != 1 {
-		res.FailWithMsg("无权限访问", c)
-		c.Abort()
-		return
+	// 校验服务端会话：已注销、被吊销或未知的会话均拒绝
+	if err = session_service.Check(claims); err != nil {
+		return nil, nil, errors.New("登录已失效，请重新登录")
 	}
+	return claims, nil, nil
+}
 
-	// 验证通过，继续执行
-	c.Next()
+// getApiKey 从 Authorization 请求头获取API密钥，格式为 Bearer hk_xxx
+func getApiKey(c *gin.Context) string {
+	key, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || !strings.HasPrefix(key, api_key_service.KeyPrefix) {
+		return ""
+	}
+	return key
+}
+
+// GetApiKey 获取当前请求使用的API密钥，通过登录token访问时返回nil
+func GetApiKey(c *gin.Context) *models.ApiKeyModel {
+	model, ok := c.Get("apiKey")
+	if !ok {
+		return nil
+	}
+	return model.(*models.ApiKeyModel)
+}
+
+// GetAuth 获取当前请求的用户信息
+func GetAuth(c *gin.Context) *jwts.Claims {
+	return c.MustGet("claims").(*jwts.Claims)
 }
@@ -46,6 +46,25 @@ func BindUriMiddleware[T any](c *gin.Context) {
 	c.Set("request", cr)
 }
 
+// BindUriJsonMiddleware 通用 Uri + JSON 参数绑定中间件
+// 适用于路径中携带资源ID、请求体携带其余参数的接口；先绑定 JSON 再绑定 Uri，Uri 字段不应设置 required 校验
+func BindUriJsonMiddleware[T any](c *gin.Context) {
+	var cr T
+	err := c.ShouldBindJSON(&cr)
+	if err != nil {
+		res.FailWithError(err, c)
+		c.Abort()
+		return
+	}
+	err = c.ShouldBindUri(&cr)
+	if err != nil {
+		res.FailWithMsg("err", c)
+		c.Abort()
+		return
+	}
+	c.Set("request", cr)
+}
+
 // GetBind 获取绑定参数的通用方法
 func GetBind[T any](c *gin.Context) (cr T) {
 	return c.MustGet("request").(T)
@@ -0,0 +1,65 @@
+package middleware
+
+// File: middleware/permission_middleware.go
+// Description: 路由权限中间件。路由权限表登记每个路由所需的权限，未登记的路由一律拒绝访问
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/service/api_key_service"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/utils"
+	"honey_server/internal/utils/res"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// routePermissionMap 路由权限表，键为 "方法 路由"，值为所需权限，空字符串表示登录即可访问
+var routePermissionMap = map[string]string{}
+
+// RegisterRoutePermission 登记路由权限表
+func RegisterRoutePermission(permissionMap map[string]string) {
+	for route, permission := range permissionMap {
+		routePermissionMap[route] = permission
+	}
+}
+
+// CheckRoutePermission 检查已注册的路由是否都登记了权限，未登记的路由将无法访问
+func CheckRoutePermission(routes gin.RoutesInfo) {
+	for _, route := range routes {
+		if utils.InList(global.Config.WhiteList, route.Path) {
+			continue
+		}
+		if _, ok := routePermissionMap[route.Method+" "+route.Path]; !ok {
+			logrus.Warnf("路由 %s %s 未登记权限，将拒绝访问", route.Method, route.Path)
+		}
+	}
+}
+
+// PermissionMiddleware 路由权限中间件，需在 AuthMiddleware 之后使用
+func PermissionMiddleware(c *gin.Context) {
+	path := c.FullPath()
+	if path == "" || utils.InList(global.Config.WhiteList, c.Request.URL.Path) {
+		// 未匹配的路由交由 gin 返回404，白名单路由免认证
+		c.Next()
+		return
+	}
+
+	permission, ok := routePermissionMap[c.Request.Method+" "+path]
+	if apiKey := GetApiKey(c); apiKey != nil {
+		// API密钥只能访问授权范围内的路由，仅需登录的路由（个人资料、会话、两步验证、API密钥管理等）不对API密钥开放
+		if !ok || permission == "" || !api_key_service.HasScope(apiKey, permission) {
+			res.FailWithMsg("API密钥无权访问", c)
+			c.Abort()
+			return
+		}
+		c.Next()
+		return
+	}
+	if !ok || (permission != "" && !role_service.HasPermission(GetAuth(c).UserID, permission)) {
+		res.FailWithMsg("无权限访问", c)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
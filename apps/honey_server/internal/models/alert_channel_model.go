@@ -0,0 +1,34 @@
+package models
+
+// File: models/alert_channel_model.go
+// Description: 定义告警通知渠道的数据模型，支持Webhook、SMTP邮件、Syslog三种渠道。
+
+// 告警通知渠道表
+type AlertChannelModel struct {
+	Model
+	Title  string             `gorm:"size:64" json:"title"`                    // 渠道名称
+	Type   string             `gorm:"size:16" json:"type"`                     // 渠道类型 webhook email syslog
+	Enable bool               `json:"enable"`                                  // 是否启用
+	Config AlertChannelConfig `gorm:"type:text;serializer:json" json:"config"` // 渠道配置
+}
+
+// AlertChannelConfig 通知渠道配置，按渠道类型使用对应字段
+type AlertChannelConfig struct {
+	// Webhook
+	URL    string `json:"url,omitempty"`    // 请求地址
+	Secret string `json:"secret,omitempty"` // 签名密钥，不为空时使用HMAC-SHA256签名请求体
+
+	// SMTP邮件
+	Host     string   `json:"host,omitempty"`     // SMTP服务器地址
+	Port     int      `json:"port,omitempty"`     // SMTP服务器端口
+	Username string   `json:"username,omitempty"` // 认证用户名，为空时不认证
+	Password string   `json:"password,omitempty"` // 认证密码
+	From     string   `json:"from,omitempty"`     // 发件人
+	To       []string `json:"to,omitempty"`       // 收件人列表
+	TLS      bool     `json:"tls,omitempty"`      // 是否使用TLS直连（如465端口），否则在服务器支持时使用STARTTLS
+
+	// Syslog
+	Network string `json:"network,omitempty"` // 传输协议 udp tcp
+	Addr    string `json:"addr,omitempty"`    // Syslog服务器地址
+	Tag     string `json:"tag,omitempty"`     // 应用名称，默认 honey_server
+}
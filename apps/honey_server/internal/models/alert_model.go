@@ -0,0 +1,28 @@
+package models
+
+// File: models/alert_model.go
+// Description: 定义告警记录的数据模型，记录规则触发的告警、重复告警的合并次数、通知结果及确认/解决状态。
+
+import "time"
+
+// 告警记录表
+type AlertModel struct {
+	Model
+	RuleID        uint       `gorm:"index:idx_rule_id" json:"ruleID"`    // 触发的规则ID
+	RuleTitle     string     `gorm:"size:64" json:"ruleTitle"`           // 触发时的规则名称
+	Severity      int8       `gorm:"index:idx_severity" json:"severity"` // 告警级别 1 低 2 中 3 高 4 严重
+	EventType     string     `gorm:"size:32" json:"eventType"`           // 事件类型
+	NodeID        uint       `json:"nodeID"`                             // 关联的节点ID
+	GroupKey      string     `gorm:"size:64" json:"groupKey"`            // 分组值（如攻击者IP）
+	Content       string     `gorm:"size:1024" json:"content"`           // 告警内容
+	Count         int        `json:"count"`                              // 合并的触发次数
+	FirstTime     time.Time  `json:"firstTime"`                          // 首次触发时间
+	LastTime      time.Time  `json:"lastTime"`                           // 最近触发时间
+	Status        int8       `gorm:"index:idx_status" json:"status"`     // 状态 1 未处理 2 已确认 3 已解决
+	NotifyResult  string     `gorm:"size:1024" json:"notifyResult"`      // 通知结果
+	AckUserID     uint       `json:"ackUserID"`                          // 确认人
+	AckTime       *time.Time `json:"ackTime"`                            // 确认时间
+	ResolveUserID uint       `json:"resolveUserID"`                      // 解决人
+	ResolveTime   *time.Time `json:"resolveTime"`                        // 解决时间
+	Remark        string     `gorm:"size:256" json:"remark"`             // 处理备注
+}
@@ -0,0 +1,23 @@
+package models
+
+// File: models/alert_rule_model.go
+// Description: 定义告警规则的数据模型，规则按事件类型及条件匹配实时事件，在时间窗口内达到阈值后产生告警。
+
+// 告警规则表
+type AlertRuleModel struct {
+	Model
+	Title          string `gorm:"size:64" json:"title"`                           // 规则名称
+	Enable         bool   `json:"enable"`                                         // 是否启用
+	EventType      string `gorm:"size:32" json:"eventType"`                       // 匹配的事件类型
+	NodeID         uint   `json:"nodeID"`                                         // 条件：节点ID，为0时不限
+	HoneyIpID      uint   `json:"honeyIpID"`                                      // 条件：诱捕IP ID，为0时不限
+	Protocol       string `gorm:"size:16" json:"protocol"`                        // 条件：攻击会话的模拟器协议，为空时不限
+	SrcIP          string `gorm:"size:64" json:"srcIP"`                           // 条件：攻击者IP或网段（CIDR），为空时不限
+	DstPort        int    `json:"dstPort"`                                        // 条件：诱捕端口，为0时不限
+	Threshold      int    `json:"threshold"`                                      // 阈值：时间窗口内匹配的事件数达到该值时告警
+	Window         int    `json:"window"`                                         // 时间窗口，单位: 秒，为0时每个事件单独判断
+	GroupBy        string `gorm:"size:16" json:"groupBy"`                         // 分组计数字段 src_ip honey_ip node，为空时不分组
+	Severity       int8   `json:"severity"`                                       // 告警级别 1 低 2 中 3 高 4 严重
+	SuppressWindow int    `json:"suppressWindow"`                                 // 抑制窗口，单位: 秒，窗口内同一规则同一分组的重复告警合并到未解决的告警中，不再通知
+	ChannelIDList  []uint `gorm:"type:text;serializer:json" json:"channelIDList"` // 通知渠道ID列表
+}
@@ -0,0 +1,20 @@
+package models
+
+// File: models/api_key_model.go
+// Description: 定义API密钥的数据模型，供脚本、SOAR等自动化系统免登录调用接口。
+// 数据库只保存密钥摘要，完整密钥仅在创建时返回一次
+
+import "time"
+
+// ApiKeyModel API密钥模型
+type ApiKeyModel struct {
+	Model
+	UserID     uint       `gorm:"index:idx_user_id" json:"userID"`            // 所属用户ID，密钥的权限不超过所属用户的权限
+	Title      string     `gorm:"size:32" json:"title"`                       // 名称
+	Prefix     string     `gorm:"size:16" json:"prefix"`                      // 密钥前缀，用于识别密钥
+	KeyHash    string     `gorm:"size:64;uniqueIndex:idx_key_hash" json:"-"`  // 密钥摘要
+	ScopeList  []string   `gorm:"type:text;serializer:json" json:"scopeList"` // 授权范围，为权限码列表
+	ExpiresAt  *time.Time `json:"expiresAt"`                                  // 过期时间，为空表示永不过期
+	LastUsedAt *time.Time `json:"lastUsedAt"`                                 // 最近使用时间
+	LastUsedIP string     `gorm:"size:64" json:"lastUsedIP"`                  // 最近使用IP
+}
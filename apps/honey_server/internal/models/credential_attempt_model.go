@@ -0,0 +1,20 @@
+package models
+
+// File: models/credential_attempt_model.go
+// Description: 定义凭据尝试的数据模型，记录攻击者在模拟服务上尝试的用户名与口令，供凭据分析使用。
+
+import "time"
+
+// 凭据尝试表
+type CredentialAttemptModel struct {
+	Model
+	NodeID      uint      `gorm:"index:idx_node_id" json:"nodeID"`               // 所属节点ID
+	HoneyIpID   uint      `gorm:"index:idx_honey_ip_id" json:"honeyIpID"`        // 诱捕IP ID
+	SessionID   string    `gorm:"size:64;index:idx_session_id" json:"sessionID"` // 所属交互会话ID
+	Protocol    string    `gorm:"size:16;index:idx_protocol" json:"protocol"`    // 协议
+	SrcIP       string    `gorm:"size:32;index:idx_src_ip" json:"srcIP"`         // 攻击者IP
+	Username    string    `gorm:"size:128;index:idx_username" json:"username"`   // 用户名
+	Password    string    `gorm:"size:128;index:idx_password" json:"password"`   // 明文口令
+	Hash        string    `gorm:"size:256" json:"hash"`                          // 无法获取明文时的口令摘要（如MySQL的挑战值与应答）
+	AttemptTime time.Time `gorm:"index:idx_attempt_time" json:"attemptTime"`     // 尝试时间
+}
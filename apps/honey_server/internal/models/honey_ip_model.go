@@ -6,13 +6,16 @@ package models
 // 诱捕IP表
 type HoneyIpModel struct {
 	Model
-	NodeID    uint      `json:"nodeID"`                         // 所属节点ID
-	NodeModel NodeModel `gorm:"foreignKey:NodeID" json:"-"`     // 关联节点
-	NetID     uint      `gorm:"index:idx_net_id" json:"netID"`  // 所属网络ID
-	NetModel  NetModel  `gorm:"foreignKey:NetID" json:"-"`      // 关联网络
-	IP        string    `gorm:"size:32;index:idx_ip" json:"ip"` // 诱捕IP
-	Mac       string    `gorm:"size:64" json:"mac"`             // MAC地址
-	Network   string    `gorm:"size:32" json:"network"`         // 网卡
-	Status    int8      `json:"status"`                         // 状态  1 创建中 2 运行中 3 失败 4 删除中
-	ErrorMsg  string    `gorm:"size:64" json:"errorMsg"`        // 错误信息
+	NodeID         uint      `json:"nodeID"`                                           // 所属节点ID
+	NodeModel      NodeModel `gorm:"foreignKey:NodeID" json:"-"`                       // 关联节点
+	NetID          uint      `gorm:"index:idx_net_id" json:"netID"`                    // 所属网络ID
+	NetModel       NetModel  `gorm:"foreignKey:NetID" json:"-"`                        // 关联网络
+	IP             string    `gorm:"size:32;index:idx_ip" json:"ip"`                   // 诱捕IP
+	Mac            string    `gorm:"size:64" json:"mac"`                               // MAC地址
+	Network        string    `gorm:"size:32" json:"network"`                           // 网卡
+	Status         int8      `json:"status"`                                           // 状态  1 创建中 2 运行中 3 失败 4 删除中
+	Operation      int8      `json:"operation"`                                        // 待完成的操作 1 创建 2 删除，失败后重试时据此重新下发
+	ErrorMsg       string    `gorm:"size:64" json:"errorMsg"`                          // 错误信息
+	RetryCount     int       `json:"retryCount"`                                       // 状态超时后的自动重试次数
+	HostTemplateID uint      `gorm:"index:idx_host_template_id" json:"hostTemplateID"` // 关联的主机模板ID（0表示手动配置端口）
 }
@@ -17,5 +17,7 @@ type HoneyPortModel struct {
 	Port         int          `json:"port"`                                   // 服务的端口
 	DstIP        string       `gorm:"size:32" json:"dstIP"`                   // 目标IP
 	DstPort      int          `json:"dstPort"`                                // 目标端口
-	Status       int8         `json:"status"`                                 // 服务状态
+	Emulator     string       `gorm:"size:16" json:"emulator"`                // 内置模拟器协议，不为空时由节点直接模拟，不经过隧道转发
+	Status       int8         `json:"status"`                                 // 服务状态 1 绑定中 2 监听中 3 绑定失败
+	ErrorMsg     string       `gorm:"size:128" json:"errorMsg"`               // 绑定失败原因
 }
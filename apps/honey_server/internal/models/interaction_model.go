@@ -0,0 +1,33 @@
+package models
+
+// File: models/interaction_model.go
+// Description: 定义内置模拟器交互会话的数据模型，记录攻击者与节点上模拟服务的每一次连接及会话内的交互事件。
+
+import "time"
+
+// 交互会话表
+type InteractionModel struct {
+	Model
+	NodeID      uint                 `gorm:"index:idx_node_id" json:"nodeID"`                // 所属节点ID
+	HoneyIpID   uint                 `gorm:"index:idx_honey_ip_id" json:"honeyIpID"`         // 诱捕IP ID
+	HoneyPortID uint                 `json:"honeyPortID"`                                    // 诱捕端口ID
+	SessionID   string               `gorm:"size:64;uniqueIndex" json:"sessionID"`           // 会话ID（节点生成）
+	Protocol    string               `gorm:"size:16;index:idx_protocol" json:"protocol"`     // 模拟器协议
+	SrcIP       string               `gorm:"size:32;index:idx_src_ip" json:"srcIP"`          // 攻击者IP
+	SrcPort     int                  `json:"srcPort"`                                        // 攻击者端口
+	DstIP       string               `gorm:"size:32" json:"dstIP"`                           // 诱捕IP
+	DstPort     int                  `json:"dstPort"`                                        // 诱捕端口
+	StartTime   time.Time            `json:"startTime"`                                      // 会话开始时间
+	EndTime     time.Time            `json:"endTime"`                                        // 会话结束时间
+	EventCount  int                  `json:"eventCount"`                                     // 交互事件数量
+	EventList   InteractionEventList `gorm:"type:longtext;serializer:json" json:"eventList"` // 交互事件列表
+}
+
+type InteractionEventList []InteractionEvent
+
+// 交互事件
+type InteractionEvent struct {
+	Time      time.Time `json:"time"`      // 事件时间
+	EventType string    `json:"eventType"` // 事件类型 auth command request read write
+	Data      string    `json:"data"`      // 事件内容
+}
@@ -6,15 +6,22 @@ package models
 // 日志模型
 type LogModel struct {
 	Model
-	Type        int8   `json:"type"`                            // 日志类型 1=登录日志
-	IP          string `gorm:"size:32;index:idx_ip" json:"ip"`  // IP地址
-	Addr        string `gorm:"size:64" json:"addr"`             // 地址
-	UserID      uint   `gorm:"index:idx_user_id" json:"userID"` // 用户ID
-	Username    string `gorm:"size:32" json:"username"`         // 用户名
-	Pwd         string `gorm:"size:64" json:"pwd"`              // 密码
-	LoginStatus bool   `json:"loginStatus"`                     // 登录状态
-	Title       string `gorm:"size:64" json:"title"`            // 日志标题
-	Level       int8   `json:"level"`                           // 日志级别
-	Content     string `json:"content"`                         // 日志内容
-	ServiceName string `gorm:"size:32" json:"serviceName"`      // 服务名称
+	Type        int8   `json:"type"`                                    // 日志类型 1=登录日志 2=操作审计日志
+	IP          string `gorm:"size:32;index:idx_ip" json:"ip"`          // IP地址
+	Addr        string `gorm:"size:64" json:"addr"`                     // 地址
+	UserID      uint   `gorm:"index:idx_user_id" json:"userID"`         // 用户ID
+	Username    string `gorm:"size:32" json:"username"`                 // 用户名
+	LoginStatus bool   `json:"loginStatus"`                             // 登录状态
+	Title       string `gorm:"size:64" json:"title"`                    // 日志标题
+	Level       int8   `json:"level"`                                   // 日志级别
+	Content     string `json:"content"`                                 // 日志内容
+	ServiceName string `gorm:"size:32" json:"serviceName"`              // 服务名称
+	Method      string `gorm:"size:8" json:"method"`                    // 请求方法
+	Path        string `gorm:"size:128;index:idx_path" json:"path"`     // 请求路由
+	Body        string `gorm:"type:text" json:"body"`                   // 请求体，敏感字段已脱敏
+	Code        int    `json:"code"`                                    // 响应的业务状态码
+	Latency     int64  `json:"latency"`                                 // 耗时（毫秒）
+	IDList      []uint `gorm:"type:text;serializer:json" json:"idList"` // 影响的记录ID
+	Before      string `gorm:"type:text" json:"before"`                 // 变更前的记录快照
+	After       string `gorm:"type:text" json:"after"`                  // 变更后的记录快照
 }
@@ -0,0 +1,16 @@
+package models
+
+// File: models/mq_dead_letter_model.go
+// Description: 定义死信消息的数据模型，节点处理失败且超过最大重试次数的消息由服务端收集入库，供查看与重放。
+
+// 死信消息表
+type MqDeadLetterModel struct {
+	Model
+	MessageID  string `gorm:"size:64;index:idx_message_id" json:"messageID"` // 原消息ID
+	Exchange   string `gorm:"size:64" json:"exchange"`                       // 原业务交换器
+	NodeUID    string `gorm:"size:64;index:idx_node_uid" json:"nodeUID"`     // 处理失败的节点UID
+	Body       string `gorm:"type:text" json:"body"`                         // 消息体
+	RetryCount int    `json:"retryCount"`                                    // 节点已重试次数
+	ErrorMsg   string `gorm:"size:256" json:"errorMsg"`                      // 最后一次处理失败原因
+	Status     int8   `gorm:"index:idx_status" json:"status"`                // 状态 1 待处理 2 已重放 3 已忽略
+}
@@ -0,0 +1,19 @@
+package models
+
+// File: models/mq_outbox_model.go
+// Description: 定义消息发件箱的数据模型，业务数据与待发送消息在同一事务中写入，由投递器异步可靠发送。
+
+import "time"
+
+// 消息发件箱表
+type MqOutboxModel struct {
+	Model
+	MessageID   string    `gorm:"size:64;uniqueIndex:idx_message_id" json:"messageID"` // 消息ID（随消息发送，供消费端幂等）
+	Exchange    string    `gorm:"size:64" json:"exchange"`                             // 目标交换器
+	RoutingKey  string    `gorm:"size:64" json:"routingKey"`                           // 路由键（节点UID）
+	Body        string    `gorm:"type:text" json:"body"`                               // 消息体
+	Status      int8      `gorm:"index:idx_status" json:"status"`                      // 状态 1 待发送 2 已发送 3 发送失败
+	RetryCount  int       `json:"retryCount"`                                          // 已重试次数
+	NextRetryAt time.Time `json:"nextRetryAt"`                                         // 下次发送时间
+	ErrorMsg    string    `gorm:"size:256" json:"errorMsg"`                            // 最近一次发送的错误信息
+}
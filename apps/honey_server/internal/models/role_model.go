@@ -0,0 +1,13 @@
+package models
+
+// File: models/role_model.go
+// Description: 定义角色的数据模型，角色持有一组权限，用户通过角色获得权限
+
+// RoleModel 角色模型
+type RoleModel struct {
+	Model
+	Title          string   `gorm:"size:32" json:"title"`                            // 角色名称
+	Builtin        bool     `json:"builtin"`                                         // 是否为内置角色，内置角色不可修改和删除
+	PermissionList []string `gorm:"type:text;serializer:json" json:"permissionList"` // 权限列表，* 表示全部权限
+	Remark         string   `gorm:"size:128" json:"remark"`                          // 备注
+}
@@ -6,13 +6,33 @@ package models
 // 服务模型
 type ServiceModel struct {
 	Model
-	Title        string     `gorm:"size:64" json:"title"`           // 服务名称
-	Agreement    int8       `json:"agreement"`                      // 协议
-	ImageID      uint       `json:"imageID"`                        // 镜像ID
-	ImageModel   ImageModel `gorm:"foreignKey:ImageID" json:"-"`    // 关联镜像模型
-	IP           string     `gorm:"size:32;index:idx_ip" json:"ip"` // 服务IP
-	Port         int        `json:"port"`                           // 服务端口
-	Status       int8       `json:"status"`                         // 服务状态
-	HoneyIPCount int        `json:"honeyIPCount"`                   // 诱捕IP数量
-	ContainerID  string     `gorm:"size:32" json:"containerID"`     // 容器ID
+	Title          string         `gorm:"size:64" json:"title"`                            // 服务名称
+	Type           int8           `gorm:"default:1" json:"type"`                           // 服务类型 1 容器服务 2 内置模拟器
+	Emulator       string         `gorm:"size:16" json:"emulator"`                         // 内置模拟器协议 ssh telnet ftp http redis mysql modbus iec104 s7
+	EmulatorConfig EmulatorConfig `gorm:"type:text;serializer:json" json:"emulatorConfig"` // 内置模拟器的设备配置
+	Agreement      int8           `json:"agreement"`                                       // 协议
+	ImageID        uint           `json:"imageID"`                                         // 镜像ID
+	ImageModel     ImageModel     `gorm:"foreignKey:ImageID" json:"-"`                     // 关联镜像模型
+	IP             string         `gorm:"size:32;index:idx_ip" json:"ip"`                  // 服务IP
+	Port           int            `json:"port"`                                            // 服务端口
+	Status         int8           `json:"status"`                                          // 服务状态
+	HoneyIPCount   int            `json:"honeyIPCount"`                                    // 诱捕IP数量
+	ContainerID    string         `gorm:"size:32" json:"containerID"`                      // 容器ID
+}
+
+// 内置模拟器的设备配置（工控协议使用，为空时节点使用该协议的默认设备配置）
+type EmulatorConfig struct {
+	Vendor       string          `json:"vendor"`       // 设备厂商
+	Model        string          `json:"model"`        // 设备型号
+	Firmware     string          `json:"firmware"`     // 固件版本
+	SerialNumber string          `json:"serialNumber"` // 序列号
+	PointList    []EmulatorPoint `json:"pointList"`    // 数据点列表
+}
+
+// 模拟设备的数据点
+type EmulatorPoint struct {
+	Area    string  `json:"area"`    // 数据区 coil discrete holding input（Modbus） single measured（IEC104） db（S7）
+	DB      int     `json:"db"`      // S7数据块编号
+	Address int     `json:"address"` // 地址（IEC104为信息对象地址，S7为数据块内的字节偏移）
+	Value   float64 `json:"value"`   // 值（线圈、遥信为0或1，S7数据块为16位整数）
 }
@@ -0,0 +1,46 @@
+package models
+
+// File: models/transcript_model.go
+// Description: 定义隧道会话录制的数据模型，索引每个经隧道转发的攻击会话及其在磁盘上的录制文件。
+
+import (
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// 会话录制表
+type TranscriptModel struct {
+	Model
+	NodeID      uint      `gorm:"index:idx_node_id" json:"nodeID"`        // 所属节点ID
+	HoneyIpID   uint      `gorm:"index:idx_honey_ip_id" json:"honeyIpID"` // 诱捕IP ID
+	HoneyPortID uint      `json:"honeyPortID"`                            // 诱捕端口ID
+	SessionID   string    `gorm:"size:64;uniqueIndex" json:"sessionID"`   // 会话ID（节点生成）
+	SrcIP       string    `gorm:"size:32;index:idx_src_ip" json:"srcIP"`  // 攻击者IP
+	SrcPort     int       `json:"srcPort"`                                // 攻击者端口
+	DstIP       string    `gorm:"size:32" json:"dstIP"`                   // 诱捕IP
+	DstPort     int       `json:"dstPort"`                                // 诱捕端口
+	TargetAddr  string    `gorm:"size:64" json:"targetAddr"`              // 转发的目标服务地址
+	StartTime   time.Time `json:"startTime"`                              // 会话开始时间
+	EndTime     time.Time `json:"endTime"`                                // 会话结束时间，会话进行中为空
+	InBytes     int64     `json:"inBytes"`                                // 攻击者发送的字节数
+	OutBytes    int64     `json:"outBytes"`                               // 诱捕服务返回的字节数
+	FrameCount  int       `json:"frameCount"`                             // 录制的数据块数量
+	Truncated   bool      `json:"truncated"`                              // 是否因超出录制上限被截断
+	FilePath    string    `gorm:"size:256" json:"-"`                      // 录制文件路径
+	FileSize    int64     `json:"fileSize"`                               // 录制文件大小（压缩后）
+}
+
+// AfterDelete 删除会话录制后删除对应的录制文件
+func (model TranscriptModel) AfterDelete(tx *gorm.DB) error {
+	if model.FilePath == "" {
+		return nil
+	}
+	err := os.Remove(model.FilePath)
+	if err != nil && !os.IsNotExist(err) {
+		logrus.Errorf("删除录制文件失败 %s", err)
+	}
+	return nil
+}
@@ -8,10 +8,18 @@ import "gorm.io/gorm"
 // 用户模型
 type UserModel struct {
 	Model
-	Username      string `gorm:"size:32;index:idx_username" json:"username"` // 用户名
-	Role          int8   `json:"role"`                                       // 角色 1:管理员 2:普通用户
-	Password      string `gorm:"size:64" json:"-"`                           // 密码
-	LastLoginDate string `gorm:"size:32" json:"lastLoginDate"`               // 最后登录时间
+	Username           string   `gorm:"size:32;index:idx_username" json:"username"` // 用户名
+	RoleID             uint     `json:"roleID"`                                     // 角色ID
+	Password           string   `gorm:"size:64" json:"-"`                           // 密码
+	LastLoginDate      string   `gorm:"size:32" json:"lastLoginDate"`               // 最后登录时间
+	TotpEnable         bool     `json:"totpEnable"`                                 // 是否启用两步验证
+	TotpSecret         string   `gorm:"size:64" json:"-"`                           // 两步验证密钥
+	RecoveryCodes      []string `gorm:"type:text;serializer:json" json:"-"`         // 恢复码摘要，每个恢复码只能使用一次
+	Nickname           string   `gorm:"size:32" json:"nickname"`                    // 昵称
+	Email              string   `gorm:"size:128" json:"email"`                      // 邮箱
+	MustChangePassword bool     `json:"mustChangePassword"`                         // 下次登录时必须修改密码，管理员重置密码后设置
+	PasswordHistory    []string `gorm:"type:text;serializer:json" json:"-"`         // 最近使用过的密码摘要，用于禁止重复使用
+	OidcSubject        string   `gorm:"size:255;index:idx_oidc_subject" json:"-"`   // 单点登录用户在身份提供方的唯一标识，本地用户为空
 }
 
 func (UserModel) BeforeDelete(tx *gorm.DB) error {
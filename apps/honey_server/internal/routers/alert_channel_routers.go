@@ -0,0 +1,32 @@
+package routers
+
+// File: routers/alert_channel_routers.go
+// Description: 告警通知渠道路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/alert_channel_api"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AlertChannelRouters(r *gin.RouterGroup) {
+	var app = api.App.AlertChannelApi
+
+	// 告警通知渠道列表（GET），绑定 Query 参数
+	r.GET("alert_channel", middleware.BindQueryMiddleware[alert_channel_api.ListRequest], app.ListView)
+
+	// 创建告警通知渠道（POST），绑定 JSON 参数
+	r.POST("alert_channel", middleware.BindJsonMiddleware[alert_channel_api.ChannelRequest], app.CreateView)
+
+	// 更新告警通知渠道（PUT），绑定 JSON 参数
+	r.PUT("alert_channel", middleware.BindJsonMiddleware[alert_channel_api.UpdateRequest], app.UpdateView)
+
+	// 删除告警通知渠道（DELETE），绑定 JSON 参数
+	r.DELETE("alert_channel", middleware.BindJsonMiddleware[models.IDListRequest], app.RemoveView)
+
+	// 测试告警通知渠道（POST），绑定 JSON 参数
+	r.POST("alert_channel/test", middleware.BindJsonMiddleware[models.IDRequest], app.TestView)
+}
@@ -0,0 +1,25 @@
+package routers
+
+// File: routers/alert_routers.go
+// Description: 告警记录路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/alert_api"
+	"honey_server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AlertRouters(r *gin.RouterGroup) {
+	var app = api.App.AlertApi
+
+	// 告警记录列表（GET），绑定 Query 参数
+	r.GET("alert", middleware.BindQueryMiddleware[alert_api.ListRequest], app.ListView)
+
+	// 确认告警（PUT），绑定 JSON 参数
+	r.PUT("alert/ack", middleware.BindJsonMiddleware[alert_api.HandleRequest], app.AckView)
+
+	// 解决告警（PUT），绑定 JSON 参数
+	r.PUT("alert/resolve", middleware.BindJsonMiddleware[alert_api.HandleRequest], app.ResolveView)
+}
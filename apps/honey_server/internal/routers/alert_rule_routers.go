@@ -0,0 +1,29 @@
+package routers
+
+// File: routers/alert_rule_routers.go
+// Description: 告警规则路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/alert_rule_api"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AlertRuleRouters(r *gin.RouterGroup) {
+	var app = api.App.AlertRuleApi
+
+	// 告警规则列表（GET），绑定 Query 参数
+	r.GET("alert_rule", middleware.BindQueryMiddleware[alert_rule_api.ListRequest], app.ListView)
+
+	// 创建告警规则（POST），绑定 JSON 参数
+	r.POST("alert_rule", middleware.BindJsonMiddleware[alert_rule_api.RuleRequest], app.CreateView)
+
+	// 更新告警规则（PUT），绑定 JSON 参数
+	r.PUT("alert_rule", middleware.BindJsonMiddleware[alert_rule_api.UpdateRequest], app.UpdateView)
+
+	// 删除告警规则（DELETE），绑定 JSON 参数
+	r.DELETE("alert_rule", middleware.BindJsonMiddleware[models.IDListRequest], app.RemoveView)
+}
@@ -0,0 +1,26 @@
+package routers
+
+// File: routers/api_key_routers.go
+// Description: 定义API密钥相关的路由。
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/api_key_api"
+	"honey_server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApiKeyRouters 定义API密钥相关路由
+func ApiKeyRouters(r *gin.RouterGroup) {
+	var app = api.App.ApiKeyApi
+
+	// API密钥列表（GET），绑定 Query 参数
+	r.GET("api_key", middleware.BindQueryMiddleware[api_key_api.ListRequest], app.ListView)
+
+	// 创建API密钥（POST），绑定 JSON 请求体
+	r.POST("api_key", middleware.BindJsonMiddleware[api_key_api.CreateRequest], app.CreateView)
+
+	// 吊销API密钥（DELETE），绑定 JSON 请求体
+	r.DELETE("api_key", middleware.BindJsonMiddleware[api_key_api.RemoveRequest], app.RemoveView)
+}
@@ -0,0 +1,42 @@
+package routers
+
+// File: routers/audit.go
+// Description: 审计模型表，登记需要在审计日志中记录变更前后快照的路由及对应的模型
+
+import "honey_server/internal/models"
+
+var auditModelMap = map[string]any{
+	// 用户与角色
+	"POST /honey_server/users":               models.UserModel{},
+	"DELETE /honey_server/users":             models.UserModel{},
+	"PUT /honey_server/users/role":           models.UserModel{},
+	"PUT /honey_server/users/password/reset": models.UserModel{},
+	"POST /honey_server/role":                models.RoleModel{},
+	"PUT /honey_server/role":                 models.RoleModel{},
+	"DELETE /honey_server/role":              models.RoleModel{},
+	"DELETE /honey_server/api_key":           models.ApiKeyModel{},
+
+	// 节点与网络
+	"PUT /honey_server/node":                models.NodeModel{},
+	"DELETE /honey_server/node/:id":         models.NodeModel{},
+	"PUT /honey_server/node_network":        models.NodeNetworkModel{},
+	"PUT /honey_server/node_network/enable": models.NodeNetworkModel{},
+	"DELETE /honey_server/node_network/:id": models.NodeNetworkModel{},
+	"PUT /honey_server/net":                 models.NetModel{},
+	"DELETE /honey_server/net":              models.NetModel{},
+	"DELETE /honey_server/host":             models.HostModel{},
+
+	// 诱捕IP
+	"POST /honey_server/honey_ip":   models.HoneyIpModel{},
+	"DELETE /honey_server/honey_ip": models.HoneyIpModel{},
+
+	// 告警
+	"PUT /honey_server/alert/ack":        models.AlertModel{},
+	"PUT /honey_server/alert/resolve":    models.AlertModel{},
+	"POST /honey_server/alert_rule":      models.AlertRuleModel{},
+	"PUT /honey_server/alert_rule":       models.AlertRuleModel{},
+	"DELETE /honey_server/alert_rule":    models.AlertRuleModel{},
+	"POST /honey_server/alert_channel":   models.AlertChannelModel{},
+	"PUT /honey_server/alert_channel":    models.AlertChannelModel{},
+	"DELETE /honey_server/alert_channel": models.AlertChannelModel{},
+}
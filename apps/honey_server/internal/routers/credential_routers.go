@@ -0,0 +1,31 @@
+package routers
+
+// File: routers/credential_routers.go
+// Description: 凭据尝试路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/credential_api"
+	"honey_server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CredentialRouters(r *gin.RouterGroup) {
+	var app = api.App.CredentialApi
+
+	// 凭据尝试列表（GET），绑定 Query 参数
+	r.GET("credential", middleware.BindQueryMiddleware[credential_api.ListRequest], app.ListView)
+
+	// 用户名排行（GET），绑定 Query 参数
+	r.GET("credential/top_username", middleware.BindQueryMiddleware[credential_api.TopRequest], app.TopUsernameView)
+
+	// 口令排行（GET），绑定 Query 参数
+	r.GET("credential/top_password", middleware.BindQueryMiddleware[credential_api.TopRequest], app.TopPasswordView)
+
+	// 用户名口令组合排行（GET），绑定 Query 参数
+	r.GET("credential/top_pair", middleware.BindQueryMiddleware[credential_api.TopRequest], app.TopPairView)
+
+	// 攻击者凭据（GET），绑定 Query 参数
+	r.GET("credential/attacker", middleware.BindQueryMiddleware[credential_api.AttackerRequest], app.AttackerView)
+}
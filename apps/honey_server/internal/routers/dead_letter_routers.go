@@ -0,0 +1,26 @@
+package routers
+
+// File: routers/dead_letter_routers.go
+// Description: 死信消息路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/dead_letter_api"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func DeadLetterRouters(r *gin.RouterGroup) {
+	var app = api.App.DeadLetterApi
+
+	// 死信消息列表（GET），仅管理员可查看，绑定 Query 参数
+	r.GET("dead_letter", middleware.BindQueryMiddleware[dead_letter_api.ListRequest], app.ListView)
+
+	// 死信消息重放（POST），仅管理员可操作，绑定 JSON 请求体
+	r.POST("dead_letter/replay", middleware.BindJsonMiddleware[models.IDListRequest], app.ReplayView)
+
+	// 死信消息忽略（POST），仅管理员可操作，绑定 JSON 请求体
+	r.POST("dead_letter/ignore", middleware.BindJsonMiddleware[models.IDListRequest], app.IgnoreView)
+}
@@ -15,20 +15,41 @@ import (
 func Run() {
 	system := global.Config.System
 	gin.SetMode(system.Mode)
-	r := gin.Default()                                         // 创建默认路由
+	r := gin.Default() // 创建默认路由
+	// 客户端IP用于登录限制及日志，不配置受信任代理时不信任任何 X-Forwarded-For
+	if err := r.SetTrustedProxies(system.TrustedProxies); err != nil {
+		logrus.Fatalf("受信任代理配置错误 %s", err)
+	}
 	r.Static("uploads", "uploads")                             // 静态文件服务
 	g := r.Group("honey_server")                               // 统一路由前缀 /honey_server
 	g.Use(middleware.LogMiddleware, middleware.AuthMiddleware) // 系统必须登录才能访问，所有以 /honey_server 开头的路由默认都需要认证
+	g.Use(middleware.AuditMiddleware)                          // 记录新增、修改、删除操作的审计日志
+	g.Use(middleware.PermissionMiddleware)                     // 按路由权限表校验权限
 
-	UserRouters(g)        // 用户相关路由
-	CaptchaRouters(g)     // 图片验证码路由
-	LogRouters(g)         // 日志相关路由
-	NodeRouters(g)        // 节点相关路由
-	NodeNetworkRouters(g) // 节点网卡相关路由
-	NetRouters(g)         // 网络相关路由
-	HostRouters(g)        // 存活主机相关路由
-	HoneyIPRouters(g)     // 诱捕IP相关路由
-	HoneyPortRouters(g)   // 诱捕转发相关路由
+	UserRouters(g)         // 用户相关路由
+	CaptchaRouters(g)      // 图片验证码路由
+	LogRouters(g)          // 日志相关路由
+	NodeRouters(g)         // 节点相关路由
+	NodeNetworkRouters(g)  // 节点网卡相关路由
+	NetRouters(g)          // 网络相关路由
+	HostRouters(g)         // 存活主机相关路由
+	HoneyIPRouters(g)      // 诱捕IP相关路由
+	HoneyPortRouters(g)    // 诱捕转发相关路由
+	OutboxRouters(g)       // 消息发件箱相关路由
+	DeadLetterRouters(g)   // 死信消息相关路由
+	InteractionRouters(g)  // 模拟器交互会话相关路由
+	CredentialRouters(g)   // 凭据尝试相关路由
+	TranscriptRouters(g)   // 会话录制相关路由
+	EventRouters(g)        // 实时事件相关路由
+	AlertRuleRouters(g)    // 告警规则相关路由
+	AlertChannelRouters(g) // 告警通知渠道相关路由
+	AlertRouters(g)        // 告警记录相关路由
+	RoleRouters(g)         // 角色相关路由
+	ApiKeyRouters(g)       // API密钥相关路由
+
+	middleware.RegisterRoutePermission(routePermissionMap) // 登记路由权限
+	middleware.CheckRoutePermission(r.Routes())            // 检查路由是否都登记了权限
+	middleware.RegisterAuditModel(auditModelMap)           // 登记审计快照模型
 
 	webAddr := system.WebAddr
 	logrus.Infof("web addr run %s", webAddr)
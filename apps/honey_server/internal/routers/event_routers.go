@@ -0,0 +1,21 @@
+package routers
+
+// File: routers/event_routers.go
+// Description: 实时事件路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/event_api"
+	"honey_server/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func EventRouters(r *gin.RouterGroup) {
+	var app = api.App.EventApi
+
+	// 实时事件流（GET，Server-Sent Events），绑定 Query 参数
+	r.GET("event/stream", middleware.BindQueryMiddleware[event_api.StreamRequest], app.StreamView)
+	// 签发订阅事件流的一次性票据（POST）
+	r.POST("event/ticket", app.TicketView)
+}
@@ -18,9 +18,15 @@ func HoneyIPRouters(r *gin.RouterGroup) {
 	// 诱捕IP创建（POST），绑定 JSON 请求体
 	r.POST("honey_ip", middleware.BindJsonMiddleware[honey_ip_api.CreateRequest], app.CreateView)
 
+	// 诱捕IP批量创建（POST），绑定 JSON 请求体
+	r.POST("honey_ip/bulk", middleware.BindJsonMiddleware[honey_ip_api.BulkCreateRequest], app.BulkCreateView)
+
 	// 诱捕IP列表查询（GET），绑定 Query 参数
 	r.GET("honey_ip", middleware.BindQueryMiddleware[honey_ip_api.ListRequest], app.ListView)
 
 	// 诱捕IP删除（DELETE），绑定 JSON 请求体
 	r.DELETE("honey_ip", middleware.BindJsonMiddleware[models.IDListRequest], app.RemoveView)
+
+	// 诱捕IP手动重试（POST），绑定 JSON 请求体
+	r.POST("honey_ip/retry", middleware.BindJsonMiddleware[models.IDListRequest], app.RetryView)
 }
@@ -17,6 +17,9 @@ func HoneyPortRouters(r *gin.RouterGroup) {
 	// 诱捕转发更新（POST），绑定 JSON 请求体
 	r.PUT("honey_port", middleware.BindJsonMiddleware[honey_port_api.UpdateRequest], app.UpdateView)
 
+	// 主机模板同步（POST），将模板的端口配置重新下发到所有关联的诱捕IP，绑定 JSON 请求体
+	r.POST("honey_port/sync_template", middleware.BindJsonMiddleware[honey_port_api.SyncTemplateRequest], app.SyncTemplateView)
+
 	// 诱捕转发列表（GET），绑定 Query 参数
 	r.GET("honey_port", middleware.BindQueryMiddleware[honey_port_api.ListRequest], app.ListView)
 }
@@ -0,0 +1,23 @@
+package routers
+
+// File: routers/interaction_routers.go
+// Description: 模拟器交互会话路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/interaction_api"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func InteractionRouters(r *gin.RouterGroup) {
+	var app = api.App.InteractionApi
+
+	// 交互会话列表（GET），绑定 Query 参数
+	r.GET("interaction", middleware.BindQueryMiddleware[interaction_api.ListRequest], app.ListView)
+
+	// 交互会话详情（GET），绑定 URI 参数
+	r.GET("interaction/:id", middleware.BindUriMiddleware[models.IDRequest], app.DetailView)
+}
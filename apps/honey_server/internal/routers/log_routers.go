@@ -18,14 +18,12 @@ func LogRouters(r *gin.RouterGroup) {
 
 	// 日志列表：GET + Query 绑定
 	r.GET("logs",
-		middleware.AdminMiddleware,                             // 管理员验证
 		middleware.BindQueryMiddleware[log_api.LogListRequest], // 绑定查询参数
-		app.LogListView,                                        // 处理请求
+		app.LogListView, // 处理请求
 	)
 
 	// 删除日志：DELETE + JSON 参数绑定
 	r.DELETE("logs",
-		middleware.AdminMiddleware,                          // 管理员验证
 		middleware.BindJsonMiddleware[models.IDListRequest], // 绑定 JSON ID 列表
 		app.RemoveView, // 处理请求
 	)
@@ -27,6 +27,9 @@ func NodeRouters(r *gin.RouterGroup) {
 	// 节点选项（GET）
 	r.GET("node/options", app.OptionsView)
 
+	// 节点远程诊断（POST），绑定 URI 与 JSON 参数
+	r.POST("node/:id/diagnose", middleware.BindUriJsonMiddleware[node_api.DiagnoseRequest], app.DiagnoseView)
+
 	// 节点删除（DELETE），绑定 URI 参数
 	r.DELETE("node/:id", middleware.BindUriMiddleware[models.IDRequest], app.RemoveView)
 }
@@ -0,0 +1,23 @@
+package routers
+
+// File: routers/outbox_routers.go
+// Description: 消息发件箱路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/outbox_api"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func OutboxRouters(r *gin.RouterGroup) {
+	var app = api.App.OutboxApi
+
+	// 发件箱消息列表（GET），仅管理员可查看，绑定 Query 参数
+	r.GET("outbox", middleware.BindQueryMiddleware[outbox_api.ListRequest], app.ListView)
+
+	// 发送失败消息重试（POST），仅管理员可操作，绑定 JSON 请求体
+	r.POST("outbox/retry", middleware.BindJsonMiddleware[models.IDListRequest], app.RetryView)
+}
@@ -0,0 +1,116 @@
+package routers
+
+// File: routers/permission.go
+// Description: 路由权限表，登记每个路由所需的权限，空字符串表示登录即可访问。
+// 新增路由时需要在此登记，未登记的路由一律拒绝访问
+
+var routePermissionMap = map[string]string{
+	// 用户
+	"POST /honey_server/logout":                    "",
+	"GET /honey_server/users/info":                 "",
+	"GET /honey_server/users/sessions":             "", // 操作其他用户的会话需要 user:write，在接口中校验
+	"DELETE /honey_server/users/sessions":          "",
+	"GET /honey_server/users":                      "user:read",
+	"POST /honey_server/users":                     "user:write",
+	"DELETE /honey_server/users":                   "user:write",
+	"PUT /honey_server/users/role":                 "user:write",
+	"GET /honey_server/users/lockout":              "user:read",
+	"DELETE /honey_server/users/lockout":           "user:write",
+	"POST /honey_server/users/totp/enroll":         "",
+	"POST /honey_server/users/totp/enable":         "",
+	"POST /honey_server/users/totp/disable":        "",
+	"POST /honey_server/users/totp/recovery_codes": "",
+	"PUT /honey_server/users/password":             "",
+	"PUT /honey_server/users/password/reset":       "user:write",
+	"PUT /honey_server/users/profile":              "",
+	"DELETE /honey_server/users/totp":              "user:write",
+
+	// API密钥，仅需登录，操作其他用户的API密钥需要用户管理权限，在接口中校验
+	"GET /honey_server/api_key":    "",
+	"POST /honey_server/api_key":   "",
+	"DELETE /honey_server/api_key": "",
+
+	// 角色
+	"GET /honey_server/role":             "role:read",
+	"GET /honey_server/role/options":     "role:read",
+	"GET /honey_server/role/permissions": "role:read",
+	"POST /honey_server/role":            "role:write",
+	"PUT /honey_server/role":             "role:write",
+	"DELETE /honey_server/role":          "role:write",
+
+	// 日志
+	"GET /honey_server/logs":    "log:read",
+	"DELETE /honey_server/logs": "log:delete",
+
+	// 消息
+	"GET /honey_server/outbox":              "mq:read",
+	"POST /honey_server/outbox/retry":       "mq:write",
+	"GET /honey_server/dead_letter":         "mq:read",
+	"POST /honey_server/dead_letter/replay": "mq:write",
+	"POST /honey_server/dead_letter/ignore": "mq:write",
+
+	// 节点
+	"GET /honey_server/node":                "node:read",
+	"GET /honey_server/node/:id":            "node:read",
+	"GET /honey_server/node/options":        "node:read",
+	"PUT /honey_server/node":                "node:write",
+	"POST /honey_server/node/:id/diagnose":  "node:write",
+	"DELETE /honey_server/node/:id":         "node:write",
+	"GET /honey_server/node_network":        "node:read",
+	"GET /honey_server/node_network/flush":  "node:write",
+	"PUT /honey_server/node_network":        "node:write",
+	"PUT /honey_server/node_network/enable": "node:write",
+	"DELETE /honey_server/node_network/:id": "node:write",
+
+	// 网络
+	"GET /honey_server/net":         "net:read",
+	"GET /honey_server/net/options": "net:read",
+	"GET /honey_server/net/:id":     "net:read",
+	"GET /honey_server/net/ip_list": "net:read",
+	"PUT /honey_server/net":         "net:write",
+	"DELETE /honey_server/net":      "net:write",
+	"POST /honey_server/net/scan":   "net:scan",
+	"GET /honey_server/host":        "host:read",
+	"DELETE /honey_server/host":     "host:delete",
+
+	// 诱捕IP与端口
+	"GET /honey_server/honey_ip":                  "honey_ip:read",
+	"POST /honey_server/honey_ip":                 "honey_ip:create",
+	"POST /honey_server/honey_ip/bulk":            "honey_ip:create",
+	"POST /honey_server/honey_ip/retry":           "honey_ip:create",
+	"DELETE /honey_server/honey_ip":               "honey_ip:delete",
+	"GET /honey_server/honey_port":                "honey_port:read",
+	"PUT /honey_server/honey_port":                "honey_port:write",
+	"POST /honey_server/honey_port/sync_template": "honey_port:write",
+
+	// 攻击分析
+	"GET /honey_server/interaction":             "interaction:read",
+	"GET /honey_server/interaction/:id":         "interaction:read",
+	"GET /honey_server/credential":              "credential:read",
+	"GET /honey_server/credential/top_username": "credential:read",
+	"GET /honey_server/credential/top_password": "credential:read",
+	"GET /honey_server/credential/top_pair":     "credential:read",
+	"GET /honey_server/credential/attacker":     "credential:read",
+	"GET /honey_server/transcript":              "transcript:read",
+	"GET /honey_server/transcript/download":     "transcript:read",
+	"GET /honey_server/transcript/live":         "transcript:read",
+	"POST /honey_server/transcript/live/ticket": "transcript:read",
+	"GET /honey_server/transcript/replay/:id":   "transcript:read",
+	"DELETE /honey_server/transcript":           "transcript:delete",
+	"GET /honey_server/event/stream":            "event:read",
+	"POST /honey_server/event/ticket":           "event:read",
+
+	// 告警
+	"GET /honey_server/alert":               "alert:read",
+	"PUT /honey_server/alert/ack":           "alert:handle",
+	"PUT /honey_server/alert/resolve":       "alert:handle",
+	"GET /honey_server/alert_rule":          "alert_rule:read",
+	"POST /honey_server/alert_rule":         "alert_rule:write",
+	"PUT /honey_server/alert_rule":          "alert_rule:write",
+	"DELETE /honey_server/alert_rule":       "alert_rule:write",
+	"GET /honey_server/alert_channel":       "alert_channel:read",
+	"POST /honey_server/alert_channel":      "alert_channel:write",
+	"PUT /honey_server/alert_channel":       "alert_channel:write",
+	"DELETE /honey_server/alert_channel":    "alert_channel:write",
+	"POST /honey_server/alert_channel/test": "alert_channel:write",
+}
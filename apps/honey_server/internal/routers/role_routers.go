@@ -0,0 +1,35 @@
+package routers
+
+// File: routers/role_routers.go
+// Description: 角色路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/role_api"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RoleRouters(r *gin.RouterGroup) {
+	var app = api.App.RoleApi
+
+	// 角色列表（GET），绑定 Query 参数
+	r.GET("role", middleware.BindQueryMiddleware[role_api.ListRequest], app.ListView)
+
+	// 角色选项（GET）
+	r.GET("role/options", app.OptionsView)
+
+	// 权限清单（GET）
+	r.GET("role/permissions", app.PermissionListView)
+
+	// 创建角色（POST），绑定 JSON 参数
+	r.POST("role", middleware.BindJsonMiddleware[role_api.RoleRequest], app.CreateView)
+
+	// 更新角色（PUT），绑定 JSON 参数
+	r.PUT("role", middleware.BindJsonMiddleware[role_api.UpdateRequest], app.UpdateView)
+
+	// 删除角色（DELETE），绑定 JSON 参数
+	r.DELETE("role", middleware.BindJsonMiddleware[models.IDListRequest], app.RemoveView)
+}
@@ -0,0 +1,35 @@
+package routers
+
+// File: routers/transcript_routers.go
+// Description: 会话录制路由
+
+import (
+	"honey_server/internal/api"
+	"honey_server/internal/api/transcript_api"
+	"honey_server/internal/middleware"
+	"honey_server/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TranscriptRouters(r *gin.RouterGroup) {
+	var app = api.App.TranscriptApi
+
+	// 会话录制列表（GET），绑定 Query 参数
+	r.GET("transcript", middleware.BindQueryMiddleware[transcript_api.ListRequest], app.ListView)
+
+	// 会话录制下载（GET），绑定 Query 参数
+	r.GET("transcript/download", middleware.BindQueryMiddleware[transcript_api.DownloadRequest], app.DownloadView)
+
+	// 会话实时查看（GET，WebSocket），绑定 Query 参数
+	r.GET("transcript/live", middleware.BindQueryMiddleware[transcript_api.LiveRequest], app.LiveView)
+
+	// 签发会话实时查看的一次性票据（POST）
+	r.POST("transcript/live/ticket", app.LiveTicketView)
+
+	// 会话录制回放（GET），绑定 URI 参数
+	r.GET("transcript/replay/:id", middleware.BindUriMiddleware[models.IDRequest], app.ReplayView)
+
+	// 删除会话录制（DELETE），绑定 JSON 参数
+	r.DELETE("transcript", middleware.BindJsonMiddleware[models.IDListRequest], app.RemoveView)
+}
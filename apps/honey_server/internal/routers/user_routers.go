@@ -18,8 +18,35 @@ func UserRouters(r *gin.RouterGroup) {
 	// 用户登录（POST），绑定 JSON 请求体
 	r.POST("login", middleware.BindJsonMiddleware[user_api.LoginRequest], app.LoginView)
 
+	// 两步验证登录（POST），绑定 JSON 请求体
+	r.POST("login/totp", middleware.BindJsonMiddleware[user_api.TotpLoginRequest], app.TotpLoginView)
+
+	// 登录时获取两步验证密钥（POST），绑定 JSON 请求体
+	r.POST("login/totp/enroll", middleware.BindJsonMiddleware[user_api.TotpEnrollLoginRequest], app.TotpEnrollLoginView)
+
+	// 单点登录配置（GET）
+	r.GET("oidc/info", app.OidcInfoView)
+
+	// 跳转身份提供方登录（GET）
+	r.GET("oidc/login", app.OidcLoginView)
+
+	// 身份提供方回调（GET），绑定 Query 参数
+	r.GET("oidc/callback", middleware.BindQueryMiddleware[user_api.OidcCallbackRequest], app.OidcCallbackView)
+
+	// 使用单点登录票据换取token（POST），绑定 JSON 请求体
+	r.POST("oidc/token", middleware.BindJsonMiddleware[user_api.OidcTokenRequest], app.OidcTokenView)
+
+	// 登录时强制修改密码（POST），绑定 JSON 请求体
+	r.POST("login/password", middleware.BindJsonMiddleware[user_api.PasswordLoginRequest], app.PasswordLoginView)
+
+	// 内部服务校验会话（GET），自行校验请求携带的凭据
+	r.GET("introspect", app.IntrospectView)
+
+	// 刷新token（POST），绑定 JSON 请求体
+	r.POST("refresh", middleware.BindJsonMiddleware[user_api.RefreshRequest], app.RefreshView)
+
 	// 创建用户（POST），管理员权限 + JSON 请求体绑定
-	r.POST("users", middleware.AdminMiddleware, middleware.BindJsonMiddleware[user_api.CreateRequest], app.CreateView)
+	r.POST("users", middleware.BindJsonMiddleware[user_api.CreateRequest], app.CreateView)
 
 	// 用户列表查询（GET），绑定 Query 参数
 	r.GET("users", middleware.BindQueryMiddleware[user_api.UserListRequest], app.UserListView)
@@ -30,6 +57,45 @@ func UserRouters(r *gin.RouterGroup) {
 	// 删除用户（DELETE），绑定 JSON 请求体
 	r.DELETE("users", middleware.BindJsonMiddleware[user_api.UserRemoveRequest], app.UserRemoveView)
 
+	// 分配用户角色（PUT），绑定 JSON 请求体
+	r.PUT("users/role", middleware.BindJsonMiddleware[user_api.UserRoleRequest], app.UserRoleView)
+
+	// 登录锁定列表（GET）
+	r.GET("users/lockout", app.LockoutListView)
+
+	// 解除登录锁定（DELETE），绑定 JSON 请求体
+	r.DELETE("users/lockout", middleware.BindJsonMiddleware[user_api.UnlockRequest], app.UnlockView)
+
+	// 获取两步验证密钥（POST）
+	r.POST("users/totp/enroll", app.TotpEnrollView)
+
+	// 启用两步验证（POST），绑定 JSON 请求体
+	r.POST("users/totp/enable", middleware.BindJsonMiddleware[user_api.TotpCodeRequest], app.TotpEnableView)
+
+	// 停用两步验证（POST），绑定 JSON 请求体
+	r.POST("users/totp/disable", middleware.BindJsonMiddleware[user_api.TotpCodeRequest], app.TotpDisableView)
+
+	// 重新生成恢复码（POST），绑定 JSON 请求体
+	r.POST("users/totp/recovery_codes", middleware.BindJsonMiddleware[user_api.TotpCodeRequest], app.TotpRecoveryCodesView)
+
+	// 重置用户的两步验证（DELETE），绑定 JSON 请求体
+	r.DELETE("users/totp", middleware.BindJsonMiddleware[user_api.TotpResetRequest], app.TotpResetView)
+
+	// 修改自己的密码（PUT），绑定 JSON 请求体
+	r.PUT("users/password", middleware.BindJsonMiddleware[user_api.PasswordChangeRequest], app.PasswordChangeView)
+
+	// 重置用户密码（PUT），绑定 JSON 请求体
+	r.PUT("users/password/reset", middleware.BindJsonMiddleware[user_api.PasswordResetRequest], app.PasswordResetView)
+
+	// 修改个人资料（PUT），绑定 JSON 请求体
+	r.PUT("users/profile", middleware.BindJsonMiddleware[user_api.ProfileUpdateRequest], app.ProfileUpdateView)
+
 	// 获取用户信息（GET）
 	r.GET("users/info", app.UserInfoView)
+
+	// 用户登录会话列表（GET），绑定 Query 参数
+	r.GET("users/sessions", middleware.BindQueryMiddleware[user_api.SessionListRequest], app.SessionListView)
+
+	// 吊销用户登录会话（DELETE），绑定 JSON 请求体
+	r.DELETE("users/sessions", middleware.BindJsonMiddleware[user_api.SessionRevokeRequest], app.SessionRevokeView)
 }
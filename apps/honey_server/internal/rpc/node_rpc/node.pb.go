@@ -28,6 +28,9 @@ const (
 	CmdType_cmdNetworkFlushType CmdType = 0
 	CmdType_cmdNetScanType      CmdType = 1
 	CmdType_cmdNodeRemoveType   CmdType = 2
+	CmdType_cmdDiagnoseType     CmdType = 3
+	CmdType_cmdBusMessageType   CmdType = 4 // 业务消息投递（gRPC消息总线模式）
+	CmdType_cmdIpStatusType     CmdType = 5 // 查询诱捕IP网卡的实际状态
 )
 
 // Enum value maps for CmdType.
@@ -36,11 +39,17 @@ var (
 		0: "cmdNetworkFlushType",
 		1: "cmdNetScanType",
 		2: "cmdNodeRemoveType",
+		3: "cmdDiagnoseType",
+		4: "cmdBusMessageType",
+		5: "cmdIpStatusType",
 	}
 	CmdType_value = map[string]int32{
 		"cmdNetworkFlushType": 0,
 		"cmdNetScanType":      1,
 		"cmdNodeRemoveType":   2,
+		"cmdDiagnoseType":     3,
+		"cmdBusMessageType":   4,
+		"cmdIpStatusType":     5,
 	}
 )
 
@@ -71,6 +80,68 @@ func (CmdType) EnumDescriptor() ([]byte, []int) {
 	return file_internal_rpc_node_proto_rawDescGZIP(), []int{0}
 }
 
+// 诊断类型枚举（白名单，节点只执行这里列出的诊断项）
+type DiagnoseType int32
+
+const (
+	DiagnoseType_diagnoseArpingType     DiagnoseType = 0 // arping探测指定IP
+	DiagnoseType_diagnoseTcpConnectType DiagnoseType = 1 // TCP连接测试
+	DiagnoseType_diagnoseLinkListType   DiagnoseType = 2 // 网卡链路列表
+	DiagnoseType_diagnoseAddrListType   DiagnoseType = 3 // 网卡地址列表
+	DiagnoseType_diagnoseListenListType DiagnoseType = 4 // 监听端口列表
+	DiagnoseType_diagnoseNeighListType  DiagnoseType = 5 // ARP/邻居表
+	DiagnoseType_diagnoseRouteListType  DiagnoseType = 6 // 路由表
+)
+
+// Enum value maps for DiagnoseType.
+var (
+	DiagnoseType_name = map[int32]string{
+		0: "diagnoseArpingType",
+		1: "diagnoseTcpConnectType",
+		2: "diagnoseLinkListType",
+		3: "diagnoseAddrListType",
+		4: "diagnoseListenListType",
+		5: "diagnoseNeighListType",
+		6: "diagnoseRouteListType",
+	}
+	DiagnoseType_value = map[string]int32{
+		"diagnoseArpingType":     0,
+		"diagnoseTcpConnectType": 1,
+		"diagnoseLinkListType":   2,
+		"diagnoseAddrListType":   3,
+		"diagnoseListenListType": 4,
+		"diagnoseNeighListType":  5,
+		"diagnoseRouteListType":  6,
+	}
+)
+
+func (x DiagnoseType) Enum() *DiagnoseType {
+	p := new(DiagnoseType)
+	*p = x
+	return p
+}
+
+func (x DiagnoseType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiagnoseType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_rpc_node_proto_enumTypes[1].Descriptor()
+}
+
+func (DiagnoseType) Type() protoreflect.EnumType {
+	return &file_internal_rpc_node_proto_enumTypes[1]
+}
+
+func (x DiagnoseType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiagnoseType.Descriptor instead.
+func (DiagnoseType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{1}
+}
+
 // 定义响应结构体
 type BaseResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -533,6 +604,9 @@ type CmdRequest struct {
 	NetworkFlushInMessage *NetworkFlushInMessage `protobuf:"bytes,3,opt,name=NetworkFlushInMessage,proto3" json:"NetworkFlushInMessage,omitempty"`
 	NetScanInMessage      *NetScanInMessage      `protobuf:"bytes,4,opt,name=NetScanInMessage,proto3" json:"NetScanInMessage,omitempty"`
 	NodeRemoveInMessage   *NodeRemoveInMessage   `protobuf:"bytes,5,opt,name=NodeRemoveInMessage,proto3" json:"NodeRemoveInMessage,omitempty"`
+	DiagnoseInMessage     *DiagnoseInMessage     `protobuf:"bytes,6,opt,name=DiagnoseInMessage,proto3" json:"DiagnoseInMessage,omitempty"`
+	BusInMessage          *BusInMessage          `protobuf:"bytes,7,opt,name=BusInMessage,proto3" json:"BusInMessage,omitempty"`
+	IpStatusInMessage     *IpStatusInMessage     `protobuf:"bytes,8,opt,name=IpStatusInMessage,proto3" json:"IpStatusInMessage,omitempty"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -602,6 +676,27 @@ func (x *CmdRequest) GetNodeRemoveInMessage() *NodeRemoveInMessage {
 	return nil
 }
 
+func (x *CmdRequest) GetDiagnoseInMessage() *DiagnoseInMessage {
+	if x != nil {
+		return x.DiagnoseInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetBusInMessage() *BusInMessage {
+	if x != nil {
+		return x.BusInMessage
+	}
+	return nil
+}
+
+func (x *CmdRequest) GetIpStatusInMessage() *IpStatusInMessage {
+	if x != nil {
+		return x.IpStatusInMessage
+	}
+	return nil
+}
+
 // 网络刷新请求消息
 type NetworkFlushInMessage struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -753,6 +848,181 @@ func (*NodeRemoveInMessage) Descriptor() ([]byte, []int) {
 	return file_internal_rpc_node_proto_rawDescGZIP(), []int{9}
 }
 
+// 远程诊断请求消息
+type DiagnoseInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiagnoseType  DiagnoseType           `protobuf:"varint,1,opt,name=diagnoseType,proto3,enum=node_rpc.DiagnoseType" json:"diagnoseType,omitempty"` // 诊断类型
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`                                                 // 目标IP（arping、TCP连接测试使用）
+	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`                                            // 目标端口（TCP连接测试使用）
+	Network       string                 `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`                                       // 网卡名称（为空时不限制网卡）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnoseInMessage) Reset() {
+	*x = DiagnoseInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnoseInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnoseInMessage) ProtoMessage() {}
+
+func (x *DiagnoseInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnoseInMessage.ProtoReflect.Descriptor instead.
+func (*DiagnoseInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DiagnoseInMessage) GetDiagnoseType() DiagnoseType {
+	if x != nil {
+		return x.DiagnoseType
+	}
+	return DiagnoseType_diagnoseArpingType
+}
+
+func (x *DiagnoseInMessage) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *DiagnoseInMessage) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *DiagnoseInMessage) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+// 业务消息投递请求消息（处理结果通过CmdResponse的code、errorMsg返回）
+type BusInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageID     string                 `protobuf:"bytes,1,opt,name=messageID,proto3" json:"messageID,omitempty"` // 消息ID，供节点幂等处理
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`         // 消息主题（与RabbitMQ模式下的交换器名称一致）
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`           // 消息体
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BusInMessage) Reset() {
+	*x = BusInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BusInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BusInMessage) ProtoMessage() {}
+
+func (x *BusInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BusInMessage.ProtoReflect.Descriptor instead.
+func (*BusInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BusInMessage) GetMessageID() string {
+	if x != nil {
+		return x.MessageID
+	}
+	return ""
+}
+
+func (x *BusInMessage) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *BusInMessage) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// 诱捕IP网卡状态查询请求消息
+type IpStatusInMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LinkNameList  []string               `protobuf:"bytes,1,rep,name=linkNameList,proto3" json:"linkNameList,omitempty"` // 待查询的网卡名称列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IpStatusInMessage) Reset() {
+	*x = IpStatusInMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IpStatusInMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IpStatusInMessage) ProtoMessage() {}
+
+func (x *IpStatusInMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IpStatusInMessage.ProtoReflect.Descriptor instead.
+func (*IpStatusInMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IpStatusInMessage) GetLinkNameList() []string {
+	if x != nil {
+		return x.LinkNameList
+	}
+	return nil
+}
+
 // 网卡刷新响应消息
 type NetworkFlushOutMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -763,7 +1033,7 @@ type NetworkFlushOutMessage struct {
 
 func (x *NetworkFlushOutMessage) Reset() {
 	*x = NetworkFlushOutMessage{}
-	mi := &file_internal_rpc_node_proto_msgTypes[10]
+	mi := &file_internal_rpc_node_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -775,7 +1045,7 @@ func (x *NetworkFlushOutMessage) String() string {
 func (*NetworkFlushOutMessage) ProtoMessage() {}
 
 func (x *NetworkFlushOutMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[10]
+	mi := &file_internal_rpc_node_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +1058,7 @@ func (x *NetworkFlushOutMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkFlushOutMessage.ProtoReflect.Descriptor instead.
 func (*NetworkFlushOutMessage) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{10}
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *NetworkFlushOutMessage) GetNetworkList() []*NetworkInfoMessage {
@@ -814,7 +1084,7 @@ type NetScanOutMessage struct {
 
 func (x *NetScanOutMessage) Reset() {
 	*x = NetScanOutMessage{}
-	mi := &file_internal_rpc_node_proto_msgTypes[11]
+	mi := &file_internal_rpc_node_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -826,7 +1096,7 @@ func (x *NetScanOutMessage) String() string {
 func (*NetScanOutMessage) ProtoMessage() {}
 
 func (x *NetScanOutMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[11]
+	mi := &file_internal_rpc_node_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,7 +1109,7 @@ func (x *NetScanOutMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetScanOutMessage.ProtoReflect.Descriptor instead.
 func (*NetScanOutMessage) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{11}
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *NetScanOutMessage) GetEnd() bool {
@@ -900,7 +1170,7 @@ type NodeRemoveOutMessage struct {
 
 func (x *NodeRemoveOutMessage) Reset() {
 	*x = NodeRemoveOutMessage{}
-	mi := &file_internal_rpc_node_proto_msgTypes[12]
+	mi := &file_internal_rpc_node_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -912,7 +1182,7 @@ func (x *NodeRemoveOutMessage) String() string {
 func (*NodeRemoveOutMessage) ProtoMessage() {}
 
 func (x *NodeRemoveOutMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[12]
+	mi := &file_internal_rpc_node_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,39 +1195,34 @@ func (x *NodeRemoveOutMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeRemoveOutMessage.ProtoReflect.Descriptor instead.
 func (*NodeRemoveOutMessage) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{12}
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{15}
 }
 
-// 命令响应结构体
-type CmdResponse struct {
-	state                  protoimpl.MessageState  `protogen:"open.v1"`
-	CmdType                CmdType                 `protobuf:"varint,1,opt,name=cmdType,proto3,enum=node_rpc.CmdType" json:"cmdType,omitempty"`
-	TaskID                 string                  `protobuf:"bytes,2,opt,name=taskID,proto3" json:"taskID,omitempty"`
-	NodeID                 string                  `protobuf:"bytes,3,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
-	Code                   int32                   `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
-	ErrorMsg               string                  `protobuf:"bytes,5,opt,name=errorMsg,proto3" json:"errorMsg,omitempty"`
-	NetworkFlushOutMessage *NetworkFlushOutMessage `protobuf:"bytes,6,opt,name=NetworkFlushOutMessage,proto3" json:"NetworkFlushOutMessage,omitempty"`
-	NetScanOutMessage      *NetScanOutMessage      `protobuf:"bytes,7,opt,name=NetScanOutMessage,proto3" json:"NetScanOutMessage,omitempty"`
-	NodeRemoveOutMessage   *NodeRemoveOutMessage   `protobuf:"bytes,8,opt,name=NodeRemoveOutMessage,proto3" json:"NodeRemoveOutMessage,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+// 远程诊断响应消息
+type DiagnoseOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	End           bool                   `protobuf:"varint,1,opt,name=end,proto3" json:"end,omitempty"`      // 是否结束
+	Output        string                 `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"` // 诊断输出（按行分批返回）
+	ErrMsg        string                 `protobuf:"bytes,3,opt,name=errMsg,proto3" json:"errMsg,omitempty"` // 错误信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CmdResponse) Reset() {
-	*x = CmdResponse{}
-	mi := &file_internal_rpc_node_proto_msgTypes[13]
+func (x *DiagnoseOutMessage) Reset() {
+	*x = DiagnoseOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CmdResponse) String() string {
+func (x *DiagnoseOutMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CmdResponse) ProtoMessage() {}
+func (*DiagnoseOutMessage) ProtoMessage() {}
 
-func (x *CmdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[13]
+func (x *DiagnoseOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -968,67 +1233,263 @@ func (x *CmdResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CmdResponse.ProtoReflect.Descriptor instead.
-func (*CmdResponse) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use DiagnoseOutMessage.ProtoReflect.Descriptor instead.
+func (*DiagnoseOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CmdResponse) GetCmdType() CmdType {
+func (x *DiagnoseOutMessage) GetEnd() bool {
 	if x != nil {
-		return x.CmdType
+		return x.End
 	}
-	return CmdType_cmdNetworkFlushType
+	return false
 }
 
-func (x *CmdResponse) GetTaskID() string {
+func (x *DiagnoseOutMessage) GetOutput() string {
 	if x != nil {
-		return x.TaskID
+		return x.Output
 	}
 	return ""
 }
 
-func (x *CmdResponse) GetNodeID() string {
+func (x *DiagnoseOutMessage) GetErrMsg() string {
 	if x != nil {
-		return x.NodeID
+		return x.ErrMsg
 	}
 	return ""
 }
 
-func (x *CmdResponse) GetCode() int32 {
-	if x != nil {
-		return x.Code
-	}
-	return 0
+// 诱捕IP网卡状态响应消息
+type IpStatusOutMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LinkList      []*IpLinkStatusMessage `protobuf:"bytes,1,rep,name=linkList,proto3" json:"linkList,omitempty"` // 网卡状态列表（与请求顺序一致）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CmdResponse) GetErrorMsg() string {
-	if x != nil {
-		return x.ErrorMsg
-	}
-	return ""
+func (x *IpStatusOutMessage) Reset() {
+	*x = IpStatusOutMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CmdResponse) GetNetworkFlushOutMessage() *NetworkFlushOutMessage {
+func (x *IpStatusOutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IpStatusOutMessage) ProtoMessage() {}
+
+func (x *IpStatusOutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[17]
 	if x != nil {
-		return x.NetworkFlushOutMessage
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CmdResponse) GetNetScanOutMessage() *NetScanOutMessage {
+// Deprecated: Use IpStatusOutMessage.ProtoReflect.Descriptor instead.
+func (*IpStatusOutMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *IpStatusOutMessage) GetLinkList() []*IpLinkStatusMessage {
 	if x != nil {
-		return x.NetScanOutMessage
+		return x.LinkList
 	}
 	return nil
 }
 
-func (x *CmdResponse) GetNodeRemoveOutMessage() *NodeRemoveOutMessage {
-	if x != nil {
+// 单个网卡的实际状态
+type IpLinkStatusMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LinkName      string                 `protobuf:"bytes,1,opt,name=linkName,proto3" json:"linkName,omitempty"` // 网卡名称
+	Exists        bool                   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`    // 网卡是否存在
+	Mac           string                 `protobuf:"bytes,3,opt,name=mac,proto3" json:"mac,omitempty"`           // MAC地址
+	AddrList      []string               `protobuf:"bytes,4,rep,name=addrList,proto3" json:"addrList,omitempty"` // 网卡上配置的地址列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IpLinkStatusMessage) Reset() {
+	*x = IpLinkStatusMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IpLinkStatusMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IpLinkStatusMessage) ProtoMessage() {}
+
+func (x *IpLinkStatusMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IpLinkStatusMessage.ProtoReflect.Descriptor instead.
+func (*IpLinkStatusMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *IpLinkStatusMessage) GetLinkName() string {
+	if x != nil {
+		return x.LinkName
+	}
+	return ""
+}
+
+func (x *IpLinkStatusMessage) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *IpLinkStatusMessage) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *IpLinkStatusMessage) GetAddrList() []string {
+	if x != nil {
+		return x.AddrList
+	}
+	return nil
+}
+
+// 命令响应结构体
+type CmdResponse struct {
+	state                  protoimpl.MessageState  `protogen:"open.v1"`
+	CmdType                CmdType                 `protobuf:"varint,1,opt,name=cmdType,proto3,enum=node_rpc.CmdType" json:"cmdType,omitempty"`
+	TaskID                 string                  `protobuf:"bytes,2,opt,name=taskID,proto3" json:"taskID,omitempty"`
+	NodeID                 string                  `protobuf:"bytes,3,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Code                   int32                   `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	ErrorMsg               string                  `protobuf:"bytes,5,opt,name=errorMsg,proto3" json:"errorMsg,omitempty"`
+	NetworkFlushOutMessage *NetworkFlushOutMessage `protobuf:"bytes,6,opt,name=NetworkFlushOutMessage,proto3" json:"NetworkFlushOutMessage,omitempty"`
+	NetScanOutMessage      *NetScanOutMessage      `protobuf:"bytes,7,opt,name=NetScanOutMessage,proto3" json:"NetScanOutMessage,omitempty"`
+	NodeRemoveOutMessage   *NodeRemoveOutMessage   `protobuf:"bytes,8,opt,name=NodeRemoveOutMessage,proto3" json:"NodeRemoveOutMessage,omitempty"`
+	DiagnoseOutMessage     *DiagnoseOutMessage     `protobuf:"bytes,9,opt,name=DiagnoseOutMessage,proto3" json:"DiagnoseOutMessage,omitempty"`
+	IpStatusOutMessage     *IpStatusOutMessage     `protobuf:"bytes,10,opt,name=IpStatusOutMessage,proto3" json:"IpStatusOutMessage,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CmdResponse) Reset() {
+	*x = CmdResponse{}
+	mi := &file_internal_rpc_node_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CmdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdResponse) ProtoMessage() {}
+
+func (x *CmdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdResponse.ProtoReflect.Descriptor instead.
+func (*CmdResponse) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CmdResponse) GetCmdType() CmdType {
+	if x != nil {
+		return x.CmdType
+	}
+	return CmdType_cmdNetworkFlushType
+}
+
+func (x *CmdResponse) GetTaskID() string {
+	if x != nil {
+		return x.TaskID
+	}
+	return ""
+}
+
+func (x *CmdResponse) GetNodeID() string {
+	if x != nil {
+		return x.NodeID
+	}
+	return ""
+}
+
+func (x *CmdResponse) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *CmdResponse) GetErrorMsg() string {
+	if x != nil {
+		return x.ErrorMsg
+	}
+	return ""
+}
+
+func (x *CmdResponse) GetNetworkFlushOutMessage() *NetworkFlushOutMessage {
+	if x != nil {
+		return x.NetworkFlushOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetNetScanOutMessage() *NetScanOutMessage {
+	if x != nil {
+		return x.NetScanOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetNodeRemoveOutMessage() *NodeRemoveOutMessage {
+	if x != nil {
 		return x.NodeRemoveOutMessage
 	}
 	return nil
 }
 
+func (x *CmdResponse) GetDiagnoseOutMessage() *DiagnoseOutMessage {
+	if x != nil {
+		return x.DiagnoseOutMessage
+	}
+	return nil
+}
+
+func (x *CmdResponse) GetIpStatusOutMessage() *IpStatusOutMessage {
+	if x != nil {
+		return x.IpStatusOutMessage
+	}
+	return nil
+}
+
 // 节点创建IP状态上报请求
 type StatusCreateIPRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1042,7 +1503,7 @@ type StatusCreateIPRequest struct {
 
 func (x *StatusCreateIPRequest) Reset() {
 	*x = StatusCreateIPRequest{}
-	mi := &file_internal_rpc_node_proto_msgTypes[14]
+	mi := &file_internal_rpc_node_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1054,7 +1515,7 @@ func (x *StatusCreateIPRequest) String() string {
 func (*StatusCreateIPRequest) ProtoMessage() {}
 
 func (x *StatusCreateIPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[14]
+	mi := &file_internal_rpc_node_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1067,7 +1528,7 @@ func (x *StatusCreateIPRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusCreateIPRequest.ProtoReflect.Descriptor instead.
 func (*StatusCreateIPRequest) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{14}
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *StatusCreateIPRequest) GetHoneyIPID() uint32 {
@@ -1108,7 +1569,7 @@ type StatusDeleteIPRequest struct {
 
 func (x *StatusDeleteIPRequest) Reset() {
 	*x = StatusDeleteIPRequest{}
-	mi := &file_internal_rpc_node_proto_msgTypes[15]
+	mi := &file_internal_rpc_node_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1120,7 +1581,7 @@ func (x *StatusDeleteIPRequest) String() string {
 func (*StatusDeleteIPRequest) ProtoMessage() {}
 
 func (x *StatusDeleteIPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[15]
+	mi := &file_internal_rpc_node_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1133,7 +1594,7 @@ func (x *StatusDeleteIPRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusDeleteIPRequest.ProtoReflect.Descriptor instead.
 func (*StatusDeleteIPRequest) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{15}
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *StatusDeleteIPRequest) GetHoneyIPIDList() []uint32 {
@@ -1143,18 +1604,367 @@ func (x *StatusDeleteIPRequest) GetHoneyIPIDList() []uint32 {
 	return nil
 }
 
+// 节点端口绑定状态上报请求
+type StatusBindPortRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	NodeUid       string                   `protobuf:"bytes,1,opt,name=nodeUid,proto3" json:"nodeUid,omitempty"`   // 节点UID
+	Ip            string                   `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`             // 诱捕IP
+	PortList      []*BindPortStatusMessage `protobuf:"bytes,3,rep,name=portList,proto3" json:"portList,omitempty"` // 端口绑定状态列表
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusBindPortRequest) Reset() {
+	*x = StatusBindPortRequest{}
+	mi := &file_internal_rpc_node_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusBindPortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusBindPortRequest) ProtoMessage() {}
+
+func (x *StatusBindPortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusBindPortRequest.ProtoReflect.Descriptor instead.
+func (*StatusBindPortRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *StatusBindPortRequest) GetNodeUid() string {
+	if x != nil {
+		return x.NodeUid
+	}
+	return ""
+}
+
+func (x *StatusBindPortRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *StatusBindPortRequest) GetPortList() []*BindPortStatusMessage {
+	if x != nil {
+		return x.PortList
+	}
+	return nil
+}
+
+// 单个端口的绑定状态
+type BindPortStatusMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Port          int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`       // 端口
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"` // 是否监听成功
+	ErrMsg        string                 `protobuf:"bytes,3,opt,name=errMsg,proto3" json:"errMsg,omitempty"`    // 失败原因
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BindPortStatusMessage) Reset() {
+	*x = BindPortStatusMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BindPortStatusMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BindPortStatusMessage) ProtoMessage() {}
+
+func (x *BindPortStatusMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BindPortStatusMessage.ProtoReflect.Descriptor instead.
+func (*BindPortStatusMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BindPortStatusMessage) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *BindPortStatusMessage) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BindPortStatusMessage) GetErrMsg() string {
+	if x != nil {
+		return x.ErrMsg
+	}
+	return ""
+}
+
+// 内置模拟器交互会话上报请求
+type ReportInteractionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	NodeUid         string                 `protobuf:"bytes,1,opt,name=nodeUid,proto3" json:"nodeUid,omitempty"`                 // 节点UID
+	InteractionList []*InteractionMessage  `protobuf:"bytes,2,rep,name=interactionList,proto3" json:"interactionList,omitempty"` // 交互会话列表
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReportInteractionRequest) Reset() {
+	*x = ReportInteractionRequest{}
+	mi := &file_internal_rpc_node_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportInteractionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportInteractionRequest) ProtoMessage() {}
+
+func (x *ReportInteractionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportInteractionRequest.ProtoReflect.Descriptor instead.
+func (*ReportInteractionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReportInteractionRequest) GetNodeUid() string {
+	if x != nil {
+		return x.NodeUid
+	}
+	return ""
+}
+
+func (x *ReportInteractionRequest) GetInteractionList() []*InteractionMessage {
+	if x != nil {
+		return x.InteractionList
+	}
+	return nil
+}
+
+// 单个交互会话（一个客户端连接）
+type InteractionMessage struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	SessionID     string                     `protobuf:"bytes,1,opt,name=sessionID,proto3" json:"sessionID,omitempty"`  // 会话ID
+	Protocol      string                     `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`    // 模拟器协议
+	SrcIP         string                     `protobuf:"bytes,3,opt,name=srcIP,proto3" json:"srcIP,omitempty"`          // 攻击者IP
+	SrcPort       int32                      `protobuf:"varint,4,opt,name=srcPort,proto3" json:"srcPort,omitempty"`     // 攻击者端口
+	DstIP         string                     `protobuf:"bytes,5,opt,name=dstIP,proto3" json:"dstIP,omitempty"`          // 诱捕IP
+	DstPort       int32                      `protobuf:"varint,6,opt,name=dstPort,proto3" json:"dstPort,omitempty"`     // 诱捕端口
+	StartTime     int64                      `protobuf:"varint,7,opt,name=startTime,proto3" json:"startTime,omitempty"` // 会话开始时间（Unix毫秒）
+	EndTime       int64                      `protobuf:"varint,8,opt,name=endTime,proto3" json:"endTime,omitempty"`     // 会话结束时间（Unix毫秒）
+	EventList     []*InteractionEventMessage `protobuf:"bytes,9,rep,name=eventList,proto3" json:"eventList,omitempty"`  // 会话内的交互事件
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InteractionMessage) Reset() {
+	*x = InteractionMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InteractionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InteractionMessage) ProtoMessage() {}
+
+func (x *InteractionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InteractionMessage.ProtoReflect.Descriptor instead.
+func (*InteractionMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *InteractionMessage) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *InteractionMessage) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *InteractionMessage) GetSrcIP() string {
+	if x != nil {
+		return x.SrcIP
+	}
+	return ""
+}
+
+func (x *InteractionMessage) GetSrcPort() int32 {
+	if x != nil {
+		return x.SrcPort
+	}
+	return 0
+}
+
+func (x *InteractionMessage) GetDstIP() string {
+	if x != nil {
+		return x.DstIP
+	}
+	return ""
+}
+
+func (x *InteractionMessage) GetDstPort() int32 {
+	if x != nil {
+		return x.DstPort
+	}
+	return 0
+}
+
+func (x *InteractionMessage) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *InteractionMessage) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *InteractionMessage) GetEventList() []*InteractionEventMessage {
+	if x != nil {
+		return x.EventList
+	}
+	return nil
+}
+
+// 单个交互事件
+type InteractionEventMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          int64                  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`          // 事件时间（Unix毫秒）
+	EventType     string                 `protobuf:"bytes,2,opt,name=eventType,proto3" json:"eventType,omitempty"` // 事件类型
+	Data          string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`           // 事件内容
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InteractionEventMessage) Reset() {
+	*x = InteractionEventMessage{}
+	mi := &file_internal_rpc_node_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InteractionEventMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InteractionEventMessage) ProtoMessage() {}
+
+func (x *InteractionEventMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_rpc_node_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InteractionEventMessage.ProtoReflect.Descriptor instead.
+func (*InteractionEventMessage) Descriptor() ([]byte, []int) {
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *InteractionEventMessage) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *InteractionEventMessage) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *InteractionEventMessage) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
 // 传输的数据块
 type TunnelData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`     // 数据块
-	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // 目标地址
+	Chunk         []byte                 `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`         // 数据块
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`     // 目标地址
+	NodeUid       string                 `protobuf:"bytes,3,opt,name=nodeUid,proto3" json:"nodeUid,omitempty"`     // 节点UID（仅初始消息携带，用于会话录制）
+	SessionID     string                 `protobuf:"bytes,4,opt,name=sessionID,proto3" json:"sessionID,omitempty"` // 会话ID（仅初始消息携带）
+	SrcAddr       string                 `protobuf:"bytes,5,opt,name=srcAddr,proto3" json:"srcAddr,omitempty"`     // 攻击者地址（仅初始消息携带）
+	LocalAddr     string                 `protobuf:"bytes,6,opt,name=localAddr,proto3" json:"localAddr,omitempty"` // 诱捕端口监听地址（仅初始消息携带）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TunnelData) Reset() {
 	*x = TunnelData{}
-	mi := &file_internal_rpc_node_proto_msgTypes[16]
+	mi := &file_internal_rpc_node_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1166,7 +1976,7 @@ func (x *TunnelData) String() string {
 func (*TunnelData) ProtoMessage() {}
 
 func (x *TunnelData) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_rpc_node_proto_msgTypes[16]
+	mi := &file_internal_rpc_node_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1179,7 +1989,7 @@ func (x *TunnelData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TunnelData.ProtoReflect.Descriptor instead.
 func (*TunnelData) Descriptor() ([]byte, []int) {
-	return file_internal_rpc_node_proto_rawDescGZIP(), []int{16}
+	return file_internal_rpc_node_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *TunnelData) GetChunk() []byte {
@@ -1196,6 +2006,34 @@ func (x *TunnelData) GetAddress() string {
 	return ""
 }
 
+func (x *TunnelData) GetNodeUid() string {
+	if x != nil {
+		return x.NodeUid
+	}
+	return ""
+}
+
+func (x *TunnelData) GetSessionID() string {
+	if x != nil {
+		return x.SessionID
+	}
+	return ""
+}
+
+func (x *TunnelData) GetSrcAddr() string {
+	if x != nil {
+		return x.SrcAddr
+	}
+	return ""
+}
+
+func (x *TunnelData) GetLocalAddr() string {
+	if x != nil {
+		return x.LocalAddr
+	}
+	return ""
+}
+
 var File_internal_rpc_node_proto protoreflect.FileDescriptor
 
 const file_internal_rpc_node_proto_rawDesc = "" +
@@ -1243,14 +2081,17 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x0e\n" +
 	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x10\n" +
 	"\x03net\x18\x03 \x01(\tR\x03net\x12\x12\n" +
-	"\x04mask\x18\x04 \x01(\x05R\x04mask\"\xc1\x02\n" +
+	"\x04mask\x18\x04 \x01(\x05R\x04mask\"\x93\x04\n" +
 	"\n" +
 	"CmdRequest\x12+\n" +
 	"\acmdType\x18\x01 \x01(\x0e2\x11.node_rpc.CmdTypeR\acmdType\x12\x16\n" +
 	"\x06taskID\x18\x02 \x01(\tR\x06taskID\x12U\n" +
 	"\x15NetworkFlushInMessage\x18\x03 \x01(\v2\x1f.node_rpc.NetworkFlushInMessageR\x15NetworkFlushInMessage\x12F\n" +
 	"\x10NetScanInMessage\x18\x04 \x01(\v2\x1a.node_rpc.NetScanInMessageR\x10NetScanInMessage\x12O\n" +
-	"\x13NodeRemoveInMessage\x18\x05 \x01(\v2\x1d.node_rpc.NodeRemoveInMessageR\x13NodeRemoveInMessage\"E\n" +
+	"\x13NodeRemoveInMessage\x18\x05 \x01(\v2\x1d.node_rpc.NodeRemoveInMessageR\x13NodeRemoveInMessage\x12I\n" +
+	"\x11DiagnoseInMessage\x18\x06 \x01(\v2\x1b.node_rpc.DiagnoseInMessageR\x11DiagnoseInMessage\x12:\n" +
+	"\fBusInMessage\x18\a \x01(\v2\x16.node_rpc.BusInMessageR\fBusInMessage\x12I\n" +
+	"\x11IpStatusInMessage\x18\b \x01(\v2\x1b.node_rpc.IpStatusInMessageR\x11IpStatusInMessage\"E\n" +
 	"\x15NetworkFlushInMessage\x12,\n" +
 	"\x11filterNetworkName\x18\x01 \x03(\tR\x11filterNetworkName\"\x80\x01\n" +
 	"\x10NetScanInMessage\x12\x18\n" +
@@ -1258,7 +2099,18 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\aipRange\x18\x02 \x01(\tR\aipRange\x12\"\n" +
 	"\ffilterIPList\x18\x03 \x03(\tR\ffilterIPList\x12\x14\n" +
 	"\x05netID\x18\x04 \x01(\rR\x05netID\"\x15\n" +
-	"\x13NodeRemoveInMessage\"X\n" +
+	"\x13NodeRemoveInMessage\"\x8d\x01\n" +
+	"\x11DiagnoseInMessage\x12:\n" +
+	"\fdiagnoseType\x18\x01 \x01(\x0e2\x16.node_rpc.DiagnoseTypeR\fdiagnoseType\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x18\n" +
+	"\anetwork\x18\x04 \x01(\tR\anetwork\"V\n" +
+	"\fBusInMessage\x12\x1c\n" +
+	"\tmessageID\x18\x01 \x01(\tR\tmessageID\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"7\n" +
+	"\x11IpStatusInMessage\x12\"\n" +
+	"\flinkNameList\x18\x01 \x03(\tR\flinkNameList\"X\n" +
 	"\x16NetworkFlushOutMessage\x12>\n" +
 	"\vnetworkList\x18\x01 \x03(\v2\x1c.node_rpc.networkInfoMessageR\vnetworkList\"\xa7\x01\n" +
 	"\x11NetScanOutMessage\x12\x10\n" +
@@ -1269,7 +2121,18 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\x05manuf\x18\x05 \x01(\tR\x05manuf\x12\x14\n" +
 	"\x05netID\x18\x06 \x01(\rR\x05netID\x12\x16\n" +
 	"\x06errMsg\x18\a \x01(\tR\x06errMsg\"\x16\n" +
-	"\x14NodeRemoveOutMessage\"\x93\x03\n" +
+	"\x14NodeRemoveOutMessage\"V\n" +
+	"\x12DiagnoseOutMessage\x12\x10\n" +
+	"\x03end\x18\x01 \x01(\bR\x03end\x12\x16\n" +
+	"\x06output\x18\x02 \x01(\tR\x06output\x12\x16\n" +
+	"\x06errMsg\x18\x03 \x01(\tR\x06errMsg\"O\n" +
+	"\x12IpStatusOutMessage\x129\n" +
+	"\blinkList\x18\x01 \x03(\v2\x1d.node_rpc.ipLinkStatusMessageR\blinkList\"w\n" +
+	"\x13ipLinkStatusMessage\x12\x1a\n" +
+	"\blinkName\x18\x01 \x01(\tR\blinkName\x12\x16\n" +
+	"\x06exists\x18\x02 \x01(\bR\x06exists\x12\x10\n" +
+	"\x03mac\x18\x03 \x01(\tR\x03mac\x12\x1a\n" +
+	"\baddrList\x18\x04 \x03(\tR\baddrList\"\xaf\x04\n" +
 	"\vCmdResponse\x12+\n" +
 	"\acmdType\x18\x01 \x01(\x0e2\x11.node_rpc.CmdTypeR\acmdType\x12\x16\n" +
 	"\x06taskID\x18\x02 \x01(\tR\x06taskID\x12\x16\n" +
@@ -1278,28 +2141,73 @@ const file_internal_rpc_node_proto_rawDesc = "" +
 	"\berrorMsg\x18\x05 \x01(\tR\berrorMsg\x12X\n" +
 	"\x16NetworkFlushOutMessage\x18\x06 \x01(\v2 .node_rpc.NetworkFlushOutMessageR\x16NetworkFlushOutMessage\x12I\n" +
 	"\x11NetScanOutMessage\x18\a \x01(\v2\x1b.node_rpc.NetScanOutMessageR\x11NetScanOutMessage\x12R\n" +
-	"\x14NodeRemoveOutMessage\x18\b \x01(\v2\x1e.node_rpc.NodeRemoveOutMessageR\x14NodeRemoveOutMessage\"y\n" +
+	"\x14NodeRemoveOutMessage\x18\b \x01(\v2\x1e.node_rpc.NodeRemoveOutMessageR\x14NodeRemoveOutMessage\x12L\n" +
+	"\x12DiagnoseOutMessage\x18\t \x01(\v2\x1c.node_rpc.DiagnoseOutMessageR\x12DiagnoseOutMessage\x12L\n" +
+	"\x12IpStatusOutMessage\x18\n" +
+	" \x01(\v2\x1c.node_rpc.IpStatusOutMessageR\x12IpStatusOutMessage\"y\n" +
 	"\x15StatusCreateIPRequest\x12\x1c\n" +
 	"\thoneyIPID\x18\x01 \x01(\rR\thoneyIPID\x12\x16\n" +
 	"\x06errMsg\x18\x02 \x01(\tR\x06errMsg\x12\x18\n" +
 	"\anetwork\x18\x03 \x01(\tR\anetwork\x12\x10\n" +
 	"\x03mac\x18\x04 \x01(\tR\x03mac\"=\n" +
 	"\x15StatusDeleteIPRequest\x12$\n" +
-	"\rhoneyIPIDList\x18\x01 \x03(\rR\rhoneyIPIDList\"<\n" +
+	"\rhoneyIPIDList\x18\x01 \x03(\rR\rhoneyIPIDList\"~\n" +
+	"\x15StatusBindPortRequest\x12\x18\n" +
+	"\anodeUid\x18\x01 \x01(\tR\anodeUid\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12;\n" +
+	"\bportList\x18\x03 \x03(\v2\x1f.node_rpc.bindPortStatusMessageR\bportList\"]\n" +
+	"\x15bindPortStatusMessage\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\x05R\x04port\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06errMsg\x18\x03 \x01(\tR\x06errMsg\"|\n" +
+	"\x18ReportInteractionRequest\x12\x18\n" +
+	"\anodeUid\x18\x01 \x01(\tR\anodeUid\x12F\n" +
+	"\x0finteractionList\x18\x02 \x03(\v2\x1c.node_rpc.interactionMessageR\x0finteractionList\"\xa7\x02\n" +
+	"\x12interactionMessage\x12\x1c\n" +
+	"\tsessionID\x18\x01 \x01(\tR\tsessionID\x12\x1a\n" +
+	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x14\n" +
+	"\x05srcIP\x18\x03 \x01(\tR\x05srcIP\x12\x18\n" +
+	"\asrcPort\x18\x04 \x01(\x05R\asrcPort\x12\x14\n" +
+	"\x05dstIP\x18\x05 \x01(\tR\x05dstIP\x12\x18\n" +
+	"\adstPort\x18\x06 \x01(\x05R\adstPort\x12\x1c\n" +
+	"\tstartTime\x18\a \x01(\x03R\tstartTime\x12\x18\n" +
+	"\aendTime\x18\b \x01(\x03R\aendTime\x12?\n" +
+	"\teventList\x18\t \x03(\v2!.node_rpc.interactionEventMessageR\teventList\"_\n" +
+	"\x17interactionEventMessage\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12\x1c\n" +
+	"\teventType\x18\x02 \x01(\tR\teventType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\"\xac\x01\n" +
 	"\n" +
 	"TunnelData\x12\x14\n" +
 	"\x05chunk\x18\x01 \x01(\fR\x05chunk\x12\x18\n" +
-	"\aaddress\x18\x02 \x01(\tR\aaddress*M\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x18\n" +
+	"\anodeUid\x18\x03 \x01(\tR\anodeUid\x12\x1c\n" +
+	"\tsessionID\x18\x04 \x01(\tR\tsessionID\x12\x18\n" +
+	"\asrcAddr\x18\x05 \x01(\tR\asrcAddr\x12\x1c\n" +
+	"\tlocalAddr\x18\x06 \x01(\tR\tlocalAddr*\x8e\x01\n" +
 	"\aCmdType\x12\x17\n" +
 	"\x13cmdNetworkFlushType\x10\x00\x12\x12\n" +
 	"\x0ecmdNetScanType\x10\x01\x12\x15\n" +
-	"\x11cmdNodeRemoveType\x10\x022\xab\x03\n" +
+	"\x11cmdNodeRemoveType\x10\x02\x12\x13\n" +
+	"\x0fcmdDiagnoseType\x10\x03\x12\x15\n" +
+	"\x11cmdBusMessageType\x10\x04\x12\x13\n" +
+	"\x0fcmdIpStatusType\x10\x05*\xc8\x01\n" +
+	"\fDiagnoseType\x12\x16\n" +
+	"\x12diagnoseArpingType\x10\x00\x12\x1a\n" +
+	"\x16diagnoseTcpConnectType\x10\x01\x12\x18\n" +
+	"\x14diagnoseLinkListType\x10\x02\x12\x18\n" +
+	"\x14diagnoseAddrListType\x10\x03\x12\x1a\n" +
+	"\x16diagnoseListenListType\x10\x04\x12\x19\n" +
+	"\x15diagnoseNeighListType\x10\x05\x12\x19\n" +
+	"\x15diagnoseRouteListType\x10\x062\xcb\x04\n" +
 	"\vNodeService\x12?\n" +
 	"\bRegister\x12\x19.node_rpc.RegisterRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12G\n" +
 	"\fNodeResource\x12\x1d.node_rpc.NodeResourceRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12<\n" +
 	"\aCommand\x12\x15.node_rpc.CmdResponse\x1a\x14.node_rpc.CmdRequest\"\x00(\x010\x01\x12K\n" +
 	"\x0eStatusCreateIP\x12\x1f.node_rpc.StatusCreateIPRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12K\n" +
-	"\x0eStatusDeleteIP\x12\x1f.node_rpc.StatusDeleteIPRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12:\n" +
+	"\x0eStatusDeleteIP\x12\x1f.node_rpc.StatusDeleteIPRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12K\n" +
+	"\x0eStatusBindPort\x12\x1f.node_rpc.StatusBindPortRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12Q\n" +
+	"\x11ReportInteraction\x12\".node_rpc.ReportInteractionRequest\x1a\x16.node_rpc.BaseResponse\"\x00\x12:\n" +
 	"\x06Tunnel\x12\x14.node_rpc.TunnelData\x1a\x14.node_rpc.TunnelData\"\x00(\x010\x01B\vZ\t/node_rpcb\x06proto3"
 
 var (
@@ -1314,59 +2222,85 @@ func file_internal_rpc_node_proto_rawDescGZIP() []byte {
 	return file_internal_rpc_node_proto_rawDescData
 }
 
-var file_internal_rpc_node_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_rpc_node_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_internal_rpc_node_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_internal_rpc_node_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_internal_rpc_node_proto_goTypes = []any{
-	(CmdType)(0),                   // 0: node_rpc.CmdType
-	(*BaseResponse)(nil),           // 1: node_rpc.BaseResponse
-	(*RegisterRequest)(nil),        // 2: node_rpc.RegisterRequest
-	(*NodeResourceRequest)(nil),    // 3: node_rpc.NodeResourceRequest
-	(*SystemInfoMessage)(nil),      // 4: node_rpc.systemInfoMessage
-	(*ResourceMessage)(nil),        // 5: node_rpc.resourceMessage
-	(*NetworkInfoMessage)(nil),     // 6: node_rpc.networkInfoMessage
-	(*CmdRequest)(nil),             // 7: node_rpc.CmdRequest
-	(*NetworkFlushInMessage)(nil),  // 8: node_rpc.NetworkFlushInMessage
-	(*NetScanInMessage)(nil),       // 9: node_rpc.NetScanInMessage
-	(*NodeRemoveInMessage)(nil),    // 10: node_rpc.NodeRemoveInMessage
-	(*NetworkFlushOutMessage)(nil), // 11: node_rpc.NetworkFlushOutMessage
-	(*NetScanOutMessage)(nil),      // 12: node_rpc.NetScanOutMessage
-	(*NodeRemoveOutMessage)(nil),   // 13: node_rpc.NodeRemoveOutMessage
-	(*CmdResponse)(nil),            // 14: node_rpc.CmdResponse
-	(*StatusCreateIPRequest)(nil),  // 15: node_rpc.StatusCreateIPRequest
-	(*StatusDeleteIPRequest)(nil),  // 16: node_rpc.StatusDeleteIPRequest
-	(*TunnelData)(nil),             // 17: node_rpc.TunnelData
+	(CmdType)(0),                     // 0: node_rpc.CmdType
+	(DiagnoseType)(0),                // 1: node_rpc.DiagnoseType
+	(*BaseResponse)(nil),             // 2: node_rpc.BaseResponse
+	(*RegisterRequest)(nil),          // 3: node_rpc.RegisterRequest
+	(*NodeResourceRequest)(nil),      // 4: node_rpc.NodeResourceRequest
+	(*SystemInfoMessage)(nil),        // 5: node_rpc.systemInfoMessage
+	(*ResourceMessage)(nil),          // 6: node_rpc.resourceMessage
+	(*NetworkInfoMessage)(nil),       // 7: node_rpc.networkInfoMessage
+	(*CmdRequest)(nil),               // 8: node_rpc.CmdRequest
+	(*NetworkFlushInMessage)(nil),    // 9: node_rpc.NetworkFlushInMessage
+	(*NetScanInMessage)(nil),         // 10: node_rpc.NetScanInMessage
+	(*NodeRemoveInMessage)(nil),      // 11: node_rpc.NodeRemoveInMessage
+	(*DiagnoseInMessage)(nil),        // 12: node_rpc.DiagnoseInMessage
+	(*BusInMessage)(nil),             // 13: node_rpc.BusInMessage
+	(*IpStatusInMessage)(nil),        // 14: node_rpc.IpStatusInMessage
+	(*NetworkFlushOutMessage)(nil),   // 15: node_rpc.NetworkFlushOutMessage
+	(*NetScanOutMessage)(nil),        // 16: node_rpc.NetScanOutMessage
+	(*NodeRemoveOutMessage)(nil),     // 17: node_rpc.NodeRemoveOutMessage
+	(*DiagnoseOutMessage)(nil),       // 18: node_rpc.DiagnoseOutMessage
+	(*IpStatusOutMessage)(nil),       // 19: node_rpc.IpStatusOutMessage
+	(*IpLinkStatusMessage)(nil),      // 20: node_rpc.ipLinkStatusMessage
+	(*CmdResponse)(nil),              // 21: node_rpc.CmdResponse
+	(*StatusCreateIPRequest)(nil),    // 22: node_rpc.StatusCreateIPRequest
+	(*StatusDeleteIPRequest)(nil),    // 23: node_rpc.StatusDeleteIPRequest
+	(*StatusBindPortRequest)(nil),    // 24: node_rpc.StatusBindPortRequest
+	(*BindPortStatusMessage)(nil),    // 25: node_rpc.bindPortStatusMessage
+	(*ReportInteractionRequest)(nil), // 26: node_rpc.ReportInteractionRequest
+	(*InteractionMessage)(nil),       // 27: node_rpc.interactionMessage
+	(*InteractionEventMessage)(nil),  // 28: node_rpc.interactionEventMessage
+	(*TunnelData)(nil),               // 29: node_rpc.TunnelData
 }
 var file_internal_rpc_node_proto_depIdxs = []int32{
-	4,  // 0: node_rpc.RegisterRequest.systemInfo:type_name -> node_rpc.systemInfoMessage
-	5,  // 1: node_rpc.RegisterRequest.resourceInfo:type_name -> node_rpc.resourceMessage
-	6,  // 2: node_rpc.RegisterRequest.networkList:type_name -> node_rpc.networkInfoMessage
-	5,  // 3: node_rpc.NodeResourceRequest.resourceInfo:type_name -> node_rpc.resourceMessage
+	5,  // 0: node_rpc.RegisterRequest.systemInfo:type_name -> node_rpc.systemInfoMessage
+	6,  // 1: node_rpc.RegisterRequest.resourceInfo:type_name -> node_rpc.resourceMessage
+	7,  // 2: node_rpc.RegisterRequest.networkList:type_name -> node_rpc.networkInfoMessage
+	6,  // 3: node_rpc.NodeResourceRequest.resourceInfo:type_name -> node_rpc.resourceMessage
 	0,  // 4: node_rpc.CmdRequest.cmdType:type_name -> node_rpc.CmdType
-	8,  // 5: node_rpc.CmdRequest.NetworkFlushInMessage:type_name -> node_rpc.NetworkFlushInMessage
-	9,  // 6: node_rpc.CmdRequest.NetScanInMessage:type_name -> node_rpc.NetScanInMessage
-	10, // 7: node_rpc.CmdRequest.NodeRemoveInMessage:type_name -> node_rpc.NodeRemoveInMessage
-	6,  // 8: node_rpc.NetworkFlushOutMessage.networkList:type_name -> node_rpc.networkInfoMessage
-	0,  // 9: node_rpc.CmdResponse.cmdType:type_name -> node_rpc.CmdType
-	11, // 10: node_rpc.CmdResponse.NetworkFlushOutMessage:type_name -> node_rpc.NetworkFlushOutMessage
-	12, // 11: node_rpc.CmdResponse.NetScanOutMessage:type_name -> node_rpc.NetScanOutMessage
-	13, // 12: node_rpc.CmdResponse.NodeRemoveOutMessage:type_name -> node_rpc.NodeRemoveOutMessage
-	2,  // 13: node_rpc.NodeService.Register:input_type -> node_rpc.RegisterRequest
-	3,  // 14: node_rpc.NodeService.NodeResource:input_type -> node_rpc.NodeResourceRequest
-	14, // 15: node_rpc.NodeService.Command:input_type -> node_rpc.CmdResponse
-	15, // 16: node_rpc.NodeService.StatusCreateIP:input_type -> node_rpc.StatusCreateIPRequest
-	16, // 17: node_rpc.NodeService.StatusDeleteIP:input_type -> node_rpc.StatusDeleteIPRequest
-	17, // 18: node_rpc.NodeService.Tunnel:input_type -> node_rpc.TunnelData
-	1,  // 19: node_rpc.NodeService.Register:output_type -> node_rpc.BaseResponse
-	1,  // 20: node_rpc.NodeService.NodeResource:output_type -> node_rpc.BaseResponse
-	7,  // 21: node_rpc.NodeService.Command:output_type -> node_rpc.CmdRequest
-	1,  // 22: node_rpc.NodeService.StatusCreateIP:output_type -> node_rpc.BaseResponse
-	1,  // 23: node_rpc.NodeService.StatusDeleteIP:output_type -> node_rpc.BaseResponse
-	17, // 24: node_rpc.NodeService.Tunnel:output_type -> node_rpc.TunnelData
-	19, // [19:25] is the sub-list for method output_type
-	13, // [13:19] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	9,  // 5: node_rpc.CmdRequest.NetworkFlushInMessage:type_name -> node_rpc.NetworkFlushInMessage
+	10, // 6: node_rpc.CmdRequest.NetScanInMessage:type_name -> node_rpc.NetScanInMessage
+	11, // 7: node_rpc.CmdRequest.NodeRemoveInMessage:type_name -> node_rpc.NodeRemoveInMessage
+	12, // 8: node_rpc.CmdRequest.DiagnoseInMessage:type_name -> node_rpc.DiagnoseInMessage
+	13, // 9: node_rpc.CmdRequest.BusInMessage:type_name -> node_rpc.BusInMessage
+	14, // 10: node_rpc.CmdRequest.IpStatusInMessage:type_name -> node_rpc.IpStatusInMessage
+	1,  // 11: node_rpc.DiagnoseInMessage.diagnoseType:type_name -> node_rpc.DiagnoseType
+	7,  // 12: node_rpc.NetworkFlushOutMessage.networkList:type_name -> node_rpc.networkInfoMessage
+	20, // 13: node_rpc.IpStatusOutMessage.linkList:type_name -> node_rpc.ipLinkStatusMessage
+	0,  // 14: node_rpc.CmdResponse.cmdType:type_name -> node_rpc.CmdType
+	15, // 15: node_rpc.CmdResponse.NetworkFlushOutMessage:type_name -> node_rpc.NetworkFlushOutMessage
+	16, // 16: node_rpc.CmdResponse.NetScanOutMessage:type_name -> node_rpc.NetScanOutMessage
+	17, // 17: node_rpc.CmdResponse.NodeRemoveOutMessage:type_name -> node_rpc.NodeRemoveOutMessage
+	18, // 18: node_rpc.CmdResponse.DiagnoseOutMessage:type_name -> node_rpc.DiagnoseOutMessage
+	19, // 19: node_rpc.CmdResponse.IpStatusOutMessage:type_name -> node_rpc.IpStatusOutMessage
+	25, // 20: node_rpc.StatusBindPortRequest.portList:type_name -> node_rpc.bindPortStatusMessage
+	27, // 21: node_rpc.ReportInteractionRequest.interactionList:type_name -> node_rpc.interactionMessage
+	28, // 22: node_rpc.interactionMessage.eventList:type_name -> node_rpc.interactionEventMessage
+	3,  // 23: node_rpc.NodeService.Register:input_type -> node_rpc.RegisterRequest
+	4,  // 24: node_rpc.NodeService.NodeResource:input_type -> node_rpc.NodeResourceRequest
+	21, // 25: node_rpc.NodeService.Command:input_type -> node_rpc.CmdResponse
+	22, // 26: node_rpc.NodeService.StatusCreateIP:input_type -> node_rpc.StatusCreateIPRequest
+	23, // 27: node_rpc.NodeService.StatusDeleteIP:input_type -> node_rpc.StatusDeleteIPRequest
+	24, // 28: node_rpc.NodeService.StatusBindPort:input_type -> node_rpc.StatusBindPortRequest
+	26, // 29: node_rpc.NodeService.ReportInteraction:input_type -> node_rpc.ReportInteractionRequest
+	29, // 30: node_rpc.NodeService.Tunnel:input_type -> node_rpc.TunnelData
+	2,  // 31: node_rpc.NodeService.Register:output_type -> node_rpc.BaseResponse
+	2,  // 32: node_rpc.NodeService.NodeResource:output_type -> node_rpc.BaseResponse
+	8,  // 33: node_rpc.NodeService.Command:output_type -> node_rpc.CmdRequest
+	2,  // 34: node_rpc.NodeService.StatusCreateIP:output_type -> node_rpc.BaseResponse
+	2,  // 35: node_rpc.NodeService.StatusDeleteIP:output_type -> node_rpc.BaseResponse
+	2,  // 36: node_rpc.NodeService.StatusBindPort:output_type -> node_rpc.BaseResponse
+	2,  // 37: node_rpc.NodeService.ReportInteraction:output_type -> node_rpc.BaseResponse
+	29, // 38: node_rpc.NodeService.Tunnel:output_type -> node_rpc.TunnelData
+	31, // [31:39] is the sub-list for method output_type
+	23, // [23:31] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_internal_rpc_node_proto_init() }
@@ -1379,8 +2313,8 @@ func file_internal_rpc_node_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_rpc_node_proto_rawDesc), len(file_internal_rpc_node_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   17,
+			NumEnums:      2,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
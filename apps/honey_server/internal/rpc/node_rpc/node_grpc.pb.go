@@ -19,12 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NodeService_Register_FullMethodName       = "/node_rpc.NodeService/Register"
-	NodeService_NodeResource_FullMethodName   = "/node_rpc.NodeService/NodeResource"
-	NodeService_Command_FullMethodName        = "/node_rpc.NodeService/Command"
-	NodeService_StatusCreateIP_FullMethodName = "/node_rpc.NodeService/StatusCreateIP"
-	NodeService_StatusDeleteIP_FullMethodName = "/node_rpc.NodeService/StatusDeleteIP"
-	NodeService_Tunnel_FullMethodName         = "/node_rpc.NodeService/Tunnel"
+	NodeService_Register_FullMethodName          = "/node_rpc.NodeService/Register"
+	NodeService_NodeResource_FullMethodName      = "/node_rpc.NodeService/NodeResource"
+	NodeService_Command_FullMethodName           = "/node_rpc.NodeService/Command"
+	NodeService_StatusCreateIP_FullMethodName    = "/node_rpc.NodeService/StatusCreateIP"
+	NodeService_StatusDeleteIP_FullMethodName    = "/node_rpc.NodeService/StatusDeleteIP"
+	NodeService_StatusBindPort_FullMethodName    = "/node_rpc.NodeService/StatusBindPort"
+	NodeService_ReportInteraction_FullMethodName = "/node_rpc.NodeService/ReportInteraction"
+	NodeService_Tunnel_FullMethodName            = "/node_rpc.NodeService/Tunnel"
 )
 
 // NodeServiceClient is the client API for NodeService service.
@@ -43,6 +45,10 @@ type NodeServiceClient interface {
 	StatusCreateIP(ctx context.Context, in *StatusCreateIPRequest, opts ...grpc.CallOption) (*BaseResponse, error)
 	// 节点上报删除IP的回调
 	StatusDeleteIP(ctx context.Context, in *StatusDeleteIPRequest, opts ...grpc.CallOption) (*BaseResponse, error)
+	// 节点上报端口绑定状态
+	StatusBindPort(ctx context.Context, in *StatusBindPortRequest, opts ...grpc.CallOption) (*BaseResponse, error)
+	// 节点上报内置模拟器的交互会话
+	ReportInteraction(ctx context.Context, in *ReportInteractionRequest, opts ...grpc.CallOption) (*BaseResponse, error)
 	// 端口转发通道
 	Tunnel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TunnelData, TunnelData], error)
 }
@@ -108,6 +114,26 @@ func (c *nodeServiceClient) StatusDeleteIP(ctx context.Context, in *StatusDelete
 	return out, nil
 }
 
+func (c *nodeServiceClient) StatusBindPort(ctx context.Context, in *StatusBindPortRequest, opts ...grpc.CallOption) (*BaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BaseResponse)
+	err := c.cc.Invoke(ctx, NodeService_StatusBindPort_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) ReportInteraction(ctx context.Context, in *ReportInteractionRequest, opts ...grpc.CallOption) (*BaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BaseResponse)
+	err := c.cc.Invoke(ctx, NodeService_ReportInteraction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeServiceClient) Tunnel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TunnelData, TunnelData], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &NodeService_ServiceDesc.Streams[1], NodeService_Tunnel_FullMethodName, cOpts...)
@@ -137,6 +163,10 @@ type NodeServiceServer interface {
 	StatusCreateIP(context.Context, *StatusCreateIPRequest) (*BaseResponse, error)
 	// 节点上报删除IP的回调
 	StatusDeleteIP(context.Context, *StatusDeleteIPRequest) (*BaseResponse, error)
+	// 节点上报端口绑定状态
+	StatusBindPort(context.Context, *StatusBindPortRequest) (*BaseResponse, error)
+	// 节点上报内置模拟器的交互会话
+	ReportInteraction(context.Context, *ReportInteractionRequest) (*BaseResponse, error)
 	// 端口转发通道
 	Tunnel(grpc.BidiStreamingServer[TunnelData, TunnelData]) error
 	mustEmbedUnimplementedNodeServiceServer()
@@ -164,6 +194,12 @@ func (UnimplementedNodeServiceServer) StatusCreateIP(context.Context, *StatusCre
 func (UnimplementedNodeServiceServer) StatusDeleteIP(context.Context, *StatusDeleteIPRequest) (*BaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StatusDeleteIP not implemented")
 }
+func (UnimplementedNodeServiceServer) StatusBindPort(context.Context, *StatusBindPortRequest) (*BaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatusBindPort not implemented")
+}
+func (UnimplementedNodeServiceServer) ReportInteraction(context.Context, *ReportInteractionRequest) (*BaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportInteraction not implemented")
+}
 func (UnimplementedNodeServiceServer) Tunnel(grpc.BidiStreamingServer[TunnelData, TunnelData]) error {
 	return status.Errorf(codes.Unimplemented, "method Tunnel not implemented")
 }
@@ -267,6 +303,42 @@ func _NodeService_StatusDeleteIP_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_StatusBindPort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusBindPortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).StatusBindPort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_StatusBindPort_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).StatusBindPort(ctx, req.(*StatusBindPortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_ReportInteraction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportInteractionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ReportInteraction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_ReportInteraction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ReportInteraction(ctx, req.(*ReportInteractionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeService_Tunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(NodeServiceServer).Tunnel(&grpc.GenericServerStream[TunnelData, TunnelData]{ServerStream: stream})
 }
@@ -297,6 +369,14 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StatusDeleteIP",
 			Handler:    _NodeService_StatusDeleteIP_Handler,
 		},
+		{
+			MethodName: "StatusBindPort",
+			Handler:    _NodeService_StatusBindPort_Handler,
+		},
+		{
+			MethodName: "ReportInteraction",
+			Handler:    _NodeService_ReportInteraction_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
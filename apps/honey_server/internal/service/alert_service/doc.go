@@ -0,0 +1,2 @@
+// Package alert_service 告警服务，按告警规则匹配实时事件产生告警，并通过Webhook、SMTP邮件、Syslog渠道发送通知
+package alert_service
@@ -0,0 +1,93 @@
+package alert_service
+
+// File: service/alert_service/email.go
+// Description: SMTP邮件通知渠道，支持TLS直连或STARTTLS，配置了用户名时使用PLAIN认证
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"honey_server/internal/models"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// sendEmail 发送邮件通知
+func sendEmail(config models.AlertChannelConfig, alert models.AlertModel) error {
+	if len(config.To) == 0 {
+		return errors.New("未配置收件人")
+	}
+	addr := net.JoinHostPort(config.Host, fmt.Sprintf("%d", config.Port))
+	tlsConfig := &tls.Config{ServerName: config.Host}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: notifyTimeout}
+	if config.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(notifyTimeout))
+
+	client, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if !config.TLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err = client.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+	if config.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", config.Username, config.Password, config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(config.From); err != nil {
+		return err
+	}
+	for _, to := range config.To {
+		if err = client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(emailMessage(config, alert)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// emailMessage 构造邮件内容
+func emailMessage(config models.AlertChannelConfig, alert models.AlertModel) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", config.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(config.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", subject(alert)))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	buf.WriteString(strings.ReplaceAll(text(alert), "\n", "\r\n"))
+	return buf.Bytes()
+}
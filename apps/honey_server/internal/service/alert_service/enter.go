@@ -0,0 +1,78 @@
+package alert_service
+
+// File: service/alert_service/enter.go
+// Description: 告警引擎入口，订阅全部实时事件并逐条交给启用的告警规则判断；
+// 规则缓存在内存中，规则变更时重新加载，并定时刷新以兼容直接修改数据库的情况
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/event_service"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const reloadInterval = time.Minute // 规则定时刷新间隔
+
+var (
+	ruleMutex sync.RWMutex
+	ruleList  []models.AlertRuleModel // 启用的告警规则
+)
+
+// Reload 重新加载启用的告警规则
+func Reload() {
+	var list []models.AlertRuleModel
+	if err := global.DB.Find(&list, "enable = ?", true).Error; err != nil {
+		logrus.Errorf("加载告警规则失败 %s", err)
+		return
+	}
+	ruleMutex.Lock()
+	ruleList = list
+	ruleMutex.Unlock()
+}
+
+// Run 启动告警引擎（阻塞运行，需以协程方式启动）
+// 订阅者接收过慢被断开时，从最后处理的事件重新订阅，补发期间错过的事件
+func Run() {
+	Reload()
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	var lastEventID string
+	for {
+		subscriber := event_service.Subscribe(event_service.Filter{}, lastEventID)
+	loop:
+		for {
+			select {
+			case event := <-subscriber.C:
+				lastEventID = strconv.FormatInt(event.ID, 10)
+				handle(event)
+			case <-ticker.C:
+				Reload()
+				cleanWindow()
+			case <-subscriber.Done:
+				logrus.Warnf("告警引擎处理事件过慢，重新订阅")
+				break loop
+			}
+		}
+		event_service.Unsubscribe(subscriber)
+	}
+}
+
+// handle 将事件交给全部匹配的规则判断
+func handle(event event_service.Event) {
+	ruleMutex.RLock()
+	list := ruleList
+	ruleMutex.RUnlock()
+
+	fields := eventFields(event)
+	for _, rule := range list {
+		if !match(rule, fields) {
+			continue
+		}
+		evaluate(rule, fields)
+	}
+}
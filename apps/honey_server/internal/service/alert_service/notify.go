@@ -0,0 +1,76 @@
+package alert_service
+
+// File: service/alert_service/notify.go
+// Description: 告警通知，按渠道类型发送告警并记录各渠道的发送结果
+
+import (
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const notifyTimeout = 10 * time.Second // 单个渠道的发送超时
+
+// SeverityText 告警级别名称
+var SeverityText = map[int8]string{
+	1: "低",
+	2: "中",
+	3: "高",
+	4: "严重",
+}
+
+// notify 向规则配置的全部启用渠道发送告警，并回写发送结果
+func notify(channelIDList []uint, alert models.AlertModel) {
+	if len(channelIDList) == 0 {
+		return
+	}
+	var channelList []models.AlertChannelModel
+	global.DB.Find(&channelList, "id in ? and enable = ?", channelIDList, true)
+
+	var resultList []string
+	for _, channel := range channelList {
+		result := "成功"
+		if err := Send(channel, alert); err != nil {
+			logrus.Errorf("告警通知发送失败 %s %s", channel.Title, err)
+			result = err.Error()
+		}
+		resultList = append(resultList, fmt.Sprintf("%s: %s", channel.Title, result))
+	}
+	global.DB.Model(&alert).Update("notify_result", truncate(strings.Join(resultList, "; "), 1024))
+}
+
+// Send 通过指定渠道发送告警
+func Send(channel models.AlertChannelModel, alert models.AlertModel) error {
+	switch channel.Type {
+	case "webhook":
+		return sendWebhook(channel.Config, alert)
+	case "email":
+		return sendEmail(channel.Config, alert)
+	case "syslog":
+		return sendSyslog(channel.Config, alert)
+	}
+	return errors.New("不支持的渠道类型")
+}
+
+// subject 告警标题
+func subject(alert models.AlertModel) string {
+	return fmt.Sprintf("[%s] %s", SeverityText[alert.Severity], alert.RuleTitle)
+}
+
+// text 告警正文
+func text(alert models.AlertModel) string {
+	return fmt.Sprintf("告警规则：%s\n告警级别：%s\n事件类型：%s\n触发次数：%d\n首次触发：%s\n最近触发：%s\n告警内容：%s\n",
+		alert.RuleTitle,
+		SeverityText[alert.Severity],
+		alert.EventType,
+		alert.Count,
+		alert.FirstTime.Format(time.DateTime),
+		alert.LastTime.Format(time.DateTime),
+		alert.Content,
+	)
+}
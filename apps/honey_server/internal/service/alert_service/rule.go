@@ -0,0 +1,239 @@
+package alert_service
+
+// File: service/alert_service/rule.go
+// Description: 告警规则判断：按条件匹配事件，按分组在时间窗口内计数，达到阈值后产生告警；
+// 抑制窗口内同一规则同一分组未解决的告警只累加次数，不重复通知
+
+import (
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/event_service"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fields 从事件中提取的可匹配字段
+type fields struct {
+	event     event_service.Event
+	nodeID    uint
+	honeyIpID uint
+	protocol  string
+	srcIP     string
+	dstPort   int
+	summary   string // 事件摘要，用于告警内容
+}
+
+// eventFields 提取事件中的可匹配字段
+func eventFields(event event_service.Event) fields {
+	f := fields{event: event, nodeID: event.NodeID}
+	switch data := event.Data.(type) {
+	case event_service.AttackSessionData:
+		f.honeyIpID = data.HoneyIpID
+		f.protocol = data.Protocol
+		f.srcIP = data.SrcIP
+		f.dstPort = data.DstPort
+		f.summary = fmt.Sprintf("攻击者 %s:%d 访问诱捕端口 %s:%d", data.SrcIP, data.SrcPort, data.DstIP, data.DstPort)
+		if data.Protocol != "" {
+			f.summary += "（" + data.Protocol + "）"
+		}
+	case event_service.HostChangeData:
+		f.srcIP = data.IP
+		f.summary = fmt.Sprintf("网络 %d 主机 %s（%s %s）%s", data.NetID, data.IP, data.Mac, data.Manuf, hostChangeText[data.Change])
+	case event_service.NodeData:
+		f.summary = fmt.Sprintf("节点 %s（%s）%s", data.Title, data.Uid, nodeEventText[event.Type])
+	case event_service.HoneyIpStatusData:
+		f.honeyIpID = data.HoneyIpID
+		f.summary = fmt.Sprintf("诱捕IP %s 状态变为 %d %s", data.IP, data.Status, data.ErrorMsg)
+	case event_service.LoginLockoutData:
+		f.srcIP = data.IP
+		f.summary = fmt.Sprintf("用户名 %s 在 %s 登录失败 %d 次，%s已被锁定至 %s", data.Username, data.IP, data.FailCount,
+			lockoutKindText[data.Kind], time.Unix(data.Until, 0).Format(time.DateTime))
+	default:
+		f.summary = event.Type
+	}
+	return f
+}
+
+var hostChangeText = map[string]string{
+	"new":    "新增",
+	"update": "变更",
+	"delete": "消失",
+}
+
+var lockoutKindText = map[string]string{
+	"user": "用户名",
+	"ip":   "IP",
+}
+
+var nodeEventText = map[string]string{
+	event_service.NodeOnline:  "上线",
+	event_service.NodeOffline: "下线",
+}
+
+// match 判断事件是否满足规则条件
+func match(rule models.AlertRuleModel, f fields) bool {
+	if rule.EventType != f.event.Type {
+		return false
+	}
+	if rule.NodeID != 0 && rule.NodeID != f.nodeID {
+		return false
+	}
+	if rule.HoneyIpID != 0 && rule.HoneyIpID != f.honeyIpID {
+		return false
+	}
+	if rule.Protocol != "" && rule.Protocol != f.protocol {
+		return false
+	}
+	if rule.DstPort != 0 && rule.DstPort != f.dstPort {
+		return false
+	}
+	if rule.SrcIP != "" && !matchIP(rule.SrcIP, f.srcIP) {
+		return false
+	}
+	return true
+}
+
+// matchIP 判断IP是否等于指定IP或属于指定网段
+func matchIP(pattern string, ip string) bool {
+	if _, ipNet, err := net.ParseCIDR(pattern); err == nil {
+		addr := net.ParseIP(ip)
+		return addr != nil && ipNet.Contains(addr)
+	}
+	return pattern == ip
+}
+
+// groupKey 按规则的分组字段取分组值
+func groupKey(rule models.AlertRuleModel, f fields) string {
+	switch rule.GroupBy {
+	case "src_ip":
+		return f.srcIP
+	case "honey_ip":
+		return fmt.Sprintf("%d", f.honeyIpID)
+	case "node":
+		return fmt.Sprintf("%d", f.nodeID)
+	}
+	return ""
+}
+
+var (
+	windowMutex sync.Mutex
+	windowMap   = map[string][]time.Time{} // 时间窗口内的事件时间，键为 规则ID|分组值
+)
+
+// evaluate 在时间窗口内计数，达到阈值时产生告警并重新计数
+func evaluate(rule models.AlertRuleModel, f fields) {
+	key := groupKey(rule, f)
+	threshold := max(rule.Threshold, 1)
+
+	windowMutex.Lock()
+	windowKey := fmt.Sprintf("%d|%s", rule.ID, key)
+	list := append(prune(windowMap[windowKey], rule.Window, f.event.Time), f.event.Time)
+	reached := len(list) >= threshold
+	if reached {
+		delete(windowMap, windowKey)
+	} else {
+		windowMap[windowKey] = list
+	}
+	windowMutex.Unlock()
+
+	if reached {
+		fire(rule, key, f, len(list))
+	}
+}
+
+// prune 丢弃时间窗口之外的事件时间
+func prune(list []time.Time, window int, now time.Time) []time.Time {
+	if window <= 0 {
+		return nil
+	}
+	start := now.Add(-time.Duration(window) * time.Second)
+	i := 0
+	for i < len(list) && list[i].Before(start) {
+		i++
+	}
+	return list[i:]
+}
+
+// cleanWindow 清理已过期或规则已删除的计数，避免内存持续增长
+func cleanWindow() {
+	ruleMutex.RLock()
+	windowSize := map[uint]int{}
+	for _, rule := range ruleList {
+		windowSize[rule.ID] = rule.Window
+	}
+	ruleMutex.RUnlock()
+
+	now := time.Now()
+	windowMutex.Lock()
+	defer windowMutex.Unlock()
+	for key, list := range windowMap {
+		var ruleID uint
+		fmt.Sscanf(key, "%d|", &ruleID)
+		window, ok := windowSize[ruleID]
+		if list = prune(list, window, now); !ok || len(list) == 0 {
+			delete(windowMap, key)
+			continue
+		}
+		windowMap[key] = list
+	}
+}
+
+// fire 产生告警：抑制窗口内存在未解决的同类告警时合并，否则新建告警并发送通知
+func fire(rule models.AlertRuleModel, key string, f fields, count int) {
+	now := f.event.Time
+	content := f.summary
+	if rule.Window > 0 && count > 1 {
+		content = fmt.Sprintf("%d秒内触发%d次，最近一次：%s", rule.Window, count, f.summary)
+	}
+
+	if rule.SuppressWindow > 0 {
+		var alert models.AlertModel
+		err := global.DB.Where("rule_id = ? and group_key = ? and status <> ? and last_time >= ?",
+			rule.ID, key, 3, now.Add(-time.Duration(rule.SuppressWindow)*time.Second)).
+			Order("id desc").Take(&alert).Error
+		if err == nil {
+			err = global.DB.Model(&alert).Updates(map[string]any{
+				"count":     alert.Count + count,
+				"last_time": now,
+				"content":   truncate(content, 1024),
+			}).Error
+			if err != nil {
+				logrus.Errorf("告警合并失败 %s", err)
+			}
+			return
+		}
+	}
+
+	alert := models.AlertModel{
+		RuleID:    rule.ID,
+		RuleTitle: rule.Title,
+		Severity:  rule.Severity,
+		EventType: rule.EventType,
+		NodeID:    f.nodeID,
+		GroupKey:  key,
+		Content:   truncate(content, 1024),
+		Count:     count,
+		FirstTime: now,
+		LastTime:  now,
+		Status:    1,
+	}
+	if err := global.DB.Create(&alert).Error; err != nil {
+		logrus.Errorf("告警入库失败 %s", err)
+		return
+	}
+	logrus.Warnf("产生告警 %s %s", rule.Title, alert.Content)
+	go notify(rule.ChannelIDList, alert)
+}
+
+// truncate 按字符数截断超长内容
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
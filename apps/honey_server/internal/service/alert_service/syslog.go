@@ -0,0 +1,64 @@
+package alert_service
+
+// File: service/alert_service/syslog.go
+// Description: Syslog通知渠道，按RFC 5424格式通过UDP或TCP发送，TCP使用八位组计数分帧（RFC 6587）
+
+import (
+	"fmt"
+	"honey_server/internal/models"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const syslogFacility = 16 // local0
+
+// syslogSeverity 告警级别与Syslog级别的对应关系
+var syslogSeverity = map[int8]int{
+	1: 5, // notice
+	2: 4, // warning
+	3: 3, // err
+	4: 2, // crit
+}
+
+// sendSyslog 发送Syslog通知
+func sendSyslog(config models.AlertChannelConfig, alert models.AlertModel) error {
+	network := config.Network
+	if network == "" {
+		network = "udp"
+	}
+	tag := config.Tag
+	if tag == "" {
+		tag = "honey_server"
+	}
+	severity, ok := syslogSeverity[alert.Severity]
+	if !ok {
+		severity = 4
+	}
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+
+	msg := fmt.Sprintf("<%d>1 %s %s %s - alert - %s: %s",
+		syslogFacility*8+severity,
+		time.Now().Format(time.RFC3339),
+		hostname,
+		tag,
+		subject(alert),
+		strings.ReplaceAll(alert.Content, "\n", " "),
+	)
+
+	conn, err := net.DialTimeout(network, config.Addr, notifyTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetWriteDeadline(time.Now().Add(notifyTimeout))
+	if network == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+	_, err = conn.Write([]byte(msg))
+	return err
+}
@@ -0,0 +1,61 @@
+package alert_service
+
+// File: service/alert_service/webhook.go
+// Description: Webhook通知渠道，以JSON格式POST告警，配置了密钥时在请求头中携带请求体的HMAC-SHA256签名
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"honey_server/internal/models"
+	"io"
+	"net/http"
+)
+
+// signatureHeader 签名请求头，值为 sha256=十六进制签名
+const signatureHeader = "X-Honey-Signature"
+
+// webhookBody Webhook请求体
+type webhookBody struct {
+	Title string            `json:"title"` // 告警标题
+	Text  string            `json:"text"`  // 告警正文
+	Alert models.AlertModel `json:"alert"` // 告警记录
+}
+
+// sendWebhook 发送Webhook通知
+func sendWebhook(config models.AlertChannelConfig, alert models.AlertModel) error {
+	body, err := json.Marshal(webhookBody{
+		Title: subject(alert),
+		Text:  text(alert),
+		Alert: alert,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if config.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(config.Secret))
+		mac.Write(body)
+		req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	client := &http.Client{Timeout: notifyTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("响应状态码 %d %s", resp.StatusCode, msg)
+	}
+	return nil
+}
@@ -0,0 +1,2 @@
+// Package api_key_service API密钥服务，提供API密钥的创建、校验与授权范围判断
+package api_key_service
@@ -0,0 +1,124 @@
+package api_key_service
+
+// File: service/api_key_service/enter.go
+// Description: API密钥的创建与校验。密钥格式为 hk_<前缀>_<随机串>，数据库只保存前缀和 SHA-256 摘要；
+// 密钥的有效权限为授权范围与所属用户当前权限的交集，用户角色变更后密钥权限随之收缩
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/utils"
+	"strings"
+	"time"
+)
+
+const (
+	KeyPrefix        = "hk_"       // 密钥固定前缀，用于区分API密钥与JWT
+	lastUsedInterval = time.Minute // 最近使用时间的更新间隔，避免每个请求都写数据库
+)
+
+// CreateRequest 创建API密钥的参数
+type CreateRequest struct {
+	UserID    uint     // 所属用户ID
+	Title     string   // 名称
+	ScopeList []string // 授权范围
+	Expires   int      // 有效天数，0 表示永不过期
+}
+
+// hashKey 计算密钥摘要
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// newKey 生成密钥，返回完整密钥及其前缀
+func newKey() (key string, prefix string) {
+	b := make([]byte, 28)
+	rand.Read(b)
+	prefix = KeyPrefix + hex.EncodeToString(b[:4])
+	return prefix + "_" + base64.RawURLEncoding.EncodeToString(b[4:]), prefix
+}
+
+// ValidScope 校验授权范围：必须为明确的权限码，且不超过所属用户的权限，返回错误信息
+func ValidScope(userID uint, scopeList []string) string {
+	if len(scopeList) == 0 {
+		return "授权范围不能为空"
+	}
+	for _, code := range scopeList {
+		if code == role_service.AllPermission || !role_service.ValidPermission(code) {
+			return fmt.Sprintf("权限 %s 不存在", code)
+		}
+		if !role_service.HasPermission(userID, code) {
+			return fmt.Sprintf("用户没有权限 %s，不能授予API密钥", code)
+		}
+	}
+	return ""
+}
+
+// Create 创建API密钥，返回密钥记录及完整密钥，完整密钥只在此时返回
+func Create(req CreateRequest) (*models.ApiKeyModel, string, error) {
+	if msg := ValidScope(req.UserID, req.ScopeList); msg != "" {
+		return nil, "", errors.New(msg)
+	}
+	var count int64
+	global.DB.Model(&models.ApiKeyModel{}).Where("user_id = ? and title = ?", req.UserID, req.Title).Count(&count)
+	if count > 0 {
+		return nil, "", errors.New("API密钥名称不能重复")
+	}
+
+	key, prefix := newKey()
+	model := models.ApiKeyModel{
+		UserID:    req.UserID,
+		Title:     req.Title,
+		Prefix:    prefix,
+		KeyHash:   hashKey(key),
+		ScopeList: req.ScopeList,
+	}
+	if req.Expires > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.Expires)
+		model.ExpiresAt = &expiresAt
+	}
+	if err := global.DB.Create(&model).Error; err != nil {
+		return nil, "", err
+	}
+	return &model, key, nil
+}
+
+// Authenticate 校验API密钥，返回密钥记录及所属用户，并按间隔记录最近使用时间与IP
+func Authenticate(key string, ip string) (*models.ApiKeyModel, *models.UserModel, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return nil, nil, errors.New("API密钥格式错误")
+	}
+	var model models.ApiKeyModel
+	if err := global.DB.Take(&model, "key_hash = ?", hashKey(key)).Error; err != nil {
+		return nil, nil, errors.New("API密钥不存在")
+	}
+	now := time.Now()
+	if model.ExpiresAt != nil && now.After(*model.ExpiresAt) {
+		return nil, nil, errors.New("API密钥已过期")
+	}
+	var user models.UserModel
+	if err := global.DB.Select("id", "role_id").Take(&user, model.UserID).Error; err != nil {
+		return nil, nil, errors.New("API密钥所属用户不存在")
+	}
+
+	if model.LastUsedAt == nil || now.Sub(*model.LastUsedAt) >= lastUsedInterval || model.LastUsedIP != ip {
+		global.DB.Model(&model).UpdateColumns(map[string]any{
+			"last_used_at": now,
+			"last_used_ip": ip,
+		})
+	}
+	return &model, &user, nil
+}
+
+// HasScope 判断API密钥是否可以使用指定权限：需同时在授权范围内且所属用户仍拥有该权限
+func HasScope(model *models.ApiKeyModel, code string) bool {
+	return utils.InList(model.ScopeList, code) && role_service.HasPermission(model.UserID, code)
+}
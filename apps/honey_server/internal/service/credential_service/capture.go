@@ -0,0 +1,66 @@
+package credential_service
+
+// File: service/credential_service/capture.go
+// Description: 凭据采集，解析交互会话中的认证事件（SSH、Telnet、FTP、HTTP Basic及登录表单、MySQL、Redis AUTH），写入凭据尝试表
+
+import (
+	"encoding/json"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// authData 认证事件内容（与节点约定）
+type authData struct {
+	Username  string `json:"username"`  // 用户名
+	Password  string `json:"password"`  // 明文口令
+	Hash      string `json:"hash"`      // 口令摘要
+	PublicKey string `json:"publicKey"` // 公钥指纹（SSH公钥认证）
+}
+
+// Capture 提取交互会话中的认证事件并写入凭据尝试表
+func Capture(interaction models.InteractionModel) {
+	var list []models.CredentialAttemptModel
+	for _, event := range interaction.EventList {
+		if event.EventType != "auth" {
+			continue
+		}
+		var data authData
+		if err := json.Unmarshal([]byte(event.Data), &data); err != nil {
+			continue
+		}
+		// 公钥认证没有口令，不计入凭据
+		if data.PublicKey != "" {
+			continue
+		}
+		list = append(list, models.CredentialAttemptModel{
+			NodeID:      interaction.NodeID,
+			HoneyIpID:   interaction.HoneyIpID,
+			SessionID:   interaction.SessionID,
+			Protocol:    interaction.Protocol,
+			SrcIP:       interaction.SrcIP,
+			Username:    truncate(data.Username, 128),
+			Password:    truncate(data.Password, 128),
+			Hash:        truncate(data.Hash, 256),
+			AttemptTime: event.Time,
+		})
+	}
+	if len(list) == 0 {
+		return
+	}
+
+	if err := global.DB.Create(&list).Error; err != nil {
+		logrus.Errorf("凭据尝试入库失败 %s", err)
+	}
+}
+
+// truncate 按字符截断超出字段长度的内容，攻击者输入中的非法UTF-8字节替换为U+FFFD，避免入库失败
+func truncate(str string, size int) string {
+	runes := []rune(strings.ToValidUTF8(str, "\uFFFD"))
+	if len(runes) > size {
+		return string(runes[:size])
+	}
+	return string(runes)
+}
@@ -0,0 +1,2 @@
+// Package credential_service 凭据采集服务包，从模拟器交互会话中提取攻击者尝试的用户名与口令
+package credential_service
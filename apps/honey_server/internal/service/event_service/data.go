@@ -0,0 +1,57 @@
+package event_service
+
+// File: service/event_service/data.go
+// Description: 各类型事件的内容结构体
+
+// NodeData 节点上下线事件内容
+type NodeData struct {
+	NodeID uint   `json:"nodeID"` // 节点ID
+	Uid    string `json:"uid"`    // 节点UID
+	Title  string `json:"title"`  // 节点名称
+}
+
+// HoneyIpStatusData 诱捕IP状态变化事件内容
+type HoneyIpStatusData struct {
+	HoneyIpID uint   `json:"honeyIpID"` // 诱捕IP ID
+	IP        string `json:"ip"`        // 诱捕IP
+	Status    int8   `json:"status"`    // 诱捕IP状态
+	ErrorMsg  string `json:"errorMsg"`  // 错误信息
+	Deleted   bool   `json:"deleted"`   // 是否已删除
+}
+
+// NetScanData 网络扫描进度及完成事件内容
+type NetScanData struct {
+	NetID    uint    `json:"netID"`    // 网络ID
+	Progress float64 `json:"progress"` // 扫描进度
+	IP       string  `json:"ip"`       // 本次发现的主机IP（进度事件）
+}
+
+// AttackSessionData 攻击会话事件内容
+type AttackSessionData struct {
+	Kind      string `json:"kind"`      // 会话来源 interaction 内置模拟器 tunnel 隧道转发
+	SessionID string `json:"sessionID"` // 会话ID
+	Protocol  string `json:"protocol"`  // 模拟器协议（隧道会话为空）
+	HoneyIpID uint   `json:"honeyIpID"` // 诱捕IP ID
+	SrcIP     string `json:"srcIP"`     // 攻击者IP
+	SrcPort   int    `json:"srcPort"`   // 攻击者端口
+	DstIP     string `json:"dstIP"`     // 诱捕IP
+	DstPort   int    `json:"dstPort"`   // 诱捕端口
+}
+
+// HostChangeData 主机变化事件内容
+type HostChangeData struct {
+	NetID  uint   `json:"netID"`  // 网络ID
+	Change string `json:"change"` // 变化类型 new 新增 update 变更 delete 消失
+	IP     string `json:"ip"`     // 主机IP
+	Mac    string `json:"mac"`    // 主机MAC地址
+	Manuf  string `json:"manuf"`  // 厂商
+}
+
+// LoginLockoutData 登录锁定事件内容
+type LoginLockoutData struct {
+	Kind      string `json:"kind"`      // 锁定对象 user 用户名 ip 客户端IP
+	Username  string `json:"username"`  // 触发锁定的登录用户名
+	IP        string `json:"ip"`        // 触发锁定的客户端IP
+	FailCount int64  `json:"failCount"` // 统计窗口内的失败次数
+	Until     int64  `json:"until"`     // 锁定截止时间（Unix秒）
+}
@@ -0,0 +1,2 @@
+// Package event_service 实时事件服务，汇集节点上下线、诱捕IP状态变化、网络扫描进度、攻击会话等事件，推送给订阅的前端
+package event_service
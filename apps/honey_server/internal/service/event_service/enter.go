@@ -0,0 +1,166 @@
+package event_service
+
+// File: service/event_service/enter.go
+// Description: 实时事件的发布与订阅。事件按递增ID保存在内存环形缓冲区中，
+// 订阅者断线重连时携带最后收到的事件ID即可补发缓冲区内的后续事件；订阅者接收过慢时断开，由客户端重连补发
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	historySize          = 1000 // 保留用于断线补发的事件数量
+	subscriberBufferSize = 1024 // 订阅者消息缓冲数量，大于补发数量，避免补发时即被断开
+)
+
+// 事件类型
+const (
+	NodeOnline      = "node_online"       // 节点上线
+	NodeOffline     = "node_offline"      // 节点下线
+	HoneyIpStatus   = "honey_ip_status"   // 诱捕IP状态变化
+	NetScanProgress = "net_scan_progress" // 网络扫描进度
+	NetScanComplete = "net_scan_complete" // 网络扫描完成
+	AttackSession   = "attack_session"    // 新的攻击会话
+	HostChange      = "host_change"       // 网络扫描发现主机变化
+	LoginLockout    = "login_lockout"     // 登录失败次数过多被锁定
+)
+
+// typePermissionMap 事件类型 -> 接收该类事件所需的权限
+var typePermissionMap = map[string]string{
+	NodeOnline:      "node:read",
+	NodeOffline:     "node:read",
+	HoneyIpStatus:   "honey_ip:read",
+	NetScanProgress: "net:read",
+	NetScanComplete: "net:read",
+	AttackSession:   "interaction:read",
+	HostChange:      "host:read",
+	LoginLockout:    "user:read",
+}
+
+// PermissionList 返回接收各类事件所需的权限列表（去重）
+func PermissionList() []string {
+	var list []string
+	seen := map[string]bool{}
+	for _, code := range typePermissionMap {
+		if !seen[code] {
+			seen[code] = true
+			list = append(list, code)
+		}
+	}
+	return list
+}
+
+// Event 实时事件
+type Event struct {
+	ID     int64     `json:"id"`     // 事件ID，单调递增
+	Type   string    `json:"type"`   // 事件类型
+	NodeID uint      `json:"nodeID"` // 事件关联的节点ID，用于按节点过滤
+	Time   time.Time `json:"time"`   // 事件时间
+	Data   any       `json:"data"`   // 事件内容
+}
+
+// Filter 订阅过滤条件，字段为空时不过滤
+type Filter struct {
+	TypeList      []string        // 事件类型列表
+	NodeID        uint            // 节点ID
+	PermissionMap map[string]bool // 订阅者拥有的权限，只推送有权查看的事件（系统内部订阅为空时不过滤）
+}
+
+// match 判断事件是否匹配过滤条件
+func (f Filter) match(event Event) bool {
+	if f.PermissionMap != nil && !f.PermissionMap[typePermissionMap[event.Type]] {
+		return false
+	}
+	if f.NodeID != 0 && f.NodeID != event.NodeID {
+		return false
+	}
+	if len(f.TypeList) == 0 {
+		return true
+	}
+	for _, t := range f.TypeList {
+		if t == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscriber 事件订阅者
+type Subscriber struct {
+	filter Filter
+	C      chan Event    // 推送的事件
+	Done   chan struct{} // 订阅者接收过慢被断开时关闭
+	once   sync.Once
+}
+
+// send 非阻塞推送事件，缓冲已满时断开订阅者
+func (s *Subscriber) send(event Event) {
+	select {
+	case s.C <- event:
+	default:
+		s.once.Do(func() { close(s.Done) })
+	}
+}
+
+var (
+	mutex         sync.RWMutex
+	lastID        = time.Now().UnixMilli() * 1000 // 以启动时间作为ID起点，服务重启后事件ID仍然递增
+	history       = make([]Event, 0, historySize)
+	subscriberMap = map[*Subscriber]struct{}{}
+)
+
+// Publish 发布事件
+func Publish(eventType string, nodeID uint, data any) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	lastID++
+	event := Event{
+		ID:     lastID,
+		Type:   eventType,
+		NodeID: nodeID,
+		Time:   time.Now(),
+		Data:   data,
+	}
+	if len(history) == historySize {
+		copy(history, history[1:])
+		history = history[:historySize-1]
+	}
+	history = append(history, event)
+
+	for s := range subscriberMap {
+		if s.filter.match(event) {
+			s.send(event)
+		}
+	}
+}
+
+// Subscribe 订阅事件，lastEventID不为空时先补发缓冲区中该ID之后的匹配事件
+func Subscribe(filter Filter, lastEventID string) *Subscriber {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	s := &Subscriber{
+		filter: filter,
+		C:      make(chan Event, subscriberBufferSize),
+		Done:   make(chan struct{}),
+	}
+	if id, err := strconv.ParseInt(lastEventID, 10, 64); err == nil {
+		for _, event := range history {
+			if event.ID > id && filter.match(event) {
+				s.send(event)
+			}
+		}
+	}
+	subscriberMap[s] = struct{}{}
+	return s
+}
+
+// Unsubscribe 取消订阅
+func Unsubscribe(s *Subscriber) {
+	mutex.Lock()
+	delete(subscriberMap, s)
+	mutex.Unlock()
+}
@@ -9,7 +9,10 @@ import (
 	"sync"
 	"time"
 
+	"honey_server/internal/global"
+	"honey_server/internal/models"
 	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/event_service"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/metadata"
@@ -65,6 +68,7 @@ func (s NodeService) Command(stream node_rpc.NodeService_CommandServer) error {
 	mapMutex.Unlock()
 
 	logrus.Infof("节点 %s 已连接", nodeID)
+	publishNodeEvent(event_service.NodeOnline, nodeID)
 
 	// 启动发送和接收协程，等待组计数+2
 	cmd.wg.Add(2)
@@ -87,9 +91,21 @@ func (s NodeService) Command(stream node_rpc.NodeService_CommandServer) error {
 	mapMutex.Unlock()
 
 	logrus.Infof("节点 %s 已断开连接", nodeID)
+	publishNodeEvent(event_service.NodeOffline, nodeID)
 	return nil
 }
 
+// publishNodeEvent 发布节点上下线事件
+func publishNodeEvent(eventType string, nodeUid string) {
+	var nodeModel models.NodeModel
+	global.DB.Take(&nodeModel, "uid = ?", nodeUid)
+	event_service.Publish(eventType, nodeModel.ID, event_service.NodeData{
+		NodeID: nodeModel.ID,
+		Uid:    nodeUid,
+		Title:  nodeModel.Title,
+	})
+}
+
 // sendLoop 命令发送循环协程
 // 功能：从ReqChan读取命令并通过grpc流发送给节点，处理发送错误及停止信号
 func (c *Command) sendLoop() {
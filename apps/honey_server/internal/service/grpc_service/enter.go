@@ -4,8 +4,10 @@ package grpc_service
 // Description: gRPC服务，负责启动gRPC服务器，注册节点服务处理器，处理节点注册等gRPC请求。
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"honey_server/internal/global"
 	"honey_server/internal/rpc/node_rpc"
 	"io/ioutil"
@@ -14,6 +16,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 // NodeService 节点服务的gRPC处理器结构体
@@ -76,3 +79,16 @@ func Run() {
 		logrus.Fatalf("Failed to serve: %v", err)
 	}
 }
+
+// peerNodeUid 从双向认证的客户端证书中获取节点UID（证书CN为节点UID，见cert/cert.sh）
+func peerNodeUid(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", errors.New("获取连接信息失败")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return "", errors.New("缺少客户端证书")
+	}
+	return tlsInfo.State.PeerCertificates[0].Subject.CommonName, nil
+}
@@ -0,0 +1,86 @@
+package grpc_service
+
+// File: service/grpc_service/report_interaction.go
+// Description: 实现内置模拟器交互会话上报的gRPC接口处理逻辑，将节点上报的会话关联到诱捕IP与诱捕端口后入库，并提取其中的凭据尝试
+
+import (
+	"context"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/credential_service"
+	"honey_server/internal/service/event_service"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm/clause"
+)
+
+// ReportInteraction 交互会话上报的gRPC接口实现
+func (NodeService) ReportInteraction(ctx context.Context, request *node_rpc.ReportInteractionRequest) (pd *node_rpc.BaseResponse, err error) {
+	pd = new(node_rpc.BaseResponse) // 初始化gRPC响应结构体
+
+	var nodeModel models.NodeModel
+	if err1 := global.DB.Take(&nodeModel, "uid = ?", request.NodeUid).Error; err1 != nil {
+		return nil, fmt.Errorf("节点不存在 %s", request.NodeUid)
+	}
+
+	var count int
+	for _, item := range request.InteractionList {
+		model := models.InteractionModel{
+			NodeID:     nodeModel.ID,
+			SessionID:  item.SessionID,
+			Protocol:   item.Protocol,
+			SrcIP:      item.SrcIP,
+			SrcPort:    int(item.SrcPort),
+			DstIP:      item.DstIP,
+			DstPort:    int(item.DstPort),
+			StartTime:  time.UnixMilli(item.StartTime),
+			EndTime:    time.UnixMilli(item.EndTime),
+			EventCount: len(item.EventList),
+		}
+		for _, event := range item.EventList {
+			model.EventList = append(model.EventList, models.InteractionEvent{
+				Time:      time.UnixMilli(event.Time),
+				EventType: event.EventType,
+				Data:      event.Data,
+			})
+		}
+
+		// 关联诱捕IP及诱捕端口（诱捕IP已删除时仍保留会话记录）
+		var honeyPortModel models.HoneyPortModel
+		global.DB.Joins("join honey_ip_models on honey_ip_models.id = honey_port_models.honey_ip_id").
+			Where("honey_ip_models.node_id = ? and honey_ip_models.ip = ? and honey_ip_models.deleted_at is null", nodeModel.ID, item.DstIP).
+			Where("honey_port_models.port = ?", item.DstPort).
+			Take(&honeyPortModel)
+		model.HoneyIpID = honeyPortModel.HoneyIpID
+		model.HoneyPortID = honeyPortModel.ID
+
+		// 节点上报失败重试时会话可能重复，按会话ID忽略已入库的记录
+		result := global.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&model)
+		if result.Error != nil {
+			logrus.Errorf("交互会话入库失败 %s", result.Error)
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		count++
+		event_service.Publish(event_service.AttackSession, model.NodeID, event_service.AttackSessionData{
+			Kind:      "interaction",
+			SessionID: model.SessionID,
+			Protocol:  model.Protocol,
+			HoneyIpID: model.HoneyIpID,
+			SrcIP:     model.SrcIP,
+			SrcPort:   model.SrcPort,
+			DstIP:     model.DstIP,
+			DstPort:   model.DstPort,
+		})
+
+		// 提取会话中的认证事件作为凭据尝试
+		credential_service.Capture(model)
+	}
+	logrus.Infof("节点 %s 上报交互会话 %d个", nodeModel.Title, count)
+	return
+}
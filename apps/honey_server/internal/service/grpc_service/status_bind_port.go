@@ -0,0 +1,56 @@
+package grpc_service
+
+// File: service/grpc_service/status_bind_port.go
+// Description: 实现端口绑定状态上报的gRPC接口处理逻辑，按端口持久化节点上的实际监听结果
+
+import (
+	"context"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StatusBindPort 端口绑定状态上报的gRPC接口实现
+func (NodeService) StatusBindPort(ctx context.Context, request *node_rpc.StatusBindPortRequest) (pd *node_rpc.BaseResponse, err error) {
+	pd = new(node_rpc.BaseResponse) // 初始化gRPC响应结构体
+
+	// 根据节点UID及IP定位诱捕IP（同一节点上的诱捕IP唯一）
+	var nodeModel models.NodeModel
+	if err1 := global.DB.Take(&nodeModel, "uid = ?", request.NodeUid).Error; err1 != nil {
+		return nil, fmt.Errorf("节点不存在 %s", request.NodeUid)
+	}
+	var honeyIPModel models.HoneyIpModel
+	if err1 := global.DB.Take(&honeyIPModel, "node_id = ? and ip = ?", nodeModel.ID, request.Ip).Error; err1 != nil {
+		return nil, fmt.Errorf("诱捕ip不存在 %s", request.Ip)
+	}
+
+	// 逐个端口更新绑定状态：2 监听中 3 绑定失败
+	for _, port := range request.PortList {
+		var status int8 = 2
+		if !port.Success {
+			status = 3
+			logrus.Errorf("诱捕端口绑定失败 %s:%d %s", request.Ip, port.Port, port.ErrMsg)
+		}
+		errMsg := truncateRunes(port.ErrMsg, 128)
+		global.DB.Model(&models.HoneyPortModel{}).
+			Where("honey_ip_id = ? and port = ?", honeyIPModel.ID, port.Port).
+			Updates(map[string]any{
+				"status":    status,
+				"error_msg": errMsg,
+			})
+	}
+
+	return // 返回gRPC响应
+}
+
+// truncateRunes 按字符截断超出字段长度的内容，不会截断多字节字符
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -9,6 +9,7 @@ import (
 	"honey_server/internal/global"
 	"honey_server/internal/models"
 	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/event_service"
 
 	"github.com/sirupsen/logrus"
 )
@@ -39,5 +40,12 @@ func (NodeService) StatusCreateIP(ctx context.Context, request *node_rpc.StatusC
 		ErrorMsg: request.ErrMsg,  // 错误信息
 	})
 
+	event_service.Publish(event_service.HoneyIpStatus, honeyIPModel.NodeID, event_service.HoneyIpStatusData{
+		HoneyIpID: honeyIPModel.ID,
+		IP:        honeyIPModel.IP,
+		Status:    status,
+		ErrorMsg:  request.ErrMsg,
+	})
+
 	return // 返回gRPC响应
 }
@@ -9,6 +9,7 @@ import (
 	"honey_server/internal/global"
 	"honey_server/internal/models"
 	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/event_service"
 
 	"github.com/sirupsen/logrus"
 )
@@ -32,5 +33,14 @@ func (NodeService) StatusDeleteIP(ctx context.Context, request *node_rpc.StatusD
 	// 执行批量删除操作（软删除/硬删除取决于模型配置的gorm标签）
 	global.DB.Delete(&honeyIPList)
 
+	for _, model := range honeyIPList {
+		event_service.Publish(event_service.HoneyIpStatus, model.NodeID, event_service.HoneyIpStatusData{
+			HoneyIpID: model.ID,
+			IP:        model.IP,
+			Status:    model.Status,
+			Deleted:   true,
+		})
+	}
+
 	return // 返回gRPC响应
 }
@@ -1,11 +1,13 @@
 package grpc_service
 
 // File: service/grpc_service/tunnel.go
-// Description: 实现双向流Tunnel接口，建立服务端与目标地址的TCP连接并转发数据，支持双向数据透传（隧道功能）
+// Description: 实现双向流Tunnel接口，建立服务端与目标地址的TCP连接并转发数据，支持双向数据透传（隧道功能），
+// 并录制会话的双向数据
 
 import (
 	"fmt"
 	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/transcript_service"
 	"io"
 	"log"
 	"net"
@@ -28,6 +30,20 @@ func (s *NodeService) Tunnel(stream node_rpc.NodeService_TunnelServer) error {
 	}
 	defer conn.Close() // 函数退出时关闭TCP连接，释放资源
 
+	// 开始录制会话（旧版本节点未携带会话信息时不录制），节点以客户端证书为准，不信任消息中的节点UID
+	nodeUid, err := peerNodeUid(stream.Context())
+	if err != nil {
+		log.Printf("获取节点证书信息失败: %v", err)
+	}
+	recorder := transcript_service.NewRecorder(transcript_service.Session{
+		NodeUid:    nodeUid,
+		SessionID:  req.SessionID,
+		SrcAddr:    req.SrcAddr,
+		LocalAddr:  req.LocalAddr,
+		TargetAddr: req.Address,
+	})
+	defer recorder.Close()
+
 	// 启动goroutine处理"客户端→服务端→目标地址"的数据流向
 	// 从gRPC流接收客户端数据，转发到目标TCP连接
 	go func() {
@@ -42,6 +58,7 @@ func (s *NodeService) Tunnel(stream node_rpc.NodeService_TunnelServer) error {
 			}
 
 			// 将客户端发送的Chunk数据写入目标TCP连接
+			recorder.Write(transcript_service.DirectionIn, req.Chunk)
 			_, err = conn.Write(req.Chunk)
 			if err != nil {
 				log.Printf("写入目标连接失败: %v", err)
@@ -65,6 +82,7 @@ func (s *NodeService) Tunnel(stream node_rpc.NodeService_TunnelServer) error {
 		}
 
 		// 将读取到的数据通过gRPC流发送给客户端
+		recorder.Write(transcript_service.DirectionOut, buffer[:n])
 		err = stream.Send(&node_rpc.TunnelData{
 			Chunk:   buffer[:n], // 仅发送实际读取到的字节（避免空数据）
 			Address: req.Address,
@@ -0,0 +1,2 @@
+// Package honey_ip_service 诱捕IP服务包，包含诱捕IP消息下发、状态核对及重试相关的功能和逻辑
+package honey_ip_service
@@ -0,0 +1,67 @@
+package honey_ip_service
+
+// File: service/honey_ip_service/query_status.go
+// Description: 通过节点命令流查询诱捕IP网卡在节点上的实际状态
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/grpc_service"
+	"time"
+)
+
+// queryStatusTimeout 查询网卡状态的超时时间
+const queryStatusTimeout = 10 * time.Second
+
+// QueryLinkStatus 查询节点上指定网卡的实际状态，返回 网卡名称 → 网卡状态
+func QueryLinkStatus(nodeUID string, linkNameList []string) (map[string]*node_rpc.IpLinkStatusMessage, error) {
+	statusMap := map[string]*node_rpc.IpLinkStatusMessage{}
+	if len(linkNameList) == 0 {
+		return statusMap, nil
+	}
+
+	cmd, ok := grpc_service.GetNodeCommand(nodeUID)
+	if !ok {
+		return nil, errors.New("节点离线中")
+	}
+
+	taskID := fmt.Sprintf("ip-status-%d", time.Now().UnixNano())
+	req := &node_rpc.CmdRequest{
+		CmdType: node_rpc.CmdType_cmdIpStatusType,
+		TaskID:  taskID,
+		IpStatusInMessage: &node_rpc.IpStatusInMessage{
+			LinkNameList: linkNameList,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryStatusTimeout)
+	defer cancel()
+
+	select {
+	case cmd.ReqChan <- req:
+	case <-ctx.Done():
+		return nil, errors.New("发送命令超时")
+	}
+
+	for {
+		select {
+		case response := <-cmd.ResChan:
+			// 非当前任务的响应放回通道，避免影响其他任务
+			if response.TaskID != taskID {
+				select {
+				case cmd.ResChan <- response:
+				case <-ctx.Done():
+				}
+				continue
+			}
+			for _, status := range response.IpStatusOutMessage.GetLinkList() {
+				statusMap[status.LinkName] = status
+			}
+			return statusMap, nil
+		case <-ctx.Done():
+			return nil, errors.New("获取响应超时")
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package honey_ip_service
+
+// File: service/honey_ip_service/retry.go
+// Description: 诱捕IP重新下发逻辑：按待完成的操作重新下发，创建中或创建失败的诱捕IP重新下发创建消息，删除中或删除失败的诱捕IP重新下发删除消息
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Retry 重新下发单个诱捕IP的创建或删除消息，model需预加载NetModel与NodeModel
+// auto为true表示过渡状态清扫触发，会累计自动重试次数；手动重试会清零重试次数
+func Retry(model models.HoneyIpModel, auto bool) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		data := map[string]any{}
+		if model.Status == 4 || model.Operation == 2 {
+			// 删除中、删除失败：重新下发删除消息，状态置为删除中
+			err = SendDeleteMsg(tx, model.NodeModel.Uid, []models.HoneyIpModel{model})
+			data["status"] = 4
+		} else {
+			// 创建中、创建失败：重新下发创建消息，状态置为创建中
+			err = SendCreateMsg(tx, model)
+			data["status"] = 1
+		}
+		data["error_msg"] = ""
+		if err != nil {
+			return err
+		}
+
+		if auto {
+			data["retry_count"] = model.RetryCount + 1
+		} else {
+			data["retry_count"] = 0
+		}
+		// 更新时会刷新updated_at，清扫器据此重新计算超时
+		return tx.Model(&model).Updates(data).Error
+	})
+}
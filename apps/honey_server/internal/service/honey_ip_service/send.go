@@ -0,0 +1,75 @@
+package honey_ip_service
+
+// File: service/honey_ip_service/send.go
+// Description: 构建诱捕IP的创建、删除消息并写入发件箱，供接口层、过渡状态清扫及手动重试复用
+
+import (
+	"fmt"
+	"honey_server/internal/models"
+	"honey_server/internal/service/mq_service"
+
+	"gorm.io/gorm"
+)
+
+// LinkName 诱捕IP在节点上对应的macvlan子接口名称（与节点约定：hy_+诱捕IPID）
+func LinkName(honeyIPID uint) string {
+	return fmt.Sprintf("hy_%d", honeyIPID)
+}
+
+// IsTan 是否为探针IP（与网络的探针IP相同，节点上复用主网卡，不创建子接口）
+func IsTan(model models.HoneyIpModel) bool {
+	return model.NetModel.IP == model.IP
+}
+
+// SendCreateMsg 将创建诱捕IP的消息写入发件箱，model需预加载NetModel与NodeModel
+func SendCreateMsg(tx *gorm.DB, model models.HoneyIpModel) error {
+	return mq_service.SendCreateIPMsg(tx, model.NodeModel.Uid, createIPRequest(model))
+}
+
+// SendCreateBatchMsg 将批量创建诱捕IP的消息按节点合并后写入发件箱，list需预加载NetModel与NodeModel
+func SendCreateBatchMsg(tx *gorm.DB, list []models.HoneyIpModel) error {
+	var nodeMap = map[string]*mq_service.CreateIPBatchRequest{}
+	var nodeUIDList []string // 保持节点顺序稳定
+	for _, model := range list {
+		req, ok := nodeMap[model.NodeModel.Uid]
+		if !ok {
+			req = &mq_service.CreateIPBatchRequest{}
+			nodeMap[model.NodeModel.Uid] = req
+			nodeUIDList = append(nodeUIDList, model.NodeModel.Uid)
+		}
+		req.IpList = append(req.IpList, createIPRequest(model))
+	}
+	for _, nodeUID := range nodeUIDList {
+		if err := mq_service.SendCreateIPBatchMsg(tx, nodeUID, *nodeMap[nodeUID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createIPRequest 构建单个诱捕IP的创建消息
+func createIPRequest(model models.HoneyIpModel) mq_service.CreateIPRequest {
+	return mq_service.CreateIPRequest{
+		HoneyIPID: model.ID,
+		IP:        model.IP,
+		Mask:      model.NetModel.Mask,
+		Network:   model.NetModel.Network,
+		IsTan:     IsTan(model),
+	}
+}
+
+// SendDeleteMsg 将批量删除诱捕IP的消息写入发件箱，list需属于同一节点并预加载NetModel
+func SendDeleteMsg(tx *gorm.DB, nodeUID string, list []models.HoneyIpModel) error {
+	req := mq_service.DeleteIPRequest{
+		LogID: "",
+	}
+	for _, model := range list {
+		req.IpList = append(req.IpList, mq_service.IpInfo{
+			HoneyIPID: model.ID,
+			IP:        model.IP,
+			Network:   model.Network,
+			IsTan:     IsTan(model),
+		})
+	}
+	return mq_service.SendDeleteIPMsg(tx, nodeUID, req)
+}
@@ -0,0 +1,125 @@
+package honey_ip_service
+
+// File: service/honey_ip_service/sweeper.go
+// Description: 诱捕IP过渡状态清扫器，定期检查长时间停留在创建中（1）、删除中（4）的诱捕IP，
+// 向节点查询网卡实际状态后补全结果、重新下发消息或标记为失败，节点离线时跳过
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/grpc_service"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	sweepInterval = time.Minute     // 清扫间隔
+	staleTimeout  = 5 * time.Minute // 过渡状态超时时间（距最后一次更新）
+	maxAutoRetry  = 3               // 自动重试次数上限，超过后标记为失败
+)
+
+// RunSweeper 启动过渡状态清扫循环（阻塞运行，需以协程方式启动）
+func RunSweeper() {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		sweep()
+	}
+}
+
+// sweep 查询超时的过渡状态诱捕IP，按节点分组核对
+func sweep() {
+	var list []models.HoneyIpModel
+	global.DB.Preload("NodeModel").Preload("NetModel").
+		Find(&list, "status in ? and updated_at < ?", []int8{1, 4}, time.Now().Add(-staleTimeout))
+	if len(list) == 0 {
+		return
+	}
+
+	var nodeMap = map[string][]models.HoneyIpModel{}
+	for _, model := range list {
+		nodeMap[model.NodeModel.Uid] = append(nodeMap[model.NodeModel.Uid], model)
+	}
+
+	for nodeUID, nodeList := range nodeMap {
+		sweepNode(nodeUID, nodeList)
+	}
+}
+
+// sweepNode 核对单个节点上超时的诱捕IP
+func sweepNode(nodeUID string, list []models.HoneyIpModel) {
+	// 节点离线：无法核对也无法重新下发，跳过等待节点上线后再处理
+	if _, ok := grpc_service.GetNodeCommand(nodeUID); !ok {
+		return
+	}
+
+	// 查询非探针IP的网卡实际状态（探针IP复用主网卡，无需查询）
+	var linkNameList []string
+	for _, model := range list {
+		if !IsTan(model) {
+			linkNameList = append(linkNameList, LinkName(model.ID))
+		}
+	}
+	statusMap, err := QueryLinkStatus(nodeUID, linkNameList)
+	if err != nil {
+		logrus.Errorf("查询节点 %s 网卡状态失败 %s", nodeUID, err)
+		return
+	}
+
+	for _, model := range list {
+		resolve(model, statusMap[LinkName(model.ID)])
+	}
+}
+
+// resolve 根据网卡实际状态处理单个超时的诱捕IP
+func resolve(model models.HoneyIpModel, link *node_rpc.IpLinkStatusMessage) {
+	exists := link != nil && link.Exists
+	switch model.Status {
+	case 1:
+		// 网卡已创建但状态回调丢失：直接补全为运行中
+		if !IsTan(model) && exists {
+			global.DB.Model(&model).Updates(map[string]any{
+				"status":      2,
+				"mac":         link.Mac,
+				"network":     link.LinkName,
+				"error_msg":   "",
+				"retry_count": 0,
+			})
+			logrus.Infof("诱捕ip %s 创建状态已补全", model.IP)
+			return
+		}
+		retryOrFail(model, "创建超时")
+	case 4:
+		// 网卡已不存在（或探针IP）但删除回调丢失：直接完成删除
+		if IsTan(model) || !exists {
+			global.DB.Delete(&model)
+			logrus.Infof("诱捕ip %s 删除状态已补全", model.IP)
+			return
+		}
+		retryOrFail(model, "删除超时，节点网卡仍存在")
+	}
+}
+
+// retryOrFail 未超过自动重试上限时重新下发消息，否则标记为失败
+func retryOrFail(model models.HoneyIpModel, reason string) {
+	if model.RetryCount >= maxAutoRetry {
+		markFailed(model, reason)
+		return
+	}
+	if err := Retry(model, true); err != nil {
+		logrus.Errorf("诱捕ip %s 自动重试失败 %s", model.IP, err)
+		return
+	}
+	logrus.Warnf("诱捕ip %s %s，第%d次自动重试", model.IP, reason, model.RetryCount+1)
+}
+
+// markFailed 将诱捕IP标记为失败并记录原因
+func markFailed(model models.HoneyIpModel, reason string) {
+	global.DB.Model(&model).Updates(map[string]any{
+		"status":    3,
+		"error_msg": reason,
+	})
+	logrus.Errorf("诱捕ip %s %s，已标记为失败", model.IP, reason)
+}
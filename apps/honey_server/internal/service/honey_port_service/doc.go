@@ -0,0 +1,2 @@
+// Package honey_port_service 诱捕端口服务包，包含诱捕端口配置同步及端口绑定消息下发相关的功能和逻辑
+package honey_port_service
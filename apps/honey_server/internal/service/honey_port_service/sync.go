@@ -0,0 +1,124 @@
+package honey_port_service
+
+// File: service/honey_port_service/sync.go
+// Description: 诱捕端口配置同步：按期望的端口列表增量更新诱捕IP的端口配置，并在同一事务中下发绑定端口消息
+
+import (
+	"fmt"
+	"honey_server/internal/models"
+	"honey_server/internal/service/mq_service"
+
+	"gorm.io/gorm"
+)
+
+// SyncResult 端口同步结果
+type SyncResult struct {
+	AddCount    int // 新增端口数
+	RemoveCount int // 删除端口数
+}
+
+// SyncPorts 将诱捕IP的端口配置同步为portList，需在事务中调用
+// honeyIP需预加载NodeModel；端口重复或服务不存在时返回错误
+func SyncPorts(tx *gorm.DB, honeyIP models.HoneyIpModel, portList []models.HostTemplatePort) (result SyncResult, err error) {
+	// 端口配置合法性校验：检查端口是否重复并收集关联的服务ID
+	var portMap = map[int]struct{}{}
+	var serviceIDList []uint
+	for _, port := range portList {
+		serviceIDList = append(serviceIDList, port.ServiceID)
+		portMap[port.Port] = struct{}{}
+	}
+	if len(portMap) != len(portList) {
+		return result, fmt.Errorf("端口重复")
+	}
+
+	// 查询所有关联的服务信息，验证服务ID有效性
+	var serviceList []models.ServiceModel
+	tx.Find(&serviceList, "id in ?", serviceIDList)
+	var serviceMap = map[uint]models.ServiceModel{}
+	for _, model := range serviceList {
+		serviceMap[model.ID] = model
+	}
+
+	// 查询该诱捕IP下已配置的端口信息
+	var existingList []models.HoneyPortModel
+	tx.Find(&existingList, "honey_ip_id = ?", honeyIP.ID)
+	var existingMap = map[int]models.HoneyPortModel{}
+	for _, port := range existingList {
+		existingMap[port.Port] = port
+	}
+
+	// 计算需要新增及需要变更服务的端口
+	for _, port := range portList {
+		service, ok := serviceMap[port.ServiceID]
+		if !ok {
+			return result, fmt.Errorf("服务%d不存在", port.ServiceID)
+		}
+
+		existing, exists := existingMap[port.Port]
+		if exists && existing.ServiceID == port.ServiceID && existing.DstIP == service.IP && existing.DstPort == service.Port && existing.Emulator == service.Emulator {
+			continue // 配置未变化
+		}
+		if exists {
+			// 端口关联的服务发生变化：删除旧配置后重新创建
+			if err = tx.Delete(&existing).Error; err != nil {
+				return
+			}
+			result.RemoveCount++
+		}
+		err = tx.Create(&models.HoneyPortModel{
+			NodeID:    honeyIP.NodeID,
+			NetID:     honeyIP.NetID,
+			HoneyIpID: honeyIP.ID,
+			Port:      port.Port,
+			ServiceID: port.ServiceID,
+			DstIP:     service.IP,   // 从关联服务获取目标IP
+			DstPort:   service.Port, // 从关联服务获取目标端口
+			Emulator:  service.Emulator,
+			Status:    1, // 绑定中，等待节点上报监听结果
+		}).Error
+		if err != nil {
+			return
+		}
+		result.AddCount++
+	}
+
+	// 删除期望列表中不存在的端口
+	for port, model := range existingMap {
+		if _, ok := portMap[port]; ok {
+			continue
+		}
+		if err = tx.Delete(&model).Error; err != nil {
+			return
+		}
+		result.RemoveCount++
+	}
+
+	err = SendBindPortMsg(tx, honeyIP)
+	return
+}
+
+// SendBindPortMsg 按诱捕IP当前的全部端口配置下发绑定端口消息，需在事务中调用
+func SendBindPortMsg(tx *gorm.DB, honeyIP models.HoneyIpModel) error {
+	var portList []models.HoneyPortModel
+	tx.Preload("ServiceModel").Find(&portList, "honey_ip_id = ?", honeyIP.ID)
+
+	req := mq_service.BindPortRequest{
+		IP:    honeyIP.IP,
+		LogID: "",
+	}
+	for _, model := range portList {
+		info := mq_service.PortInfo{
+			IP:       honeyIP.IP,
+			Port:     model.Port,
+			DestIP:   model.DstIP,
+			DestPort: model.DstPort,
+			Emulator: model.Emulator,
+		}
+		// 模拟器的设备配置随服务下发，节点据此模拟设备标识及数据点
+		if model.Emulator != "" {
+			info.EmulatorConfig = &model.ServiceModel.EmulatorConfig
+		}
+		req.PortList = append(req.PortList, info)
+	}
+	return mq_service.SendBindPortMsg(tx, honeyIP.NodeModel.Uid, req)
+}
@@ -0,0 +1,61 @@
+package honey_port_service
+
+// File: service/honey_port_service/template.go
+// Description: 主机模板应用：将主机模板的端口配置应用到诱捕IP并保持关联，模板修改后可重新同步所有关联的诱捕IP
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ApplyTemplate 将主机模板应用到诱捕IP：同步端口配置并记录关联的模板，需在事务中调用
+func ApplyTemplate(tx *gorm.DB, honeyIP models.HoneyIpModel, template models.HostTemplateModel) (SyncResult, error) {
+	result, err := SyncPorts(tx, honeyIP, template.PortList)
+	if err != nil {
+		return result, err
+	}
+	err = tx.Model(&honeyIP).Update("host_template_id", template.ID).Error
+	return result, err
+}
+
+// TemplateSyncItem 单个诱捕IP的模板同步结果
+type TemplateSyncItem struct {
+	HoneyIPID   uint   `json:"honeyIpID"`   // 诱捕IP ID
+	IP          string `json:"ip"`          // 诱捕IP
+	Success     bool   `json:"success"`     // 是否同步成功
+	AddCount    int    `json:"addCount"`    // 新增端口数
+	RemoveCount int    `json:"removeCount"` // 删除端口数
+	Msg         string `json:"msg"`         // 失败原因
+}
+
+// PropagateTemplate 将主机模板重新同步到所有使用该模板的诱捕IP，每个诱捕IP独立事务
+func PropagateTemplate(template models.HostTemplateModel) (list []TemplateSyncItem) {
+	var honeyIPList []models.HoneyIpModel
+	global.DB.Preload("NodeModel").Find(&honeyIPList, "host_template_id = ?", template.ID)
+
+	for _, honeyIP := range honeyIPList {
+		item := TemplateSyncItem{HoneyIPID: honeyIP.ID, IP: honeyIP.IP}
+		// 删除中、失败的诱捕IP不下发端口
+		if honeyIP.Status == 3 || honeyIP.Status == 4 {
+			item.Msg = "诱捕ip状态异常，跳过"
+			list = append(list, item)
+			continue
+		}
+		var result SyncResult
+		err := global.DB.Transaction(func(tx *gorm.DB) (err error) {
+			result, err = ApplyTemplate(tx, honeyIP, template)
+			return
+		})
+		if err != nil {
+			item.Msg = err.Error()
+		} else {
+			item.Success = true
+			item.AddCount = result.AddCount
+			item.RemoveCount = result.RemoveCount
+		}
+		list = append(list, item)
+	}
+	return list
+}
@@ -1,2 +1,2 @@
-// Package log_service 用户登录日志记录服务
+// Package log_service 日志记录服务，包括用户登录日志与操作审计日志
 package log_service
@@ -27,23 +27,23 @@ func NewLoginLog(c *gin.Context) *LoginLogService {
 
 // 记录登录成功日志
 func (l LoginLogService) SuccessLog(userID uint, username string) {
-	l.save(userID, username, "", "登录成功", true)
+	l.save(userID, username, "登录成功", true)
 }
 
 // 记录登录失败日志
-func (l LoginLogService) FailLog(username string, password string, title string) {
-	l.save(0, username, password, title, false)
+// 不记录尝试的密码，避免明文密码落库
+func (l LoginLogService) FailLog(username string, title string) {
+	l.save(0, username, title, false)
 }
 
 // 日志统一写入方法，成功与失败均由此函数落库
-func (l LoginLogService) save(userID uint, username string, password string, title string, loginStatus bool) {
+func (l LoginLogService) save(userID uint, username string, title string, loginStatus bool) {
 	global.DB.Create(&models.LogModel{
 		Type:        1,           // 1 代表登录日志
 		IP:          l.IP,        // 客户端 IP
 		Addr:        l.Addr,      // 地址
 		UserID:      userID,      // 用户ID
 		Username:    username,    // 用户名
-		Pwd:         password,    // 密码（失败时记录）
 		LoginStatus: loginStatus, // 登录状态 true=成功 false=失败
 		Title:       title,       // 日志标题
 	})
@@ -0,0 +1,2 @@
+// Package login_limit_service 登录防暴力破解服务，按用户名和客户端IP统计登录失败次数，递增等待并临时锁定
+package login_limit_service
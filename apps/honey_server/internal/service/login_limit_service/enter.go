@@ -0,0 +1,226 @@
+package login_limit_service
+
+// File: service/login_limit_service/enter.go
+// Description: 登录失败计数与锁定。
+// login_fail:<对象> 统计窗口内的失败次数；login_delay:<对象> 存在时需等待后再试，等待时长随失败次数递增；
+// login_lock:<对象> 锁定信息，过期即解锁；login_lock_count:<对象> 最近的锁定次数，用于加倍锁定时长。
+// 对象为 user:<用户名> 或 ip:<客户端IP>
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/service/event_service"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 锁定对象类型
+const (
+	KindUser = "user" // 用户名
+	KindIP   = "ip"   // 客户端IP
+)
+
+const maxDelay = 30 * time.Second // 最长等待时长
+
+// Lock 锁定信息
+type Lock struct {
+	Kind      string `redis:"kind" json:"kind"`           // 锁定对象类型 user ip
+	Value     string `redis:"value" json:"value"`         // 用户名或客户端IP
+	FailCount int64  `redis:"failCount" json:"failCount"` // 锁定时统计窗口内的失败次数
+	LockTime  int64  `redis:"lockTime" json:"lockTime"`   // 锁定时间（Unix秒）
+	Until     int64  `redis:"until" json:"until"`         // 锁定截止时间（Unix秒）
+}
+
+// option 带默认值的配置
+type option struct {
+	maxFail     int64
+	ipMaxFail   int64
+	window      time.Duration
+	lockTime    time.Duration
+	maxLockTime time.Duration
+	delay       time.Duration
+}
+
+func getOption() option {
+	l := global.Config.Login
+	o := option{
+		maxFail:     int64(l.MaxFail),
+		ipMaxFail:   int64(l.IPMaxFail),
+		window:      time.Duration(l.Window) * time.Second,
+		lockTime:    time.Duration(l.LockTime) * time.Second,
+		maxLockTime: time.Duration(l.MaxLockTime) * time.Second,
+		delay:       time.Duration(l.Delay) * time.Second,
+	}
+	if o.maxFail <= 0 {
+		o.maxFail = 5
+	}
+	if o.ipMaxFail <= 0 {
+		o.ipMaxFail = 20
+	}
+	if o.window <= 0 {
+		o.window = 15 * time.Minute
+	}
+	if o.lockTime <= 0 {
+		o.lockTime = 15 * time.Minute
+	}
+	if o.maxLockTime <= 0 {
+		o.maxLockTime = 24 * time.Hour
+	}
+	if o.delay <= 0 {
+		o.delay = time.Second
+	}
+	return o
+}
+
+// target 锁定对象的键名后缀
+func target(kind, value string) string {
+	return kind + ":" + value
+}
+
+// Check 登录前检查用户名与客户端IP是否被锁定或需要等待
+func Check(username, ip string) error {
+	ctx := context.Background()
+	for _, t := range []string{target(KindUser, username), target(KindIP, ip)} {
+		ttl, err := global.Redis.TTL(ctx, "login_lock:"+t).Result()
+		if err != nil {
+			logrus.Errorf("查询登录锁定失败 %s", err)
+			continue
+		}
+		if ttl > 0 {
+			return fmt.Errorf("登录失败次数过多，已被锁定，请%s后重试", formatDuration(ttl))
+		}
+	}
+	for _, t := range []string{target(KindUser, username), target(KindIP, ip)} {
+		ttl, err := global.Redis.PTTL(ctx, "login_delay:"+t).Result()
+		if err == nil && ttl > 0 {
+			return fmt.Errorf("登录过于频繁，请%s后重试", formatDuration(ttl))
+		}
+	}
+	return nil
+}
+
+// Fail 记录一次登录失败，达到次数上限后锁定并发布锁定事件
+func Fail(username, ip string) {
+	o := getOption()
+	fail(o, KindUser, username, o.maxFail, username, ip)
+	fail(o, KindIP, ip, o.ipMaxFail, username, ip)
+}
+
+// fail 累加失败次数，设置递增的等待时长，达到上限后锁定
+func fail(o option, kind, value string, maxFail int64, username, ip string) {
+	ctx := context.Background()
+	t := target(kind, value)
+	count, err := global.Redis.Incr(ctx, "login_fail:"+t).Result()
+	if err != nil {
+		logrus.Errorf("记录登录失败次数失败 %s", err)
+		return
+	}
+	if count == 1 {
+		// 统计窗口从第一次失败开始计算
+		global.Redis.Expire(ctx, "login_fail:"+t, o.window)
+	}
+
+	if count < maxFail {
+		delay := min(time.Duration(count)*o.delay, maxDelay)
+		global.Redis.Set(ctx, "login_delay:"+t, 1, delay)
+		return
+	}
+
+	// 锁定时长随最近的锁定次数加倍
+	lockCount, _ := global.Redis.Incr(ctx, "login_lock_count:"+t).Result()
+	global.Redis.Expire(ctx, "login_lock_count:"+t, o.maxLockTime)
+	lockTime := o.lockTime
+	for i := int64(1); i < lockCount && lockTime < o.maxLockTime; i++ {
+		lockTime *= 2
+	}
+	lockTime = min(lockTime, o.maxLockTime)
+
+	now := time.Now()
+	lock := Lock{
+		Kind:      kind,
+		Value:     value,
+		FailCount: count,
+		LockTime:  now.Unix(),
+		Until:     now.Add(lockTime).Unix(),
+	}
+	pipe := global.Redis.TxPipeline()
+	pipe.HSet(ctx, "login_lock:"+t, lock)
+	pipe.Expire(ctx, "login_lock:"+t, lockTime)
+	pipe.Del(ctx, "login_fail:"+t, "login_delay:"+t)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logrus.Errorf("锁定登录失败 %s", err)
+		return
+	}
+
+	logrus.Warnf("登录失败次数过多，锁定 %s %s，时长 %s", kind, value, lockTime)
+	event_service.Publish(event_service.LoginLockout, 0, event_service.LoginLockoutData{
+		Kind:      kind,
+		Username:  username,
+		IP:        ip,
+		FailCount: count,
+		Until:     lock.Until,
+	})
+}
+
+// Success 登录成功后清除该用户名的失败计数，客户端IP的计数保留到窗口结束
+func Success(username string) {
+	t := target(KindUser, username)
+	global.Redis.Del(context.Background(), "login_fail:"+t, "login_delay:"+t)
+}
+
+// Unlock 解除锁定，同时清除失败计数与锁定次数
+func Unlock(kind, value string) error {
+	if kind != KindUser && kind != KindIP {
+		return errors.New("锁定对象类型错误")
+	}
+	t := target(kind, value)
+	count, err := global.Redis.Del(context.Background(),
+		"login_lock:"+t, "login_fail:"+t, "login_delay:"+t, "login_lock_count:"+t).Result()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("锁定不存在")
+	}
+	return nil
+}
+
+// List 查询当前全部锁定，按锁定时间倒序
+func List() ([]Lock, error) {
+	ctx := context.Background()
+	var list = make([]Lock, 0)
+	iter := global.Redis.Scan(ctx, 0, "login_lock:*", 100).Iterator()
+	for iter.Next(ctx) {
+		var lock Lock
+		if err := global.Redis.HGetAll(ctx, iter.Val()).Scan(&lock); err != nil {
+			return nil, err
+		}
+		if lock.Kind == "" {
+			continue
+		}
+		list = append(list, lock)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LockTime > list[j].LockTime
+	})
+	return list, nil
+}
+
+// formatDuration 将等待时长格式化为便于阅读的文字
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%d小时%d分钟", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%d分钟", int(d.Minutes()+0.5))
+	default:
+		return fmt.Sprintf("%d秒", int(d.Seconds()+0.99))
+	}
+}
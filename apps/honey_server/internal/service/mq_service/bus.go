@@ -0,0 +1,48 @@
+package mq_service
+
+// File: service/mq_service/bus.go
+// Description: 消息总线抽象，将创建IP、删除IP、绑定端口等业务消息的投递与具体传输方式解耦，
+// 支持RabbitMQ（默认）、gRPC命令流、内存三种实现，通过settings.yaml的mq.bus选择
+
+import (
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+
+	"github.com/sirupsen/logrus"
+)
+
+// BusMessage 消息总线上传输的业务消息
+type BusMessage struct {
+	MessageID string // 消息ID，供消费端幂等处理
+	Topic     string // 消息主题（RabbitMQ模式下即交换器名称）
+	NodeUID   string // 目标节点UID
+	Body      string // 消息体（JSON）
+}
+
+// Bus 消息总线接口
+// Publish 需同步返回投递结果：返回nil表示消息已被可靠接收，返回错误时由发件箱按退避策略重试
+type Bus interface {
+	Publish(msg BusMessage) error
+}
+
+// bus 当前使用的消息总线
+var bus Bus
+
+// Run 根据配置初始化消息总线并启动发件箱投递器
+func Run() {
+	busType := global.Config.MQ.BusType()
+	switch busType {
+	case "grpc":
+		bus = &grpcBus{}
+	case "memory":
+		bus = Memory
+	default:
+		global.Queue = core.InitMQ() // 初始化rabbitMQ
+		RegisterExChange()           // 注册交换机
+		go RunDeadLetterConsumer()   // 启动死信消息收集
+		bus = &rabbitBus{}
+	}
+	logrus.Infof("消息总线类型 %s", busType)
+
+	go RunOutboxDispatcher() // 启动发件箱投递器
+}
@@ -0,0 +1,69 @@
+package mq_service
+
+// File: service/mq_service/bus_grpc.go
+// Description: gRPC消息总线，复用与节点之间已建立的Command双向流投递业务消息，
+// 节点处理完成后通过命令响应返回结果，小规模部署可不依赖RabbitMQ
+
+import (
+	"context"
+	"errors"
+	"honey_server/internal/rpc/node_rpc"
+	"honey_server/internal/service/grpc_service"
+	"time"
+)
+
+// grpcBusTimeout 等待节点处理结果的超时时间
+const grpcBusTimeout = 60 * time.Second
+
+// grpcBus 基于节点命令流的消息总线
+type grpcBus struct {
+}
+
+// Publish 通过命令流将消息发送给目标节点，并同步等待节点的处理结果
+func (grpcBus) Publish(msg BusMessage) error {
+	// 节点离线时由发件箱推迟投递，节点重新上线后继续发送
+	cmd, ok := grpc_service.GetNodeCommand(msg.NodeUID)
+	if !ok {
+		return errNodeOffline
+	}
+
+	taskID := "bus-" + msg.MessageID
+	req := &node_rpc.CmdRequest{
+		CmdType: node_rpc.CmdType_cmdBusMessageType,
+		TaskID:  taskID,
+		BusInMessage: &node_rpc.BusInMessage{
+			MessageID: msg.MessageID,
+			Topic:     msg.Topic,
+			Body:      msg.Body,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), grpcBusTimeout)
+	defer cancel()
+
+	select {
+	case cmd.ReqChan <- req:
+	case <-ctx.Done():
+		return errors.New("发送消息超时")
+	}
+
+	for {
+		select {
+		case response := <-cmd.ResChan:
+			// 非当前任务的响应放回通道，避免影响其他任务
+			if response.TaskID != taskID {
+				select {
+				case cmd.ResChan <- response:
+				case <-ctx.Done():
+				}
+				continue
+			}
+			if response.Code != 0 {
+				return errors.New(response.ErrorMsg)
+			}
+			return nil
+		case <-ctx.Done():
+			return errors.New("等待节点处理结果超时")
+		}
+	}
+}
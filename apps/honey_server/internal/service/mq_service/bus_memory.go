@@ -0,0 +1,54 @@
+package mq_service
+
+// File: service/mq_service/bus_memory.go
+// Description: 内存消息总线，消息在进程内同步投递给订阅的处理函数，用于测试及不部署节点的调试环境
+
+import "sync"
+
+// MemoryHandler 内存总线消息处理函数
+type MemoryHandler func(msg BusMessage) error
+
+// MemoryBus 内存消息总线，记录所有已发布的消息
+type MemoryBus struct {
+	mu         sync.Mutex
+	handlerMap map[string]MemoryHandler // 主题 → 处理函数
+	messages   []BusMessage             // 已发布的消息
+}
+
+// Memory 全局内存消息总线实例
+var Memory = &MemoryBus{handlerMap: map[string]MemoryHandler{}}
+
+// Subscribe 订阅指定主题的消息
+func (b *MemoryBus) Subscribe(topic string, handler MemoryHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlerMap[topic] = handler
+}
+
+// Publish 记录消息并同步调用订阅的处理函数，未订阅的主题仅记录
+func (b *MemoryBus) Publish(msg BusMessage) error {
+	b.mu.Lock()
+	b.messages = append(b.messages, msg)
+	handler := b.handlerMap[msg.Topic]
+	b.mu.Unlock()
+
+	if handler == nil {
+		return nil
+	}
+	return handler(msg)
+}
+
+// Messages 返回已发布消息的副本
+func (b *MemoryBus) Messages() []BusMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]BusMessage(nil), b.messages...)
+}
+
+// Reset 清空已发布的消息及订阅
+func (b *MemoryBus) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = nil
+	b.handlerMap = map[string]MemoryHandler{}
+}
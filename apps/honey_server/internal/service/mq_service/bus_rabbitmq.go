@@ -0,0 +1,127 @@
+package mq_service
+
+// File: service/mq_service/bus_rabbitmq.go
+// Description: RabbitMQ消息总线，以发布确认（publisher confirms）+ mandatory 方式发布消息，
+// 通道关闭或确认超时后自动重建连接
+
+import (
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+// rabbitConfirmTimeout 等待broker确认的超时时间
+const rabbitConfirmTimeout = 10 * time.Second
+
+// rabbitBus RabbitMQ消息总线，持有开启了发布确认模式的消息通道
+type rabbitBus struct {
+	conn     *amqp.Connection       // 重建通道时新建的连接，使用全局消息通道时为空
+	ch       *amqp.Channel          // 开启发布确认模式的消息通道
+	confirms chan amqp.Confirmation // broker发布确认通知
+	returns  chan amqp.Return       // 不可路由消息的退回通知
+	opened   bool                   // 是否已使用过全局消息通道
+}
+
+// open 打开消息通道并开启发布确认模式
+// 首次使用启动时建立的全局消息通道，通道关闭后重新建立连接；重建的通道只由总线持有，不回写全局变量，避免并发读写
+func (b *rabbitBus) open() error {
+	ch := global.Queue
+	var conn *amqp.Connection
+	if b.opened {
+		var err error
+		conn, ch, err = core.ConnectMQ()
+		if err != nil {
+			return err
+		}
+	}
+	b.opened = true
+
+	// 开启发布确认模式，broker会对每条消息回复ack/nack
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		if conn != nil {
+			conn.Close()
+		}
+		return fmt.Errorf("开启发布确认失败 %s", err)
+	}
+	b.conn = conn
+	b.ch = ch
+	b.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+	b.returns = ch.NotifyReturn(make(chan amqp.Return, outboxBatchSize))
+	logrus.Infof("RabbitMQ消息总线已就绪")
+	return nil
+}
+
+// reset 关闭当前消息通道及重建时新建的连接，下次发布时重新建立连接
+func (b *rabbitBus) reset() {
+	if b.ch != nil {
+		b.ch.Close()
+	}
+	if b.conn != nil {
+		b.conn.Close()
+	}
+	b.ch = nil
+	b.conn = nil
+}
+
+// Publish 发布单条消息并同步等待broker确认
+// 使用mandatory标志，节点队列不存在导致消息无法路由时broker会退回消息
+func (b *rabbitBus) Publish(msg BusMessage) error {
+	if b.ch == nil {
+		if err := b.open(); err != nil {
+			return fmt.Errorf("%w: %s", errBusUnavailable, err)
+		}
+	}
+
+	err := b.ch.Publish(
+		msg.Topic,   // 目标交换器名称
+		msg.NodeUID, // 路由键（目标节点UID，确保消息路由到指定节点队列）
+		true,        // mandatory：消息无法路由时返回给生产者
+		false,       // immediate：无消费者时是否立即返回（false不立即返回）
+		amqp.Publishing{
+			ContentType:  "text/plain",     // 消息内容类型
+			DeliveryMode: amqp.Persistent,  // 持久化消息，broker重启后不丢失
+			MessageId:    msg.MessageID,    // 消息ID，供消费端幂等处理
+			Timestamp:    time.Now(),       // 发送时间
+			Body:         []byte(msg.Body), // 消息体
+		})
+	if err != nil {
+		b.reset()
+		return fmt.Errorf("%w: %s", errBusUnavailable, err)
+	}
+
+	// 等待broker确认（退回通知总是先于确认到达）
+	select {
+	case confirm, ok := <-b.confirms:
+		if !ok {
+			b.reset()
+			return fmt.Errorf("%w: 消息通道已关闭", errBusUnavailable)
+		}
+		if !confirm.Ack {
+			return errors.New("broker拒绝接收消息")
+		}
+	case <-time.After(rabbitConfirmTimeout):
+		// 超时后确认序号无法再与消息对应，需要重建通道
+		b.reset()
+		return fmt.Errorf("%w: 等待broker确认超时", errBusUnavailable)
+	}
+
+	// 检查消息是否因无法路由被退回
+	for {
+		select {
+		case ret := <-b.returns:
+			if ret.MessageId == msg.MessageID {
+				return fmt.Errorf("消息不可路由，节点队列不存在 %s", ret.ReplyText)
+			}
+			continue
+		default:
+		}
+		break
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package mq_service
+
+// File: service/mq_service/dead_letter_consumer.go
+// Description: 死信消息收集器，订阅各业务的死信交换器，将节点处理失败且超过最大重试次数的消息入库，供管理员查看与重放
+
+import (
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+	"gorm.io/gorm"
+)
+
+const (
+	retryCountHeader     = "x-retry-count"     // 重试次数消息头（与节点约定）
+	errorMsgHeader       = "x-error"           // 处理失败原因消息头（与节点约定）
+	originExchangeHeader = "x-origin-exchange" // 原始交换器消息头（与节点约定）
+	nodeUidHeader        = "x-node-uid"        // 节点UID消息头（与节点约定）
+)
+
+// deadLetterExchangeName 死信交换器名称（与节点约定：业务交换器名称 + _dlx）
+func deadLetterExchangeName(exChangeName string) string {
+	return exChangeName + "_dlx"
+}
+
+// RunDeadLetterConsumer 启动死信消息收集（阻塞运行，需以协程方式启动），连接断开后自动重连
+func RunDeadLetterConsumer() {
+	for {
+		err := consumeDeadLetter()
+		logrus.Errorf("死信消息收集中断 %v，5秒后重连", err)
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// consumeDeadLetter 使用独立的消息通道订阅所有业务的死信交换器并消费
+func consumeDeadLetter() error {
+	conn, ch, err := core.ConnectMQ()
+	if err != nil {
+		return err
+	}
+	defer conn.Close() // 关闭连接时其上的通道一并关闭
+
+	cfg := global.Config.MQ
+	closeChan := ch.NotifyClose(make(chan *amqp.Error, 1))
+	for _, exChangeName := range []string{
+		cfg.CreateIpExchangeName,
+		cfg.DeleteIpExchangeName,
+		cfg.BindPortExchangeName,
+	} {
+		msgs, err := declareDeadLetterQueue(ch, exChangeName)
+		if err != nil {
+			return err
+		}
+		go func(exChangeName string, msgs <-chan amqp.Delivery) {
+			for d := range msgs {
+				saveDeadLetter(exChangeName, d)
+			}
+		}(exChangeName, msgs)
+	}
+
+	// 阻塞直到通道关闭
+	return <-closeChan
+}
+
+// declareDeadLetterQueue 声明死信交换器及服务端的死信收集队列，并注册消费者
+func declareDeadLetterQueue(ch *amqp.Channel, exChangeName string) (<-chan amqp.Delivery, error) {
+	dlxName := deadLetterExchangeName(exChangeName)
+	// 声明死信交换器（fanout类型，需与节点声明一致）
+	err := ch.ExchangeDeclare(dlxName, "fanout", true, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("声明死信交换器 %s 失败 %s", dlxName, err)
+	}
+
+	// 声明服务端死信收集队列（持久化，服务端离线期间的死信不会丢失）
+	queue, err := ch.QueueDeclare(dlxName+"_queue", true, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("声明死信队列失败 %s", err)
+	}
+	err = ch.QueueBind(queue.Name, "", dlxName, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("绑定死信队列失败 %s", err)
+	}
+
+	msgs, err := ch.Consume(queue.Name, "", false, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("注册死信消费者失败 %s", err)
+	}
+	logrus.Infof("订阅死信交换器成功 %s", dlxName)
+	return msgs, nil
+}
+
+// saveDeadLetter 将死信消息入库，入库成功后确认消息
+func saveDeadLetter(exChangeName string, d amqp.Delivery) {
+	model := models.MqDeadLetterModel{
+		MessageID: d.MessageId,
+		Exchange:  exChangeName,
+		NodeUID:   d.RoutingKey,
+		Body:      string(d.Body),
+		Status:    1,
+	}
+	if v, ok := d.Headers[originExchangeHeader].(string); ok && v != "" {
+		model.Exchange = v
+	}
+	if v, ok := d.Headers[nodeUidHeader].(string); ok && v != "" {
+		model.NodeUID = v
+	}
+	switch v := d.Headers[retryCountHeader].(type) {
+	case int32:
+		model.RetryCount = int(v)
+	case int64:
+		model.RetryCount = int(v)
+	}
+	if v, ok := d.Headers[errorMsgHeader].(string); ok {
+		model.ErrorMsg = truncateRunes(v, 256)
+	}
+
+	if err := global.DB.Create(&model).Error; err != nil {
+		logrus.Errorf("死信消息入库失败 %s %s", d.MessageId, err)
+		d.Nack(false, true)
+		return
+	}
+	logrus.Warnf("收到死信消息 节点 %s 交换器 %s 消息 %s", model.NodeUID, model.Exchange, model.MessageID)
+	d.Ack(false)
+}
+
+// ReplayDeadLetter 重放死信消息：以新的消息ID写入发件箱重新投递到原节点，并将死信标记为已重放
+func ReplayDeadLetter(tx *gorm.DB, model models.MqDeadLetterModel) error {
+	if err := saveOutboxBody(tx, model.Exchange, model.NodeUID, model.Body); err != nil {
+		return err
+	}
+	return tx.Model(&model).Update("status", 2).Error
+}
@@ -0,0 +1,45 @@
+package mq_service
+
+// File: service/mq_service/outbox.go
+// Description: 消息发件箱写入逻辑，将待发送的消息与业务数据在同一数据库事务中持久化，保证业务变更与消息投递的一致性
+
+import (
+	"encoding/json"
+	"honey_server/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// saveOutbox 将消息写入发件箱，由投递器异步发送
+// tx 需为业务操作所在的事务，事务回滚时消息随之丢弃
+func saveOutbox(tx *gorm.DB, exchange string, nodeUID string, req any) error {
+	// 将请求参数序列化为JSON字节数据（消息体）
+	byteData, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return saveOutboxBody(tx, exchange, nodeUID, string(byteData))
+}
+
+// saveOutboxBody 将已序列化的消息体写入发件箱（死信重放时复用原消息体）
+func saveOutboxBody(tx *gorm.DB, exchange string, nodeUID string, body string) error {
+	model := models.MqOutboxModel{
+		MessageID:   uuid.New().String(),
+		Exchange:    exchange,
+		RoutingKey:  nodeUID,
+		Body:        body,
+		Status:      1,
+		NextRetryAt: time.Now(),
+	}
+	err := tx.Create(&model).Error
+	if err != nil {
+		logrus.Errorf("写入消息发件箱失败 %s %s", err, body)
+		return err
+	}
+	logrus.Infof("消息已写入发件箱 %s %s", model.MessageID, body)
+	return nil
+}
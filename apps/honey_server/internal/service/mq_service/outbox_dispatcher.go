@@ -0,0 +1,169 @@
+package mq_service
+
+// File: service/mq_service/outbox_dispatcher.go
+// Description: 发件箱投递器，轮询待发送消息并通过消息总线发布，
+// 失败时按指数退避重试，超过最大重试次数后标记为发送失败并回写关联业务状态
+
+import (
+	"encoding/json"
+	"errors"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	outboxPollInterval = time.Second      // 轮询发件箱的间隔
+	outboxBatchSize    = 50               // 单次轮询处理的消息数量
+	outboxMaxBackoff   = 5 * time.Minute  // 重试退避的最大间隔
+	outboxDefaultRetry = 10               // 未配置时的默认最大重试次数
+	outboxDeferDelay   = 10 * time.Second // 总线不可用或节点离线时推迟投递的间隔
+)
+
+var (
+	// errBusUnavailable 消息总线暂不可用（如RabbitMQ通道关闭），需要重新建立连接
+	errBusUnavailable = errors.New("消息总线不可用")
+	// errNodeOffline 目标节点离线，等待节点重新上线后再投递
+	errNodeOffline = errors.New("节点离线中")
+)
+
+// RunOutboxDispatcher 启动发件箱投递循环（阻塞运行，需以协程方式启动）
+func RunOutboxDispatcher() {
+	for {
+		dispatch()
+		time.Sleep(outboxPollInterval)
+	}
+}
+
+// dispatch 通过消息总线发送一批到期的待发送消息
+func dispatch() {
+	var list []models.MqOutboxModel
+	global.DB.Where("status = ? and next_retry_at <= ?", 1, time.Now()).
+		Order("id").Limit(outboxBatchSize).Find(&list)
+
+	for _, model := range list {
+		err := bus.Publish(BusMessage{
+			MessageID: model.MessageID,
+			Topic:     model.Exchange,
+			NodeUID:   model.RoutingKey,
+			Body:      model.Body,
+		})
+		if err == nil {
+			global.DB.Model(&model).Updates(map[string]any{
+				"status":    2,
+				"error_msg": "",
+			})
+			logrus.Infof("发件箱消息发送成功 %s", model.MessageID)
+			continue
+		}
+
+		// 总线不可用或节点离线不是消息本身的问题，仅推迟投递，不消耗重试次数
+		if errors.Is(err, errBusUnavailable) || errors.Is(err, errNodeOffline) {
+			logrus.Warnf("发件箱消息推迟发送 %s %s", model.MessageID, err)
+			markOutboxDeferred(model, err)
+			// 消息总线不可用时结束本轮，同一批次的其他消息同样无法发送
+			if errors.Is(err, errBusUnavailable) {
+				return
+			}
+			continue
+		}
+
+		logrus.Errorf("发件箱消息发送失败 %s %s", model.MessageID, err)
+		markOutboxRetry(model, err)
+	}
+}
+
+// markOutboxDeferred 推迟消息的下次投递时间，不增加重试次数
+func markOutboxDeferred(model models.MqOutboxModel, err error) {
+	global.DB.Model(&model).Updates(map[string]any{
+		"next_retry_at": time.Now().Add(outboxDeferDelay),
+		"error_msg":     truncateRunes(err.Error(), 256),
+	})
+}
+
+// markOutboxRetry 记录发送失败，按指数退避安排重试，超过最大重试次数后标记为发送失败
+func markOutboxRetry(model models.MqOutboxModel, err error) {
+	maxRetry := global.Config.MQ.OutboxMaxRetry
+	if maxRetry <= 0 {
+		maxRetry = outboxDefaultRetry
+	}
+
+	errMsg := truncateRunes(err.Error(), 256)
+
+	retryCount := model.RetryCount + 1
+	if retryCount >= maxRetry {
+		global.DB.Model(&model).Updates(map[string]any{
+			"status":      3,
+			"retry_count": retryCount,
+			"error_msg":   errMsg,
+		})
+		logrus.Errorf("发件箱消息超过最大重试次数 %s", model.MessageID)
+		onOutboxFailed(model)
+		return
+	}
+
+	// 指数退避：1s、2s、4s ... 最大不超过outboxMaxBackoff
+	backoff := time.Second << min(retryCount-1, 16)
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	global.DB.Model(&model).Updates(map[string]any{
+		"retry_count":   retryCount,
+		"next_retry_at": time.Now().Add(backoff),
+		"error_msg":     errMsg,
+	})
+}
+
+// onOutboxFailed 消息最终发送失败时回写关联业务状态，避免诱捕IP一直停留在过渡状态
+func onOutboxFailed(model models.MqOutboxModel) {
+	cfg := global.Config.MQ
+	switch model.Exchange {
+	case cfg.CreateIpExchangeName:
+		// 兼容单个创建与批量创建两种消息体
+		var req CreateIPBatchRequest
+		if err := json.Unmarshal([]byte(model.Body), &req); err != nil {
+			return
+		}
+		if len(req.IpList) == 0 {
+			var single CreateIPRequest
+			json.Unmarshal([]byte(model.Body), &single)
+			req.IpList = append(req.IpList, single)
+		}
+		var idList []uint
+		for _, info := range req.IpList {
+			idList = append(idList, info.HoneyIPID)
+		}
+		global.DB.Model(&models.HoneyIpModel{}).
+			Where("id in ? and status = ?", idList, 1).
+			Updates(map[string]any{
+				"status":    3,
+				"error_msg": "创建消息投递失败",
+			})
+	case cfg.DeleteIpExchangeName:
+		var req DeleteIPRequest
+		if err := json.Unmarshal([]byte(model.Body), &req); err != nil {
+			return
+		}
+		var idList []uint
+		for _, info := range req.IpList {
+			idList = append(idList, info.HoneyIPID)
+		}
+		global.DB.Model(&models.HoneyIpModel{}).
+			Where("id in ? and status = ?", idList, 4).
+			Updates(map[string]any{
+				"status":    3,
+				"error_msg": "删除消息投递失败",
+			})
+	}
+}
+
+// truncateRunes 按字符截断超出字段长度的内容，不会截断多字节字符
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
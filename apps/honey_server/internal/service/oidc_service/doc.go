@@ -0,0 +1,2 @@
+// Package oidc_service 单点登录服务，提供 OpenID Connect 授权码模式（PKCE）登录、角色映射与用户自动创建
+package oidc_service
@@ -0,0 +1,215 @@
+package oidc_service
+
+// File: service/oidc_service/enter.go
+// Description: OpenID Connect 授权码模式（PKCE）登录流程。
+// 跳转身份提供方前生成 state、nonce 与 code_verifier 并临时保存，state 的摘要写入浏览器 Cookie；
+// 回调时校验 state 及其与发起登录的浏览器一致（防止登录CSRF），
+// 使用 code_verifier 换取 token，校验 id_token 的签名、受众与 nonce 后取出用户声明
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/oauth2"
+)
+
+const (
+	stateTTL       = 10 * time.Minute // 登录跳转的有效期
+	stateKeyPrefix = "oidc_state:"
+	StateCookie    = "oidc_state"                // 保存 state 摘要的 Cookie 名称
+	StateMaxAge    = int(stateTTL / time.Second) // Cookie 有效期，单位: 秒，与登录跳转的有效期一致
+	requestTimeout = 10 * time.Second            // 请求身份提供方的超时时间
+)
+
+var (
+	providerMutex sync.Mutex
+	provider      *oidc.Provider // 身份提供方，首次使用时发现端点，失败后下次重试
+)
+
+// authState 登录跳转时保存的状态
+type authState struct {
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// Identity 身份提供方返回的用户身份
+type Identity struct {
+	Subject    string   // 用户在身份提供方的唯一标识
+	Username   string   // 用户名
+	RoleValues []string // 用于映射角色的声明值
+}
+
+// Enable 是否启用单点登录
+func Enable() bool {
+	return global.Config.Oidc.Enable
+}
+
+// getProvider 获取身份提供方，通过 issuer 发现端点
+func getProvider(ctx context.Context) (*oidc.Provider, error) {
+	providerMutex.Lock()
+	defer providerMutex.Unlock()
+	if provider != nil {
+		return provider, nil
+	}
+	p, err := oidc.NewProvider(ctx, global.Config.Oidc.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("发现身份提供方端点失败 %w", err)
+	}
+	provider = p
+	return provider, nil
+}
+
+// oauth2Config 构造 OAuth2 客户端配置
+func oauth2Config(p *oidc.Provider) *oauth2.Config {
+	cfg := global.Config.Oidc
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+	}
+	return &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Endpoint:     p.Endpoint(),
+		Scopes:       scopes,
+	}
+}
+
+// randString 生成随机字符串
+func randString() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// StateHash 计算 state 的摘要，写入发起登录的浏览器 Cookie
+func StateHash(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckState 校验回调的 state 与浏览器 Cookie 中的摘要一致
+func CheckState(state string, cookie string) bool {
+	return state != "" && subtle.ConstantTimeCompare([]byte(StateHash(state)), []byte(cookie)) == 1
+}
+
+// AuthURL 生成跳转身份提供方的授权地址，同时返回 state 供绑定到浏览器
+func AuthURL() (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	p, err := getProvider(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state := randString()
+	value := authState{
+		Nonce:    randString(),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+	byteData, _ := json.Marshal(value)
+	if err = global.Redis.Set(ctx, stateKeyPrefix+state, byteData, stateTTL).Err(); err != nil {
+		return "", "", err
+	}
+	return oauth2Config(p).AuthCodeURL(state, oidc.Nonce(value.Nonce), oauth2.S256ChallengeOption(value.Verifier)), state, nil
+}
+
+// takeState 取出并作废登录跳转的状态，每个 state 只能使用一次
+func takeState(ctx context.Context, state string) (*authState, error) {
+	pipe := global.Redis.TxPipeline()
+	get := pipe.Get(ctx, stateKeyPrefix+state)
+	pipe.Del(ctx, stateKeyPrefix+state)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	byteData, err := get.Bytes()
+	if err != nil {
+		return nil, errors.New("登录请求无效或已过期，请重新登录")
+	}
+	var value authState
+	if err = json.Unmarshal(byteData, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// Exchange 使用授权码换取 token，校验 id_token 后返回用户身份
+func Exchange(state string, code string) (*Identity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	value, err := takeState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	p, err := getProvider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauth2Config(p).Exchange(ctx, code, oauth2.VerifierOption(value.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("换取token失败 %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("身份提供方未返回 id_token")
+	}
+	idToken, err := p.Verifier(&oidc.Config{ClientID: global.Config.Oidc.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("id_token 校验失败 %w", err)
+	}
+	if idToken.Nonce != value.Nonce {
+		return nil, errors.New("id_token nonce 不匹配")
+	}
+
+	var claims map[string]any
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+	return newIdentity(idToken.Subject, claims)
+}
+
+// newIdentity 从声明中取出用户名与角色声明值
+func newIdentity(subject string, claims map[string]any) (*Identity, error) {
+	cfg := global.Config.Oidc
+	usernameClaim := cfg.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "preferred_username"
+	}
+	roleClaim := cfg.RoleClaim
+	if roleClaim == "" {
+		roleClaim = "groups"
+	}
+
+	username, _ := claims[usernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("id_token 缺少用户名声明 %s", usernameClaim)
+	}
+	identity := &Identity{
+		Subject:  subject,
+		Username: username,
+	}
+	switch roles := claims[roleClaim].(type) {
+	case string:
+		identity.RoleValues = []string{roles}
+	case []any:
+		for _, role := range roles {
+			if s, ok := role.(string); ok {
+				identity.RoleValues = append(identity.RoleValues, s)
+			}
+		}
+	}
+	return identity, nil
+}
@@ -0,0 +1,105 @@
+package oidc_service
+
+// File: service/oidc_service/provision.go
+// Description: 单点登录用户的角色映射与自动创建。
+// 用户按身份提供方的唯一标识关联，每次登录时按映射同步角色；用户名与本地用户重名时拒绝登录，避免接管本地账号
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/role_service"
+	"honey_server/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ticketTTL       = time.Minute // 登录票据有效期
+	ticketKeyPrefix = "oidc_ticket:"
+)
+
+// MapRole 按声明值映射角色，返回角色ID，没有匹配的映射时返回默认角色
+func MapRole(roleValues []string) uint {
+	cfg := global.Config.Oidc
+	for _, mapping := range cfg.RoleMapping {
+		if utils.InList(roleValues, mapping.Value) {
+			return mapping.RoleID
+		}
+	}
+	return cfg.DefaultRoleID
+}
+
+// Provision 查询或创建单点登录用户，并同步其角色
+func Provision(identity *Identity) (user models.UserModel, err error) {
+	roleID := MapRole(identity.RoleValues)
+	if roleID == 0 {
+		return user, errors.New("没有可分配的角色，请联系管理员")
+	}
+	var count int64
+	global.DB.Model(&models.RoleModel{}).Where("id = ?", roleID).Count(&count)
+	if count == 0 {
+		return user, fmt.Errorf("映射的角色 %d 不存在", roleID)
+	}
+
+	err = global.DB.Take(&user, "oidc_subject = ?", identity.Subject).Error
+	if err != nil {
+		if !global.Config.Oidc.AutoCreate {
+			return user, errors.New("用户不存在，请联系管理员")
+		}
+		global.DB.Model(&models.UserModel{}).Where("username = ?", identity.Username).Count(&count)
+		if count > 0 {
+			return user, errors.New("用户名已被本地用户使用，请联系管理员")
+		}
+		// 单点登录用户没有本地密码，无法通过密码登录
+		user = models.UserModel{
+			Username:    identity.Username,
+			RoleID:      roleID,
+			OidcSubject: identity.Subject,
+		}
+		if err = global.DB.Create(&user).Error; err != nil {
+			return user, err
+		}
+		logrus.Infof("单点登录创建用户 %s 角色 %d", user.Username, roleID)
+		return user, nil
+	}
+
+	if user.RoleID != roleID {
+		if err = global.DB.Model(&user).Update("role_id", roleID).Error; err != nil {
+			return user, err
+		}
+		role_service.Invalidate()
+		logrus.Infof("单点登录同步用户 %s 角色 %d -> %d", user.Username, user.RoleID, roleID)
+		user.RoleID = roleID
+	}
+	return user, nil
+}
+
+// CreateTicket 回调完成后签发一次性登录票据，前端使用票据换取token，避免token出现在跳转地址中
+func CreateTicket(userID uint) (string, error) {
+	ticket := randString()
+	err := global.Redis.Set(context.Background(), ticketKeyPrefix+ticket, userID, ticketTTL).Err()
+	return ticket, err
+}
+
+// UseTicket 使用登录票据，返回用户ID，每个票据只能使用一次
+func UseTicket(ticket string) (uint, error) {
+	ctx := context.Background()
+	pipe := global.Redis.TxPipeline()
+	get := pipe.Get(ctx, ticketKeyPrefix+ticket)
+	pipe.Del(ctx, ticketKeyPrefix+ticket)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+	value, err := get.Result()
+	if err != nil {
+		return 0, errors.New("登录票据无效或已过期，请重新登录")
+	}
+	userID, _ := strconv.ParseUint(value, 10, 64)
+	return uint(userID), nil
+}
@@ -0,0 +1,2 @@
+// Package role_service 角色与权限服务，定义权限清单与内置角色，并提供带缓存的用户权限查询
+package role_service
@@ -0,0 +1,134 @@
+package role_service
+
+// File: service/role_service/enter.go
+// Description: 内置角色的初始化，以及用户权限的查询。
+// 权限按用户缓存一段时间，角色或用户角色变更后调用 Invalidate 立即生效
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/utils"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 内置角色ID
+const (
+	AdminRoleID    = 1 // 管理员，拥有全部权限
+	OperatorRoleID = 2 // 运维人员，除用户、角色管理和删除日志外的全部权限
+	ViewerRoleID   = 3 // 只读用户，全部查看权限
+)
+
+const cacheTTL = 30 * time.Second // 用户权限缓存时间
+
+// operatorExclude 运维人员不具备的权限
+var operatorExclude = []string{"user:write", "role:write", "log:delete"}
+
+// builtinRoleList 内置角色
+func builtinRoleList() []models.RoleModel {
+	var operator, viewer []string
+	for _, permission := range PermissionList {
+		if !utils.InList(operatorExclude, permission.Code) {
+			operator = append(operator, permission.Code)
+		}
+		if strings.HasSuffix(permission.Code, ":read") {
+			viewer = append(viewer, permission.Code)
+		}
+	}
+	return []models.RoleModel{
+		{Model: models.Model{ID: AdminRoleID}, Title: "管理员", Builtin: true, PermissionList: []string{AllPermission}},
+		{Model: models.Model{ID: OperatorRoleID}, Title: "运维人员", Builtin: true, PermissionList: operator},
+		{Model: models.Model{ID: ViewerRoleID}, Title: "只读用户", Builtin: true, PermissionList: viewer},
+	}
+}
+
+// InitBuiltin 初始化内置角色，在表结构迁移后执行
+// 内置角色的权限随权限清单更新；旧版本用户表的 role 字段迁移为内置角色（1管理员 -> 管理员，2普通用户 -> 只读用户）
+func InitBuiltin() error {
+	roleList := builtinRoleList()
+	err := global.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "builtin", "permission_list", "updated_at"}),
+	}).Create(&roleList).Error
+	if err != nil {
+		return err
+	}
+
+	migrator := global.DB.Migrator()
+	if migrator.HasColumn(&models.UserModel{}, "role") {
+		err = global.DB.Transaction(func(tx *gorm.DB) error {
+			// 旧版本普通用户仅有查看权限，迁移为只读用户
+			if err := tx.Exec("UPDATE user_models SET role_id = CASE role WHEN 1 THEN ? ELSE ? END WHERE (role_id IS NULL OR role_id = 0) AND role IN (1, 2)",
+				AdminRoleID, ViewerRoleID).Error; err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&models.UserModel{}, "role")
+		})
+		if err != nil {
+			return err
+		}
+		logrus.Infof("用户角色字段迁移完成")
+	}
+	return nil
+}
+
+// cacheItem 用户权限缓存项
+type cacheItem struct {
+	permissionMap map[string]bool
+	expire        time.Time
+}
+
+var cache sync.Map // 用户ID -> cacheItem
+
+// Permissions 查询用户的权限集合，用户不存在或未分配角色时返回空集合
+func Permissions(userID uint) map[string]bool {
+	if v, ok := cache.Load(userID); ok {
+		item := v.(cacheItem)
+		if time.Now().Before(item.expire) {
+			return item.permissionMap
+		}
+	}
+
+	permissionMap := map[string]bool{}
+	var user models.UserModel
+	if err := global.DB.Select("id", "role_id").Take(&user, userID).Error; err == nil && user.RoleID != 0 {
+		var role models.RoleModel
+		if err = global.DB.Take(&role, user.RoleID).Error; err == nil {
+			for _, code := range role.PermissionList {
+				permissionMap[code] = true
+			}
+		}
+	}
+	cache.Store(userID, cacheItem{permissionMap: permissionMap, expire: time.Now().Add(cacheTTL)})
+	return permissionMap
+}
+
+// HasPermission 判断用户是否拥有指定权限
+func HasPermission(userID uint, code string) bool {
+	permissionMap := Permissions(userID)
+	return permissionMap[AllPermission] || permissionMap[code]
+}
+
+// Covers 判断用户是否拥有权限列表中的全部权限，列表含全部权限时要求用户同样拥有全部权限
+func Covers(userID uint, permissionList []string) bool {
+	permissionMap := Permissions(userID)
+	if permissionMap[AllPermission] {
+		return true
+	}
+	for _, code := range permissionList {
+		if !permissionMap[code] {
+			return false
+		}
+	}
+	return true
+}
+
+// Invalidate 清空权限缓存
+func Invalidate() {
+	cache.Clear()
+}
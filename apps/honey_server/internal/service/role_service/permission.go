@@ -0,0 +1,70 @@
+package role_service
+
+// File: service/role_service/permission.go
+// Description: 权限清单。权限码格式为 资源:操作，honey_server 与 image_server 的路由权限均在此定义
+
+// AllPermission 全部权限
+const AllPermission = "*"
+
+// Permission 权限
+type Permission struct {
+	Code  string `json:"code"`  // 权限码
+	Title string `json:"title"` // 权限名称
+	Group string `json:"group"` // 权限分组
+}
+
+// PermissionList 全部权限清单
+var PermissionList = []Permission{
+	{Code: "user:read", Title: "查看用户", Group: "系统管理"},
+	{Code: "user:write", Title: "管理用户", Group: "系统管理"},
+	{Code: "role:read", Title: "查看角色", Group: "系统管理"},
+	{Code: "role:write", Title: "管理角色", Group: "系统管理"},
+	{Code: "log:read", Title: "查看日志", Group: "系统管理"},
+	{Code: "log:delete", Title: "删除日志", Group: "系统管理"},
+	{Code: "mq:read", Title: "查看消息", Group: "系统管理"},
+	{Code: "mq:write", Title: "重试消息", Group: "系统管理"},
+	{Code: "node:read", Title: "查看节点", Group: "节点管理"},
+	{Code: "node:write", Title: "管理节点", Group: "节点管理"},
+	{Code: "net:read", Title: "查看网络", Group: "网络管理"},
+	{Code: "net:write", Title: "管理网络", Group: "网络管理"},
+	{Code: "net:scan", Title: "扫描网络", Group: "网络管理"},
+	{Code: "host:read", Title: "查看存活主机", Group: "网络管理"},
+	{Code: "host:delete", Title: "删除存活主机", Group: "网络管理"},
+	{Code: "honey_ip:read", Title: "查看诱捕IP", Group: "诱捕管理"},
+	{Code: "honey_ip:create", Title: "创建诱捕IP", Group: "诱捕管理"},
+	{Code: "honey_ip:delete", Title: "删除诱捕IP", Group: "诱捕管理"},
+	{Code: "honey_port:read", Title: "查看诱捕端口", Group: "诱捕管理"},
+	{Code: "honey_port:write", Title: "管理诱捕端口", Group: "诱捕管理"},
+	{Code: "interaction:read", Title: "查看交互会话", Group: "攻击分析"},
+	{Code: "credential:read", Title: "查看凭据尝试", Group: "攻击分析"},
+	{Code: "transcript:read", Title: "查看会话录制", Group: "攻击分析"},
+	{Code: "transcript:delete", Title: "删除会话录制", Group: "攻击分析"},
+	{Code: "event:read", Title: "订阅实时事件", Group: "攻击分析"},
+	{Code: "alert:read", Title: "查看告警", Group: "告警管理"},
+	{Code: "alert:handle", Title: "处理告警", Group: "告警管理"},
+	{Code: "alert_rule:read", Title: "查看告警规则", Group: "告警管理"},
+	{Code: "alert_rule:write", Title: "管理告警规则", Group: "告警管理"},
+	{Code: "alert_channel:read", Title: "查看通知渠道", Group: "告警管理"},
+	{Code: "alert_channel:write", Title: "管理通知渠道", Group: "告警管理"},
+	{Code: "image:read", Title: "查看镜像", Group: "镜像管理"},
+	{Code: "image:write", Title: "管理镜像", Group: "镜像管理"},
+	{Code: "vs:read", Title: "查看虚拟服务", Group: "镜像管理"},
+	{Code: "vs:write", Title: "管理虚拟服务", Group: "镜像管理"},
+	{Code: "vs_net:read", Title: "查看虚拟网络", Group: "镜像管理"},
+	{Code: "vs_net:write", Title: "管理虚拟网络", Group: "镜像管理"},
+	{Code: "template:read", Title: "查看模板", Group: "镜像管理"},
+	{Code: "template:write", Title: "管理模板", Group: "镜像管理"},
+}
+
+// ValidPermission 判断权限码是否存在
+func ValidPermission(code string) bool {
+	if code == AllPermission {
+		return true
+	}
+	for _, permission := range PermissionList {
+		if permission.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,2 @@
+// Package session_service 登录会话服务，基于Redis保存以JWT ID为键的服务端会话，支持会话校验、查询与吊销
+package session_service
@@ -0,0 +1,193 @@
+package session_service
+
+// File: service/session_service/enter.go
+// Description: 登录会话的创建、校验、查询与吊销。
+// 会话以 session:<会话ID> 的哈希保存，过期时间与 refresh token 一致，每次刷新后顺延；
+// user_session:<用户ID> 集合索引用户的全部会话
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"honey_server/internal/core"
+	"honey_server/internal/global"
+	"honey_server/internal/utils/jwts"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	lastSeenInterval      = time.Minute   // 最近访问时间的更新间隔，避免每个请求都写Redis
+	defaultRefreshExpires = 7 * 24 * 3600 // 未配置时 refresh token 的有效期（秒）
+)
+
+// Session 登录会话
+type Session struct {
+	ID        string `redis:"id" json:"id"`               // 会话ID
+	UserID    uint   `redis:"userID" json:"userID"`       // 用户ID
+	IP        string `redis:"ip" json:"ip"`               // 登录IP
+	Addr      string `redis:"addr" json:"addr"`           // 登录IP归属地
+	UserAgent string `redis:"userAgent" json:"userAgent"` // 浏览器标识
+	LoginTime int64  `redis:"loginTime" json:"loginTime"` // 登录时间（Unix秒）
+	LastSeen  int64  `redis:"lastSeen" json:"lastSeen"`   // 最近访问时间（Unix秒）
+	ExpiresAt int64  `redis:"expiresAt" json:"expiresAt"` // 过期时间（Unix秒）
+	Refresh   string `redis:"refresh" json:"-"`           // 当前有效的 refresh token 摘要
+}
+
+// sessionKey 会话的Redis键
+func sessionKey(id string) string {
+	return "session:" + id
+}
+
+// userSessionKey 用户会话索引的Redis键
+func userSessionKey(userID uint) string {
+	return fmt.Sprintf("user_session:%d", userID)
+}
+
+// refreshExpiration refresh token 及会话的有效期
+func refreshExpiration() time.Duration {
+	expires := global.Config.Jwt.RefreshExpires
+	if expires <= 0 {
+		expires = defaultRefreshExpires
+	}
+	return time.Duration(expires) * time.Second
+}
+
+// Create 登录成功后创建会话，返回会话及 refresh token
+func Create(c *gin.Context, userID uint) (*Session, string, error) {
+	ctx := context.Background()
+	now := time.Now()
+	expiration := refreshExpiration()
+	refreshToken, refreshHash := newRefreshToken()
+	session := &Session{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		IP:        c.ClientIP(),
+		Addr:      core.GetIpAddr(c.ClientIP()),
+		UserAgent: c.Request.UserAgent(),
+		LoginTime: now.Unix(),
+		LastSeen:  now.Unix(),
+		ExpiresAt: now.Add(expiration).Unix(),
+		Refresh:   refreshHash,
+	}
+
+	pipe := global.Redis.TxPipeline()
+	pipe.HSet(ctx, sessionKey(session.ID), session)
+	pipe.Expire(ctx, sessionKey(session.ID), expiration)
+	pipe.Set(ctx, refreshKey(refreshHash), session.ID, expiration)
+	pipe.SAdd(ctx, userSessionKey(userID), session.ID)
+	// 会话有效期相同，最新的会话最晚过期，索引的过期时间与之一致
+	pipe.Expire(ctx, userSessionKey(userID), expiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, "", err
+	}
+	return session, refreshToken, nil
+}
+
+// Check 校验token对应的会话是否有效，并按间隔更新最近访问时间
+func Check(claims *jwts.Claims) error {
+	if claims.SessionID == "" {
+		return errors.New("会话不存在")
+	}
+	ctx := context.Background()
+	var session Session
+	err := global.Redis.HGetAll(ctx, sessionKey(claims.SessionID)).Scan(&session)
+	if err != nil {
+		logrus.Errorf("查询会话失败 %s", err)
+		return errors.New("查询会话失败")
+	}
+	if session.ID == "" || session.UserID != claims.UserID {
+		return errors.New("会话已失效")
+	}
+
+	now := time.Now().Unix()
+	if now-session.LastSeen >= int64(lastSeenInterval.Seconds()) {
+		global.Redis.HSet(ctx, sessionKey(claims.SessionID), "lastSeen", now)
+	}
+	return nil
+}
+
+// List 查询用户的全部有效会话，按最近访问时间倒序
+func List(userID uint) ([]Session, error) {
+	ctx := context.Background()
+	idList, err := global.Redis.SMembers(ctx, userSessionKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Session, 0, len(idList))
+	for _, id := range idList {
+		var session Session
+		if err = global.Redis.HGetAll(ctx, sessionKey(id)).Scan(&session); err != nil {
+			return nil, err
+		}
+		if session.ID == "" {
+			// 会话已过期，清理索引
+			global.Redis.SRem(ctx, userSessionKey(userID), id)
+			continue
+		}
+		list = append(list, session)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LastSeen > list[j].LastSeen
+	})
+	return list, nil
+}
+
+// Revoke 吊销用户的指定会话，会话当前的 refresh token 一并失效
+func Revoke(userID uint, id string) error {
+	ctx := context.Background()
+	values, err := global.Redis.HMGet(ctx, sessionKey(id), "userID", "refresh").Result()
+	if err != nil {
+		return err
+	}
+	userIDStr, _ := values[0].(string)
+	if userIDStr != strconv.FormatUint(uint64(userID), 10) {
+		return errors.New("会话不存在")
+	}
+	refreshHash, _ := values[1].(string)
+
+	pipe := global.Redis.TxPipeline()
+	pipe.Del(ctx, sessionKey(id))
+	pipe.Del(ctx, refreshKey(refreshHash))
+	pipe.SRem(ctx, userSessionKey(userID), id)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// RevokeUser 吊销用户的全部会话（修改密码、删除用户时调用），except不为空时保留该会话
+func RevokeUser(userID uint, except string) error {
+	ctx := context.Background()
+	idList, err := global.Redis.SMembers(ctx, userSessionKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+
+	var count int
+	for _, id := range idList {
+		if id == except {
+			continue
+		}
+		if err = Revoke(userID, id); err != nil {
+			// 已过期的会话只清理索引
+			global.Redis.SRem(context.Background(), userSessionKey(userID), id)
+			continue
+		}
+		count++
+	}
+	if count > 0 {
+		logrus.Infof("吊销用户 %d 的会话 %d个", userID, count)
+	}
+	return nil
+}
+
+// isNil 判断是否为Redis键不存在
+func isNil(err error) bool {
+	return errors.Is(err, redis.Nil)
+}
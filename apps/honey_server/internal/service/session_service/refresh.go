@@ -0,0 +1,100 @@
+package session_service
+
+// File: service/session_service/refresh.go
+// Description: refresh token 的签发与轮换。refresh token 为随机字符串，Redis中只保存其摘要；
+// 每次刷新都会签发新的 refresh token，旧的立即作废但保留到原过期时间用于重用检测：
+// 已作废的 refresh token 再次使用说明其可能已泄露，此时吊销整个会话
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"honey_server/internal/global"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// refreshKey refresh token 摘要的Redis键，值为所属会话ID
+func refreshKey(hash string) string {
+	return "refresh:" + hash
+}
+
+// newRefreshToken 生成 refresh token 及其摘要
+func newRefreshToken() (token string, hash string) {
+	b := make([]byte, 32)
+	rand.Read(b)
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashRefreshToken(token)
+}
+
+// hashRefreshToken 计算 refresh token 的摘要
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// rotateScript 原子地比较并替换会话当前的 refresh token 摘要
+// 返回 1 成功；0 会话不存在；-1 提交的 refresh token 不是当前有效的（重用）
+var rotateScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], 'refresh')
+if not current then
+	return 0
+end
+if current ~= ARGV[1] then
+	return -1
+end
+redis.call('HSET', KEYS[1], 'refresh', ARGV[2], 'lastSeen', ARGV[4], 'expiresAt', ARGV[5])
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('SET', KEYS[2], ARGV[6], 'EX', ARGV[3])
+return 1
+`)
+
+// Refresh 使用 refresh token 续期会话，返回会话及新的 refresh token
+func Refresh(refreshToken string) (*Session, string, error) {
+	ctx := context.Background()
+	hash := hashRefreshToken(refreshToken)
+	sessionID, err := global.Redis.Get(ctx, refreshKey(hash)).Result()
+	if isNil(err) {
+		return nil, "", errors.New("refresh token 无效或已过期")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var session Session
+	if err = global.Redis.HGetAll(ctx, sessionKey(sessionID)).Scan(&session); err != nil {
+		return nil, "", err
+	}
+	if session.ID == "" {
+		return nil, "", errors.New("会话已失效")
+	}
+
+	now := time.Now()
+	expiration := refreshExpiration()
+	newToken, newHash := newRefreshToken()
+	result, err := rotateScript.Run(ctx, global.Redis,
+		[]string{sessionKey(sessionID), refreshKey(newHash)},
+		hash, newHash, int64(expiration.Seconds()), now.Unix(), now.Add(expiration).Unix(), sessionID,
+	).Int()
+	if err != nil {
+		return nil, "", err
+	}
+	switch result {
+	case 0:
+		return nil, "", errors.New("会话已失效")
+	case -1:
+		logrus.Warnf("检测到 refresh token 重用，吊销用户 %d 的会话 %s", session.UserID, sessionID)
+		Revoke(session.UserID, sessionID)
+		return nil, "", errors.New("refresh token 已失效，请重新登录")
+	}
+
+	global.Redis.Expire(ctx, userSessionKey(session.UserID), expiration)
+	session.ExpiresAt = now.Add(expiration).Unix()
+	session.Refresh = newHash
+	return &session, newToken, nil
+}
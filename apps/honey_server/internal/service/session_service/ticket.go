@@ -0,0 +1,53 @@
+package session_service
+
+// File: service/session_service/ticket.go
+// Description: 一次性访问票据。浏览器的 EventSource 无法设置请求头，订阅事件流前先用 token 换取短期票据，
+// 再通过 query 参数携带票据，避免长期有效的 token 出现在地址中被代理、日志记录
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"honey_server/internal/global"
+	"honey_server/internal/utils/jwts"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ticketTTL       = 30 * time.Second // 票据有效期
+	ticketKeyPrefix = "access_ticket:"
+)
+
+// CreateTicket 为当前登录会话签发一次性访问票据
+func CreateTicket(claims *jwts.Claims) (string, error) {
+	b := make([]byte, 32)
+	rand.Read(b)
+	ticket := base64.RawURLEncoding.EncodeToString(b)
+	byteData, _ := json.Marshal(claims)
+	err := global.Redis.Set(context.Background(), ticketKeyPrefix+ticket, byteData, ticketTTL).Err()
+	return ticket, err
+}
+
+// UseTicket 使用访问票据，返回签发票据时的用户信息，每个票据只能使用一次
+func UseTicket(ticket string) (*jwts.Claims, error) {
+	ctx := context.Background()
+	pipe := global.Redis.TxPipeline()
+	get := pipe.Get(ctx, ticketKeyPrefix+ticket)
+	pipe.Del(ctx, ticketKeyPrefix+ticket)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	byteData, err := get.Bytes()
+	if err != nil {
+		return nil, errors.New("访问票据无效或已过期")
+	}
+	var claims jwts.Claims
+	if err = json.Unmarshal(byteData, &claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
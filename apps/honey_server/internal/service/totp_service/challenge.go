@@ -0,0 +1,79 @@
+package totp_service
+
+// File: service/totp_service/challenge.go
+// Description: 两步登录凭证与绑定中的临时密钥。
+// 密码校验通过后签发一次性的登录凭证，凭证与验证码一起提交才完成登录；
+// 绑定两步验证时生成的密钥先临时保存，验证码校验通过后才写入用户
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"strconv"
+	"time"
+)
+
+const (
+	challengeTTL           = 5 * time.Minute  // 登录凭证有效期
+	challengeMaxFail       = 5                // 登录凭证允许的验证码错误次数
+	pendingSecretTTL       = 10 * time.Minute // 绑定中的临时密钥有效期
+	challengeKeyPrefix     = "login_2fa:"
+	challengeFailPrefix    = "login_2fa_fail:"
+	pendingSecretKeyFormat = "totp_enroll:%d"
+)
+
+// CreateChallenge 密码校验通过后签发两步登录凭证
+func CreateChallenge(userID uint) (string, error) {
+	b := make([]byte, 32)
+	rand.Read(b)
+	challenge := base64.RawURLEncoding.EncodeToString(b)
+	err := global.Redis.Set(context.Background(), challengeKeyPrefix+challenge, userID, challengeTTL).Err()
+	return challenge, err
+}
+
+// GetChallenge 查询登录凭证对应的用户ID
+func GetChallenge(challenge string) (uint, error) {
+	value, err := global.Redis.Get(context.Background(), challengeKeyPrefix+challenge).Result()
+	if err != nil {
+		return 0, errors.New("登录凭证无效或已过期，请重新登录")
+	}
+	userID, _ := strconv.ParseUint(value, 10, 64)
+	return uint(userID), nil
+}
+
+// FailChallenge 记录验证码错误，超过次数后凭证作废
+func FailChallenge(challenge string) {
+	ctx := context.Background()
+	count, _ := global.Redis.Incr(ctx, challengeFailPrefix+challenge).Result()
+	global.Redis.Expire(ctx, challengeFailPrefix+challenge, challengeTTL)
+	if count >= challengeMaxFail {
+		RemoveChallenge(challenge)
+	}
+}
+
+// RemoveChallenge 登录完成后作废凭证
+func RemoveChallenge(challenge string) {
+	global.Redis.Del(context.Background(), challengeKeyPrefix+challenge, challengeFailPrefix+challenge)
+}
+
+// SetPendingSecret 保存绑定中的临时密钥
+func SetPendingSecret(userID uint, secret string) error {
+	return global.Redis.Set(context.Background(), fmt.Sprintf(pendingSecretKeyFormat, userID), secret, pendingSecretTTL).Err()
+}
+
+// GetPendingSecret 查询绑定中的临时密钥
+func GetPendingSecret(userID uint) (string, error) {
+	secret, err := global.Redis.Get(context.Background(), fmt.Sprintf(pendingSecretKeyFormat, userID)).Result()
+	if err != nil {
+		return "", errors.New("请先获取两步验证密钥")
+	}
+	return secret, nil
+}
+
+// RemovePendingSecret 绑定完成后删除临时密钥
+func RemovePendingSecret(userID uint) {
+	global.Redis.Del(context.Background(), fmt.Sprintf(pendingSecretKeyFormat, userID))
+}
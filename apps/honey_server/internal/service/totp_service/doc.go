@@ -0,0 +1,2 @@
+// Package totp_service 两步验证服务，提供 TOTP 密钥生成与校验、恢复码、两步登录凭证及绑定中的临时密钥
+package totp_service
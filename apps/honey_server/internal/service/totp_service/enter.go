@@ -0,0 +1,166 @@
+package totp_service
+
+// File: service/totp_service/enter.go
+// Description: TOTP 密钥生成与校验、恢复码的生成与使用。
+// 验证码校验允许前后各一个周期的时钟偏差，同一用户的同一验证码只能使用一次
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	recoveryCodeCount = 10               // 恢复码数量
+	codeUsedTTL       = 90 * time.Second // 已使用验证码的保留时长，覆盖允许的时钟偏差
+)
+
+// Key 新生成的 TOTP 密钥
+type Key struct {
+	Secret string `json:"secret"` // 密钥，用于手动输入
+	URI    string `json:"uri"`    // otpauth 链接
+	QRCode string `json:"qrCode"` // otpauth 链接的二维码，base64 编码的 PNG 图片
+}
+
+// issuer 身份验证器中显示的签发者名称
+func issuer() string {
+	if global.Config.Totp.Issuer == "" {
+		return "honey_server"
+	}
+	return global.Config.Totp.Issuer
+}
+
+// RequireTotp 判断用户是否必须启用两步验证
+func RequireTotp(user models.UserModel) bool {
+	return global.Config.Totp.RequireAdmin && user.RoleID == 1
+}
+
+// Generate 为用户生成新的 TOTP 密钥
+func Generate(username string) (*Key, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer(),
+		AccountName: username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	img, err := key.Image(200, 200)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return &Key{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// Validate 校验验证码，同一用户的同一验证码只能使用一次
+func Validate(userID uint, secret string, code string) bool {
+	ok, err := totp.ValidateCustom(code, secret, time.Now(), totp.ValidateOpts{
+		Period:    30,
+		Skew:      1,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	if err != nil || !ok {
+		return false
+	}
+	key := fmt.Sprintf("totp_used:%d:%s", userID, code)
+	first, err := global.Redis.SetNX(context.Background(), key, 1, codeUsedTTL).Result()
+	if err != nil {
+		logrus.Errorf("记录验证码使用失败 %s", err)
+		return true
+	}
+	return first
+}
+
+// NewRecoveryCodes 生成一组恢复码，返回恢复码明文及其摘要，明文只展示给用户一次
+func NewRecoveryCodes() (codes []string, hashList []string) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		rand.Read(b)
+		code := strings.ToLower(hex.EncodeToString(b))
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		hashList = append(hashList, hashRecoveryCode(code))
+	}
+	return
+}
+
+// hashRecoveryCode 计算恢复码摘要，忽略大小写和分隔符
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// UseRecoveryCode 使用恢复码，成功时从用户的恢复码中移除
+func UseRecoveryCode(user *models.UserModel, code string) bool {
+	hash := hashRecoveryCode(code)
+	for i, item := range user.RecoveryCodes {
+		if item != hash {
+			continue
+		}
+		list := append(append([]string{}, user.RecoveryCodes[:i]...), user.RecoveryCodes[i+1:]...)
+		// 以原恢复码列表为条件更新，避免并发使用同一恢复码
+		old, _ := json.Marshal(user.RecoveryCodes)
+		result := global.DB.Model(&models.UserModel{}).
+			Where("id = ? and recovery_codes = ?", user.ID, string(old)).
+			Select("recovery_codes").Updates(models.UserModel{RecoveryCodes: list})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return false
+		}
+		user.RecoveryCodes = list
+		return true
+	}
+	return false
+}
+
+// Verify 校验验证码或恢复码
+func Verify(user *models.UserModel, code string) bool {
+	code = strings.TrimSpace(code)
+	if len(code) == 6 && Validate(user.ID, user.TotpSecret, code) {
+		return true
+	}
+	return len(code) > 6 && UseRecoveryCode(user, code)
+}
+
+// Enable 为用户启用两步验证并生成新的恢复码，返回恢复码明文
+func Enable(user *models.UserModel, secret string) ([]string, error) {
+	codes, hashList := NewRecoveryCodes()
+	user.TotpEnable = true
+	user.TotpSecret = secret
+	user.RecoveryCodes = hashList
+	err := global.DB.Model(user).Select("totp_enable", "totp_secret", "recovery_codes").Updates(user).Error
+	if err != nil {
+		return nil, err
+	}
+	RemovePendingSecret(user.ID)
+	return codes, nil
+}
+
+// Disable 停用用户的两步验证，清除密钥与恢复码
+func Disable(userID uint) error {
+	return global.DB.Model(&models.UserModel{}).Where("id = ?", userID).
+		Select("totp_enable", "totp_secret", "recovery_codes").
+		Updates(models.UserModel{}).Error
+}
@@ -0,0 +1,2 @@
+// Package transcript_service 隧道会话录制服务，录制经隧道转发的攻击会话的双向数据，并支持读取、导出为pcap
+package transcript_service
@@ -0,0 +1,86 @@
+package transcript_service
+
+// File: service/transcript_service/format.go
+// Description: 录制文件格式。文件整体为gzip压缩流，解压后为文件头加连续的数据块，
+// 每个数据块为：方向(1字节) + 时间戳(8字节，Unix纳秒) + 数据长度(4字节) + 数据，整数均为大端序
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// fileMagic 录制文件头
+var fileMagic = []byte("HTR1")
+
+const frameHeaderSize = 13 // 数据块头长度
+
+const (
+	DirectionIn  byte = 1 // 攻击者 → 诱捕服务
+	DirectionOut byte = 2 // 诱捕服务 → 攻击者
+)
+
+// Frame 录制的数据块
+type Frame struct {
+	Direction byte      // 数据方向
+	Time      time.Time // 数据到达时间
+	Data      []byte    // 数据内容
+}
+
+// writeFrame 写入一个数据块
+func writeFrame(w io.Writer, frame Frame) error {
+	var header [frameHeaderSize]byte
+	header[0] = frame.Direction
+	binary.BigEndian.PutUint64(header[1:9], uint64(frame.Time.UnixNano()))
+	binary.BigEndian.PutUint32(header[9:13], uint32(len(frame.Data)))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(frame.Data)
+	return err
+}
+
+// Read 读取录制文件中的全部数据块
+// 会话进行中或异常中断的录制文件可能不完整，此时返回已读取到的数据块
+func Read(path string) ([]Frame, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("录制文件格式错误 %s", err)
+	}
+	defer gz.Close()
+	reader := bufio.NewReader(gz)
+
+	magic := make([]byte, len(fileMagic))
+	if _, err = io.ReadFull(reader, magic); err != nil || string(magic) != string(fileMagic) {
+		return nil, errors.New("录制文件格式错误")
+	}
+
+	var frameList []Frame
+	var header [frameHeaderSize]byte
+	for {
+		if _, err = io.ReadFull(reader, header[:]); err != nil {
+			break
+		}
+		frame := Frame{
+			Direction: header[0],
+			Time:      time.Unix(0, int64(binary.BigEndian.Uint64(header[1:9]))),
+			Data:      make([]byte, binary.BigEndian.Uint32(header[9:13])),
+		}
+		if _, err = io.ReadFull(reader, frame.Data); err != nil {
+			break
+		}
+		frameList = append(frameList, frame)
+	}
+	return frameList, nil
+}
@@ -0,0 +1,161 @@
+package transcript_service
+
+// File: service/transcript_service/live.go
+// Description: 会话实时查看，录制器将会话开始、双向数据及结束事件推送给匹配的查看者，
+// 查看者接收过慢时丢弃数据并记录丢弃数量，不会阻塞隧道转发
+
+import (
+	"errors"
+	"honey_server/internal/models"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const viewerBufferSize = 256 // 查看者消息缓冲数量
+
+// 实时消息类型
+const (
+	LiveStart = "start" // 会话开始
+	LiveData  = "data"  // 会话数据
+	LiveEnd   = "end"   // 会话结束
+)
+
+// LiveFilter 查看者订阅条件，按会话ID订阅单个会话，或按诱捕IP（及端口）订阅其上的全部会话
+type LiveFilter struct {
+	SessionID string // 会话ID
+	HoneyIpID uint   // 诱捕IP ID
+	Port      int    // 诱捕端口，为0时不限端口
+}
+
+// match 判断会话是否匹配订阅条件
+func (f LiveFilter) match(model *models.TranscriptModel) bool {
+	if f.SessionID != "" {
+		return f.SessionID == model.SessionID
+	}
+	return f.HoneyIpID == model.HoneyIpID && (f.Port == 0 || f.Port == model.DstPort)
+}
+
+// LiveMessage 推送给查看者的消息
+type LiveMessage struct {
+	Type       string                  `json:"type"`                 // 消息类型 start data end
+	SessionID  string                  `json:"sessionID"`            // 会话ID
+	Time       time.Time               `json:"time"`                 // 消息时间
+	Direction  string                  `json:"direction,omitempty"`  // 数据方向 in 攻击者发送 out 诱捕服务返回
+	Data       []byte                  `json:"data,omitempty"`       // 数据内容（base64编码）
+	Transcript *models.TranscriptModel `json:"transcript,omitempty"` // 会话信息（start、end消息携带）
+}
+
+// Viewer 会话查看者
+type Viewer struct {
+	filter  LiveFilter
+	C       chan LiveMessage // 推送的消息，按会话ID订阅时会话结束后关闭
+	dropped atomic.Int64     // 因接收过慢丢弃的消息数量
+}
+
+// Dropped 取出并清零丢弃的消息数量
+func (v *Viewer) Dropped() int64 {
+	return v.dropped.Swap(0)
+}
+
+// send 非阻塞推送消息，缓冲已满时丢弃
+func (v *Viewer) send(msg LiveMessage) {
+	select {
+	case v.C <- msg:
+	default:
+		v.dropped.Add(1)
+	}
+}
+
+var (
+	liveMutex     sync.RWMutex
+	viewerMap     = map[*Viewer]struct{}{} // 全部查看者
+	activeSessMap = map[string]*Recorder{} // 进行中的会话，键为会话ID
+)
+
+// Subscribe 订阅实时会话，订阅时已在进行中的匹配会话会先推送start消息
+func Subscribe(filter LiveFilter) (*Viewer, error) {
+	liveMutex.Lock()
+	defer liveMutex.Unlock()
+
+	if filter.SessionID != "" {
+		if _, ok := activeSessMap[filter.SessionID]; !ok {
+			return nil, errors.New("会话不存在或已结束")
+		}
+	}
+
+	v := &Viewer{filter: filter, C: make(chan LiveMessage, viewerBufferSize)}
+	for _, r := range activeSessMap {
+		if filter.match(&r.model) {
+			v.send(r.startMsg)
+		}
+	}
+	viewerMap[v] = struct{}{}
+	return v, nil
+}
+
+// Unsubscribe 取消订阅
+func Unsubscribe(v *Viewer) {
+	liveMutex.Lock()
+	delete(viewerMap, v)
+	liveMutex.Unlock()
+}
+
+// publish 将会话消息推送给匹配的查看者
+// 数据块来自隧道的复用缓冲区，仅在存在匹配的查看者时复制一次
+func publish(model *models.TranscriptModel, msg LiveMessage) {
+	liveMutex.RLock()
+	defer liveMutex.RUnlock()
+	copied := false
+	for v := range viewerMap {
+		if !v.filter.match(model) {
+			continue
+		}
+		if !copied && msg.Data != nil {
+			msg.Data = append([]byte(nil), msg.Data...)
+			copied = true
+		}
+		v.send(msg)
+	}
+}
+
+// register 登记进行中的会话并通知查看者
+// 锁顺序固定为先录制器后查看者列表，订阅时只读取开始时的会话快照，不获取录制器的锁
+func (r *Recorder) register() {
+	model := r.model
+	r.startMsg = LiveMessage{
+		Type:       LiveStart,
+		SessionID:  model.SessionID,
+		Time:       model.StartTime,
+		Transcript: &model,
+	}
+	liveMutex.Lock()
+	activeSessMap[r.model.SessionID] = r
+	liveMutex.Unlock()
+	publish(&r.model, r.startMsg)
+}
+
+// unregister 注销结束的会话并通知查看者，按会话ID订阅该会话的查看者随后被移除并关闭消息通道，
+// 即使结束消息因接收过慢被丢弃，查看者也能感知会话结束
+func (r *Recorder) unregister() {
+	liveMutex.Lock()
+	delete(activeSessMap, r.model.SessionID)
+	liveMutex.Unlock()
+	model := r.model
+	publish(&model, LiveMessage{
+		Type:       LiveEnd,
+		SessionID:  model.SessionID,
+		Time:       model.EndTime,
+		Transcript: &model,
+	})
+
+	// 移出查看者列表后不会再有推送，可以安全关闭通道
+	liveMutex.Lock()
+	for v := range viewerMap {
+		if v.filter.SessionID == model.SessionID {
+			delete(viewerMap, v)
+			close(v.C)
+		}
+	}
+	liveMutex.Unlock()
+}
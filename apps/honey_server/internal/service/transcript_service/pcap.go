@@ -0,0 +1,214 @@
+package transcript_service
+
+// File: service/transcript_service/pcap.go
+// Description: 将录制的会话重建为pcap格式，补全TCP三次握手与四次挥手，
+// 双向数据按录制顺序拆分为TCP报文段，可直接使用Wireshark等工具分析
+
+import (
+	"encoding/binary"
+	"honey_server/internal/models"
+	"io"
+	"net"
+	"time"
+)
+
+const (
+	pcapMaxSegment = 1460  // 单个TCP报文段的最大数据长度
+	pcapSnapLen    = 65535 // pcap最大抓包长度
+	pcapLinkEther  = 1     // 链路类型：以太网
+
+	tcpFlagFin = 0x01
+	tcpFlagSyn = 0x02
+	tcpFlagPsh = 0x08
+	tcpFlagAck = 0x10
+)
+
+// 重建报文使用的虚拟MAC地址
+var (
+	clientMAC = []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	serverMAC = []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x02}
+)
+
+// tcpPeer TCP连接的一端
+type tcpPeer struct {
+	mac  []byte
+	ip   net.IP
+	port uint16
+	seq  uint32 // 下一个发送序号
+}
+
+// pcapWriter pcap重建写入器
+type pcapWriter struct {
+	w      io.Writer
+	client *tcpPeer
+	server *tcpPeer
+	err    error
+}
+
+// WritePcap 将会话录制重建为pcap格式写入w
+func WritePcap(w io.Writer, model models.TranscriptModel, frameList []Frame) error {
+	p := &pcapWriter{
+		w:      w,
+		client: &tcpPeer{mac: clientMAC, ip: parseIP(model.SrcIP), port: uint16(model.SrcPort), seq: 1000},
+		server: &tcpPeer{mac: serverMAC, ip: parseIP(model.DstIP), port: uint16(model.DstPort), seq: 5000},
+	}
+	// 两端IP版本不一致时（如地址缺失）统一按IPv4处理
+	if (p.client.ip.To4() == nil) != (p.server.ip.To4() == nil) {
+		p.client.ip, p.server.ip = p.client.ip.To4(), p.server.ip.To4()
+		if p.client.ip == nil {
+			p.client.ip = net.IPv4zero.To4()
+		}
+		if p.server.ip == nil {
+			p.server.ip = net.IPv4zero.To4()
+		}
+	}
+
+	// pcap文件头（小端序，微秒精度）
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:], 0xa1b2c3d4)
+	binary.LittleEndian.PutUint16(header[4:], 2)
+	binary.LittleEndian.PutUint16(header[6:], 4)
+	binary.LittleEndian.PutUint32(header[16:], pcapSnapLen)
+	binary.LittleEndian.PutUint32(header[20:], pcapLinkEther)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	// 三次握手
+	start := model.StartTime
+	p.packet(start, p.client, p.server, tcpFlagSyn, nil)
+	p.client.seq++
+	p.packet(start, p.server, p.client, tcpFlagSyn|tcpFlagAck, nil)
+	p.server.seq++
+	p.packet(start, p.client, p.server, tcpFlagAck, nil)
+
+	end := start
+	for _, frame := range frameList {
+		src, dst := p.client, p.server
+		if frame.Direction == DirectionOut {
+			src, dst = p.server, p.client
+		}
+		for data := frame.Data; len(data) > 0; {
+			n := min(len(data), pcapMaxSegment)
+			p.packet(frame.Time, src, dst, tcpFlagPsh|tcpFlagAck, data[:n])
+			src.seq += uint32(n)
+			data = data[n:]
+		}
+		end = frame.Time
+	}
+
+	// 四次挥手（合并为三个报文）
+	if !model.EndTime.IsZero() {
+		end = model.EndTime
+	}
+	p.packet(end, p.client, p.server, tcpFlagFin|tcpFlagAck, nil)
+	p.client.seq++
+	p.packet(end, p.server, p.client, tcpFlagFin|tcpFlagAck, nil)
+	p.server.seq++
+	p.packet(end, p.client, p.server, tcpFlagAck, nil)
+	return p.err
+}
+
+// packet 构造并写入一个以太网帧
+func (p *pcapWriter) packet(t time.Time, src, dst *tcpPeer, flags byte, payload []byte) {
+	if p.err != nil {
+		return
+	}
+
+	// TCP头（20字节，无选项）
+	tcp := make([]byte, 20+len(payload))
+	binary.BigEndian.PutUint16(tcp[0:], src.port)
+	binary.BigEndian.PutUint16(tcp[2:], dst.port)
+	binary.BigEndian.PutUint32(tcp[4:], src.seq)
+	if flags&tcpFlagAck != 0 {
+		binary.BigEndian.PutUint32(tcp[8:], dst.seq)
+	}
+	tcp[12] = 5 << 4
+	tcp[13] = flags
+	binary.BigEndian.PutUint16(tcp[14:], 65535)
+	copy(tcp[20:], payload)
+
+	var ip []byte
+	var etherType uint16
+	if src.ip.To4() != nil {
+		etherType = 0x0800
+		ip = make([]byte, 20)
+		ip[0] = 0x45
+		binary.BigEndian.PutUint16(ip[2:], uint16(20+len(tcp)))
+		ip[6] = 0x40 // 不分片
+		ip[8] = 64
+		ip[9] = 6
+		copy(ip[12:], src.ip.To4())
+		copy(ip[16:], dst.ip.To4())
+		binary.BigEndian.PutUint16(ip[10:], checksum(ip, 0))
+
+		pseudo := pseudoSum(src.ip.To4(), dst.ip.To4(), len(tcp))
+		binary.BigEndian.PutUint16(tcp[16:], checksum(tcp, pseudo))
+	} else {
+		etherType = 0x86dd
+		ip = make([]byte, 40)
+		ip[0] = 0x60
+		binary.BigEndian.PutUint16(ip[4:], uint16(len(tcp)))
+		ip[6] = 6
+		ip[7] = 64
+		copy(ip[8:], src.ip.To16())
+		copy(ip[24:], dst.ip.To16())
+
+		pseudo := pseudoSum(src.ip.To16(), dst.ip.To16(), len(tcp))
+		binary.BigEndian.PutUint16(tcp[16:], checksum(tcp, pseudo))
+	}
+
+	frame := make([]byte, 0, 14+len(ip)+len(tcp))
+	frame = append(frame, dst.mac...)
+	frame = append(frame, src.mac...)
+	frame = binary.BigEndian.AppendUint16(frame, etherType)
+	frame = append(frame, ip...)
+	frame = append(frame, tcp...)
+
+	record := make([]byte, 16)
+	binary.LittleEndian.PutUint32(record[0:], uint32(t.Unix()))
+	binary.LittleEndian.PutUint32(record[4:], uint32(t.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(record[8:], uint32(len(frame)))
+	binary.LittleEndian.PutUint32(record[12:], uint32(len(frame)))
+	if _, p.err = p.w.Write(record); p.err != nil {
+		return
+	}
+	_, p.err = p.w.Write(frame)
+}
+
+// pseudoSum 计算TCP伪首部的校验和累加值
+func pseudoSum(src, dst net.IP, length int) uint32 {
+	var sum uint32
+	for _, b := range [][]byte{src, dst} {
+		for i := 0; i < len(b); i += 2 {
+			sum += uint32(b[i])<<8 | uint32(b[i+1])
+		}
+	}
+	return sum + 6 + uint32(length)
+}
+
+// checksum 计算互联网校验和
+func checksum(data []byte, sum uint32) uint16 {
+	for i := 0; i+1 < len(data); i += 2 {
+		sum += uint32(data[i])<<8 | uint32(data[i+1])
+	}
+	if len(data)%2 == 1 {
+		sum += uint32(data[len(data)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+// parseIP 解析IP地址，无法解析时使用0.0.0.0
+func parseIP(s string) net.IP {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return net.IPv4zero.To4()
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
@@ -0,0 +1,239 @@
+package transcript_service
+
+// File: service/transcript_service/recorder.go
+// Description: 会话录制器，会话开始时建立索引记录，双向数据按到达顺序写入压缩录制文件，
+// 超出录制上限后不再写入数据但继续统计流量，会话结束时回写统计信息
+
+import (
+	"compress/gzip"
+	"errors"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/service/event_service"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultDir     = "transcript" // 默认录制文件目录
+	defaultMaxSize = 1024         // 默认单个会话最大录制字节数，单位: KB
+)
+
+// errClosed 录制已结束
+var errClosed = errors.New("录制已结束")
+
+// Session 待录制的会话信息
+type Session struct {
+	NodeUid    string // 节点UID
+	SessionID  string // 会话ID
+	SrcAddr    string // 攻击者地址
+	LocalAddr  string // 诱捕端口监听地址
+	TargetAddr string // 转发的目标服务地址
+}
+
+// Recorder 会话录制器，可并发写入
+type Recorder struct {
+	mu      sync.Mutex
+	model   models.TranscriptModel
+	file    *os.File
+	gz      *gzip.Writer
+	size    int64 // 已录制的数据字节数
+	maxSize int64 // 最大录制字节数
+	err     error // 写入失败或录制结束后不再录制
+
+	startMsg LiveMessage // 会话开始消息，供实时查看使用
+}
+
+// NewRecorder 开始录制会话，缺少会话信息（旧版本节点）或创建失败时返回nil
+func NewRecorder(session Session) *Recorder {
+	if session.SessionID == "" || session.NodeUid == "" {
+		return nil
+	}
+	// 会话ID由节点上报且用于拼接文件路径，必须是合法的UUID
+	sessionID, err := uuid.Parse(session.SessionID)
+	if err != nil {
+		logrus.Errorf("会话录制失败，会话ID非法 %q", session.SessionID)
+		return nil
+	}
+	session.SessionID = sessionID.String()
+
+	var nodeModel models.NodeModel
+	if err := global.DB.Take(&nodeModel, "uid = ?", session.NodeUid).Error; err != nil {
+		logrus.Errorf("会话录制失败，节点不存在 %s", session.NodeUid)
+		return nil
+	}
+
+	cfg := global.Config.Transcript
+	dir := cfg.Dir
+	if dir == "" {
+		dir = defaultDir
+	}
+	maxSize := cfg.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+
+	now := time.Now()
+	model := models.TranscriptModel{
+		NodeID:     nodeModel.ID,
+		SessionID:  session.SessionID,
+		TargetAddr: session.TargetAddr,
+		StartTime:  now,
+		FilePath:   filepath.Join(dir, now.Format("20060102"), session.SessionID+".gz"),
+	}
+	model.SrcIP, model.SrcPort = splitAddr(session.SrcAddr)
+	model.DstIP, model.DstPort = splitAddr(session.LocalAddr)
+
+	// 关联诱捕IP及诱捕端口（与交互会话上报的关联方式一致）
+	var honeyPortModel models.HoneyPortModel
+	global.DB.Joins("join honey_ip_models on honey_ip_models.id = honey_port_models.honey_ip_id").
+		Where("honey_ip_models.node_id = ? and honey_ip_models.ip = ? and honey_ip_models.deleted_at is null", nodeModel.ID, model.DstIP).
+		Where("honey_port_models.port = ?", model.DstPort).
+		Take(&honeyPortModel)
+	model.HoneyIpID = honeyPortModel.HoneyIpID
+	model.HoneyPortID = honeyPortModel.ID
+
+	if err := os.MkdirAll(filepath.Dir(model.FilePath), 0755); err != nil {
+		logrus.Errorf("创建录制目录失败 %s", err)
+		return nil
+	}
+	file, err := os.OpenFile(model.FilePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		logrus.Errorf("创建录制文件失败 %s", err)
+		return nil
+	}
+	r := &Recorder{
+		model:   model,
+		file:    file,
+		gz:      gzip.NewWriter(file),
+		maxSize: int64(maxSize) * 1024,
+	}
+	if _, err = r.gz.Write(fileMagic); err != nil {
+		r.abort(err)
+		return nil
+	}
+	if err = global.DB.Create(&r.model).Error; err != nil {
+		r.abort(err)
+		return nil
+	}
+	r.register()
+	event_service.Publish(event_service.AttackSession, model.NodeID, event_service.AttackSessionData{
+		Kind:      "tunnel",
+		SessionID: model.SessionID,
+		HoneyIpID: model.HoneyIpID,
+		SrcIP:     model.SrcIP,
+		SrcPort:   model.SrcPort,
+		DstIP:     model.DstIP,
+		DstPort:   model.DstPort,
+	})
+	return r
+}
+
+// Write 录制一个方向的数据并推送给实时查看者，超出录制上限的部分不再录制，但仍推送给查看者
+func (r *Recorder) Write(direction byte, data []byte) {
+	if r == nil || len(data) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == errClosed {
+		return
+	}
+
+	now := time.Now()
+	msg := LiveMessage{
+		Type:      LiveData,
+		SessionID: r.model.SessionID,
+		Time:      now,
+		Direction: "in",
+		Data:      data,
+	}
+	if direction == DirectionIn {
+		r.model.InBytes += int64(len(data))
+	} else {
+		r.model.OutBytes += int64(len(data))
+		msg.Direction = "out"
+	}
+	publish(&r.model, msg)
+
+	if r.err != nil || r.model.Truncated {
+		return
+	}
+	if remain := r.maxSize - r.size; int64(len(data)) > remain {
+		data = data[:remain]
+		r.model.Truncated = true
+	}
+	if len(data) == 0 {
+		return
+	}
+
+	err := writeFrame(r.gz, Frame{
+		Direction: direction,
+		Time:      now,
+		Data:      data,
+	})
+	if err != nil {
+		logrus.Errorf("写入录制文件失败 %s %s", r.model.SessionID, err)
+		r.err = err
+		return
+	}
+	r.size += int64(len(data))
+	r.model.FrameCount++
+}
+
+// Close 结束录制并回写会话统计信息
+func (r *Recorder) Close() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err == errClosed {
+		return
+	}
+	r.err = errClosed
+	r.gz.Close()
+	if info, err := r.file.Stat(); err == nil {
+		r.model.FileSize = info.Size()
+	}
+	r.file.Close()
+
+	r.model.EndTime = time.Now()
+	global.DB.Model(&r.model).Updates(map[string]any{
+		"end_time":    r.model.EndTime,
+		"in_bytes":    r.model.InBytes,
+		"out_bytes":   r.model.OutBytes,
+		"frame_count": r.model.FrameCount,
+		"truncated":   r.model.Truncated,
+		"file_size":   r.model.FileSize,
+	})
+	r.unregister()
+	logrus.Infof("会话录制结束 %s %s:%d → %s:%d 上行%d字节 下行%d字节",
+		r.model.SessionID, r.model.SrcIP, r.model.SrcPort, r.model.DstIP, r.model.DstPort, r.model.InBytes, r.model.OutBytes)
+}
+
+// abort 创建录制失败时清理录制文件
+func (r *Recorder) abort(err error) {
+	logrus.Errorf("会话录制失败 %s %s", r.model.SessionID, err)
+	r.gz.Close()
+	r.file.Close()
+	os.Remove(r.model.FilePath)
+}
+
+// splitAddr 拆分地址中的IP与端口
+func splitAddr(addr string) (string, int) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, 0
+	}
+	p, _ := strconv.Atoi(port)
+	return host, p
+}
@@ -0,0 +1,140 @@
+package user_service
+
+// File: service/user_service/password.go
+// Description: 密码策略校验与密码修改。新密码需满足配置的复杂度要求，且不能与最近使用过的密码相同；
+// 管理员重置密码后用户下次登录时必须先修改密码
+
+import (
+	"errors"
+	"fmt"
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+	"honey_server/internal/utils/pwd"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const defaultMinLength = 8 // 未配置时的密码最小长度
+
+// CheckPasswordPolicy 校验密码是否满足复杂度要求
+func CheckPasswordPolicy(username string, password string) error {
+	cfg := global.Config.Password
+	minLength := cfg.MinLength
+	if minLength <= 0 {
+		minLength = defaultMinLength
+	}
+	if utf8.RuneCountInString(password) < minLength {
+		return fmt.Errorf("密码长度不能少于%d位", minLength)
+	}
+	if username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		return errors.New("密码不能包含用户名")
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	switch {
+	case cfg.RequireUpper && !upper:
+		return errors.New("密码必须包含大写字母")
+	case cfg.RequireLower && !lower:
+		return errors.New("密码必须包含小写字母")
+	case cfg.RequireDigit && !digit:
+		return errors.New("密码必须包含数字")
+	case cfg.RequireSymbol && !symbol:
+		return errors.New("密码必须包含特殊字符")
+	}
+	return nil
+}
+
+// usedPassword 判断密码是否为当前密码或最近使用过的密码
+func usedPassword(user *models.UserModel, password string) bool {
+	if global.Config.Password.History <= 0 {
+		return false
+	}
+	if pwd.CompareHashAndPassword(user.Password, password) {
+		return true
+	}
+	for _, hash := range user.PasswordHistory {
+		if pwd.CompareHashAndPassword(hash, password) {
+			return true
+		}
+	}
+	return false
+}
+
+// setPassword 校验并更新用户密码，原密码加入历史记录
+func (u *UserService) setPassword(user *models.UserModel, password string, mustChange bool) error {
+	if user.OidcSubject != "" {
+		return errors.New("单点登录用户的密码由身份提供方管理")
+	}
+	if err := CheckPasswordPolicy(user.Username, password); err != nil {
+		return err
+	}
+	if usedPassword(user, password) {
+		return fmt.Errorf("新密码不能与最近%d次使用过的密码相同", global.Config.Password.History)
+	}
+
+	hashPwd, err := pwd.GenerateFromPassword(password)
+	if err != nil {
+		return err
+	}
+	history := user.PasswordHistory
+	if n := global.Config.Password.History; n > 0 && user.Password != "" {
+		// 当前密码也参与比较，历史记录只需保留 n-1 个
+		history = append([]string{user.Password}, history...)
+		if len(history) > n-1 {
+			history = history[:n-1]
+		}
+	} else {
+		history = []string{}
+	}
+
+	user.Password = hashPwd
+	user.PasswordHistory = history
+	user.MustChangePassword = mustChange
+	return global.DB.Model(user).Select("password", "password_history", "must_change_password").Updates(user).Error
+}
+
+// ChangePassword 用户修改自己的密码，需要校验原密码
+func (u *UserService) ChangePassword(user *models.UserModel, oldPassword string, password string) error {
+	if !pwd.CompareHashAndPassword(user.Password, oldPassword) {
+		return errors.New("原密码错误")
+	}
+	if err := u.setPassword(user, password, false); err != nil {
+		return err
+	}
+	u.log.Infof("用户 %s 修改密码", user.Username)
+	return nil
+}
+
+// ForceChangePassword 登录时按要求修改密码，调用方已校验过原密码
+func (u *UserService) ForceChangePassword(user *models.UserModel, password string) error {
+	if pwd.CompareHashAndPassword(user.Password, password) {
+		return errors.New("新密码不能与当前密码相同")
+	}
+	if err := u.setPassword(user, password, false); err != nil {
+		return err
+	}
+	u.log.Infof("用户 %s 登录时修改密码", user.Username)
+	return nil
+}
+
+// ResetPassword 管理员重置用户密码，用户下次登录时必须修改密码
+func (u *UserService) ResetPassword(user *models.UserModel, password string) error {
+	if err := u.setPassword(user, password, true); err != nil {
+		return err
+	}
+	u.log.Infof("重置用户 %s 的密码", user.Username)
+	return nil
+}
@@ -0,0 +1,44 @@
+package user_service
+
+// File: service/user_service/password_challenge.go
+// Description: 登录时强制修改密码的凭证。密码校验通过但必须修改密码时签发一次性凭证，
+// 凭证与新密码一起提交，修改成功后才继续登录
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"honey_server/internal/global"
+	"strconv"
+	"time"
+)
+
+const (
+	passwordChallengeTTL       = 5 * time.Minute // 凭证有效期
+	passwordChallengeKeyPrefix = "login_pwd:"
+)
+
+// CreatePasswordChallenge 签发强制修改密码凭证
+func CreatePasswordChallenge(userID uint) (string, error) {
+	b := make([]byte, 32)
+	rand.Read(b)
+	challenge := base64.RawURLEncoding.EncodeToString(b)
+	err := global.Redis.Set(context.Background(), passwordChallengeKeyPrefix+challenge, userID, passwordChallengeTTL).Err()
+	return challenge, err
+}
+
+// GetPasswordChallenge 查询凭证对应的用户ID
+func GetPasswordChallenge(challenge string) (uint, error) {
+	value, err := global.Redis.Get(context.Background(), passwordChallengeKeyPrefix+challenge).Result()
+	if err != nil {
+		return 0, errors.New("登录凭证无效或已过期，请重新登录")
+	}
+	userID, _ := strconv.ParseUint(value, 10, 64)
+	return uint(userID), nil
+}
+
+// RemovePasswordChallenge 修改密码后作废凭证
+func RemovePasswordChallenge(challenge string) {
+	global.Redis.Del(context.Background(), passwordChallengeKeyPrefix+challenge)
+}
@@ -0,0 +1,26 @@
+package user_service
+
+// File: service/user_service/profile.go
+// Description: 实现用户个人资料的修改
+
+import (
+	"honey_server/internal/global"
+	"honey_server/internal/models"
+)
+
+// ProfileUpdateRequest 个人资料修改请求结构体
+type ProfileUpdateRequest struct {
+	Nickname string `json:"nickname"` // 昵称
+	Email    string `json:"email"`    // 邮箱
+}
+
+// UpdateProfile 修改用户的个人资料
+func (u *UserService) UpdateProfile(user *models.UserModel, req ProfileUpdateRequest) error {
+	user.Nickname = req.Nickname
+	user.Email = req.Email
+	if err := global.DB.Model(user).Select("nickname", "email").Updates(user).Error; err != nil {
+		return err
+	}
+	u.log.Infof("用户 %s 修改个人资料", user.Username)
+	return nil
+}
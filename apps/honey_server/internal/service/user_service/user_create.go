@@ -12,7 +12,7 @@ import (
 
 // UserCreateRequest 用户创建请求结构体
 type UserCreateRequest struct {
-	Role     int8   `json:"role"`     // 用户角色（1管理员 2普通用户）
+	RoleID   uint   `json:"roleID"`   // 角色ID
 	Username string `json:"username"` // 用户名
 	Password string `json:"password"` // 密码
 }
@@ -26,6 +26,18 @@ func (u *UserService) Create(req UserCreateRequest) (user models.UserModel, err
 		return
 	}
 
+	// 检查角色是否存在
+	var role models.RoleModel
+	if err = global.DB.Take(&role, req.RoleID).Error; err != nil {
+		err = fmt.Errorf("角色 %d 不存在", req.RoleID)
+		return
+	}
+
+	// 检查密码是否满足复杂度要求
+	if err = CheckPasswordPolicy(req.Username, req.Password); err != nil {
+		return
+	}
+
 	// 对用户密码进行加密
 	hashPwd, _ := pwd.GenerateFromPassword(req.Password)
 
@@ -33,7 +45,7 @@ func (u *UserService) Create(req UserCreateRequest) (user models.UserModel, err
 	user = models.UserModel{
 		Username: req.Username,
 		Password: hashPwd,
-		Role:     req.Role,
+		RoleID:   req.RoleID,
 	}
 
 	// 将用户信息写入数据库
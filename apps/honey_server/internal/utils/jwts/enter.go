@@ -1,71 +1,118 @@
 package jwts
 
 // File: utils/jwts/enter.go
-// Description: JWT 生成与解析
+// Description: JWT 生成与解析。access token 使用 HS256 签名，token 头的 kid 标识签名密钥，
+// 配置多个密钥时均可用于校验，便于密钥轮换
 
 import (
 	"errors"
 	"honey_server/internal/global"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 // 在 JWT 中携带的用户信息
 type ClaimsUserInfo struct {
 	UserID uint `json:"userID"` // 用户ID
-	Role   int8 `json:"role"`   // 用户角色
+	RoleID uint `json:"roleID"` // 角色ID
 }
 
 // Claims 定义自定义的声明体结构，包含：
 // - 自定义用户信息 (ClaimsUserInfo)
-// - 标准 JWT 声明字段 (jwt.StandardClaims)
+// - 服务端会话ID，刷新 token 时保持不变
+// - 标准 JWT 声明字段 (jwt.RegisteredClaims)，其中 ID 每次签发都不同
 type Claims struct {
 	ClaimsUserInfo
-	jwt.StandardClaims
+	SessionID string `json:"sid"` // 会话ID
+	jwt.RegisteredClaims
 }
 
-// 根据用户信息生成 JWT
-func GetToken(info ClaimsUserInfo) (string, error) {
+// defaultKeyID 未配置密钥列表时使用的密钥ID
+const defaultKeyID = "default"
+
+// signingKey 获取当前用于签发的密钥
+func signingKey() (kid string, secret []byte, err error) {
+	j := global.Config.Jwt
+	if len(j.Keys) == 0 {
+		return defaultKeyID, []byte(j.Secret), nil
+	}
+	for _, key := range j.Keys {
+		if key.ID == j.KeyID {
+			return key.ID, []byte(key.Secret), nil
+		}
+	}
+	return "", nil, errors.New("签发密钥不存在")
+}
+
+// verifyKey 根据 token 头的 kid 获取校验密钥
+func verifyKey(token *jwt.Token) (any, error) {
+	j := global.Config.Jwt
+	kid, _ := token.Header["kid"].(string)
+	if len(j.Keys) == 0 {
+		// 兼容未携带 kid 的旧 token
+		if kid == "" || kid == defaultKeyID {
+			return []byte(j.Secret), nil
+		}
+		return nil, errors.New("unknown kid")
+	}
+	for _, key := range j.Keys {
+		if key.ID == kid {
+			return []byte(key.Secret), nil
+		}
+	}
+	return nil, errors.New("unknown kid")
+}
+
+// 根据用户信息生成 access token，同时返回 claims
+func GetToken(info ClaimsUserInfo, sessionID string) (string, *Claims, error) {
 	j := global.Config.Jwt // 从全局配置中读取 JWT 相关配置
+	kid, secret, err := signingKey()
+	if err != nil {
+		return "", nil, err
+	}
 
 	// 构造 claims
-	cla := Claims{
+	now := time.Now()
+	cla := &Claims{
 		ClaimsUserInfo: info,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Duration(j.Expires) * time.Second).Unix(), // 设置过期时间（单位秒）
-			Issuer:    j.Issuer,                                                      // 设置签发人
+		SessionID:      sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),                                                 // 设置 JWT ID
+			IssuedAt:  jwt.NewNumericDate(now),                                             // 设置签发时间
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(j.Expires) * time.Second)), // 设置过期时间（单位秒）
+			Issuer:    j.Issuer,                                                            // 设置签发人
 		},
 	}
 
-	// 使用 HS256 算法创建 token
+	// 使用 HS256 算法创建 token，并在头部写入密钥ID
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, cla)
+	token.Header["kid"] = kid
 
-	// 使用配置中的密钥进行签名，生成最终 token 字符串
-	return token.SignedString([]byte(j.Secret))
+	// 使用密钥进行签名，生成最终 token 字符串
+	tokenString, err := token.SignedString(secret)
+	return tokenString, cla, err
 }
 
 // 对传入的 token 字符串进行解析与验证
+// 限定签名算法为 HS256，并要求过期时间存在且签发人匹配
 func ParseToken(tokenString string) (*Claims, error) {
 	j := global.Config.Jwt // 获取全局 JWT 配置
 
-	// 使用指定的密钥解析 token，并绑定到自定义的 Claims 结构中
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.Secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, verifyKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(j.Issuer),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	// 类型断言并校验 token 是否有效
 	claims, ok := token.Claims.(*Claims)
-	if ok && token.Valid {
-		// 校验签发人是否匹配，防止伪造
-		if claims.Issuer != j.Issuer {
-			return nil, errors.New("invalid issuer")
-		}
-		return claims, nil
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
 	}
-
-	return nil, errors.New("invalid token")
+	return claims, nil
 }
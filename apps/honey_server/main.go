@@ -8,7 +8,9 @@ import (
 	"honey_server/internal/flags"
 	"honey_server/internal/global"
 	"honey_server/internal/routers"
+	"honey_server/internal/service/alert_service"
 	"honey_server/internal/service/grpc_service"
+	"honey_server/internal/service/honey_ip_service"
 	"honey_server/internal/service/mq_service"
 )
 
@@ -19,9 +21,10 @@ func main() {
 	global.Log = core.GetLogger()        // 初始化日志系统
 	global.DB = core.GetDB()             // 初始化数据库连接
 	global.Redis = core.GetRedisClient() // 初始化Redis连接
-	global.Queue = core.InitMQ()         // 初始化rabbitMQ
-	mq_service.RegisterExChange()        // 注册交换机
 	flags.Run()                          // 解析命令行参数
+	mq_service.Run()                     // 初始化消息总线并启动发件箱投递器
 	go grpc_service.Run()                // 启动gRPC服务
+	go honey_ip_service.RunSweeper()     // 启动诱捕IP过渡状态清扫
+	go alert_service.Run()               // 启动告警引擎
 	routers.Run()                        // 启动路由服务
 }
@@ -0,0 +1,168 @@
+package main
+
+// 本地模拟的 OIDC 身份提供方，用于联调单点登录，不做用户认证，授权请求直接以指定用户身份通过。
+// 运行：go run testdata/5.oidc_mock_provider.go -user alice -groups honey-admins
+// settings.yaml 中 oidc.issuer 配置为 http://127.0.0.1:9000，clientID 与 -client 一致
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+var (
+	addr     = flag.String("addr", "127.0.0.1:9000", "监听地址")
+	clientID = flag.String("client", "honey_server", "客户端ID")
+	username = flag.String("user", "alice", "登录的用户名")
+	groups   = flag.String("groups", "honey-admins", "用户所属的组，逗号分隔")
+)
+
+// authCode 授权码对应的授权请求
+type authCode struct {
+	Nonce       string
+	Challenge   string
+	RedirectURI string
+	ExpiresAt   time.Time
+}
+
+var (
+	codeMap = map[string]authCode{}
+	mutex   sync.Mutex
+)
+
+func randString() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func writeJson(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func main() {
+	flag.Parse()
+	issuer := "http://" + *addr
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("生成签名密钥失败: %v", err)
+	}
+	jwk := jose.JSONWebKey{Key: &key.PublicKey, KeyID: "mock", Algorithm: string(jose.RS256), Use: "sig"}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "mock"))
+	if err != nil {
+		log.Fatalf("创建签名器失败: %v", err)
+	}
+
+	// 发现端点
+	http.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusOK, map[string]any{
+			"issuer":                                issuer,
+			"authorization_endpoint":                issuer + "/authorize",
+			"token_endpoint":                        issuer + "/token",
+			"jwks_uri":                              issuer + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+			"code_challenge_methods_supported":      []string{"S256"},
+		})
+	})
+
+	// 公钥
+	http.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusOK, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk}})
+	})
+
+	// 授权端点，直接签发授权码并跳转回客户端
+	http.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("client_id") != *clientID || query.Get("response_type") != "code" {
+			http.Error(w, "invalid_request", http.StatusBadRequest)
+			return
+		}
+		if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+			http.Error(w, "PKCE required", http.StatusBadRequest)
+			return
+		}
+		code := randString()
+		mutex.Lock()
+		codeMap[code] = authCode{
+			Nonce:       query.Get("nonce"),
+			Challenge:   query.Get("code_challenge"),
+			RedirectURI: query.Get("redirect_uri"),
+			ExpiresAt:   time.Now().Add(time.Minute),
+		}
+		mutex.Unlock()
+
+		redirect, err := url.Parse(query.Get("redirect_uri"))
+		if err != nil {
+			http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+			return
+		}
+		values := redirect.Query()
+		values.Set("code", code)
+		values.Set("state", query.Get("state"))
+		redirect.RawQuery = values.Encode()
+		log.Printf("用户 %s 授权通过，跳转 %s", *username, redirect)
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+
+	// token 端点，校验授权码与 code_verifier 后签发 id_token
+	http.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		mutex.Lock()
+		ac, ok := codeMap[r.PostForm.Get("code")]
+		delete(codeMap, r.PostForm.Get("code"))
+		mutex.Unlock()
+		if !ok || time.Now().After(ac.ExpiresAt) || ac.RedirectURI != r.PostForm.Get("redirect_uri") {
+			writeJson(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != ac.Challenge {
+			writeJson(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+			return
+		}
+
+		now := time.Now()
+		idToken, err := jwt.Signed(signer).Claims(jwt.Claims{
+			Issuer:   issuer,
+			Subject:  "mock|" + *username,
+			Audience: jwt.Audience{*clientID},
+			IssuedAt: jwt.NewNumericDate(now),
+			Expiry:   jwt.NewNumericDate(now.Add(5 * time.Minute)),
+		}).Claims(map[string]any{
+			"nonce":              ac.Nonce,
+			"preferred_username": *username,
+			"groups":             strings.Split(*groups, ","),
+		}).Serialize()
+		if err != nil {
+			writeJson(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+			return
+		}
+		writeJson(w, http.StatusOK, map[string]any{
+			"access_token": randString(),
+			"token_type":   "Bearer",
+			"expires_in":   300,
+			"id_token":     idToken,
+		})
+	})
+
+	log.Printf("模拟身份提供方运行在 %s，用户 %s 组 %s", issuer, *username, *groups)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
@@ -0,0 +1,70 @@
+package main
+
+// 本地模拟的告警 Webhook 接收端，用于联调 Webhook 通知渠道，校验 X-Honey-Signature 签名并打印收到的告警。
+// 运行：go run testdata/6.alert_webhook_receiver.go -secret mysecret
+// 通知渠道配置为 {"url": "http://127.0.0.1:9001/webhook", "secret": "mysecret"}，secret 为空时不校验签名
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+var (
+	addr   = flag.String("addr", "127.0.0.1:9001", "监听地址")
+	secret = flag.String("secret", "", "签名密钥，与通知渠道配置一致")
+)
+
+// webhookBody Webhook请求体
+type webhookBody struct {
+	Title string         `json:"title"`
+	Text  string         `json:"text"`
+	Alert map[string]any `json:"alert"`
+}
+
+func main() {
+	flag.Parse()
+
+	http.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "read body failed", http.StatusBadRequest)
+			return
+		}
+
+		// 校验签名：sha256=十六进制HMAC-SHA256(请求体)
+		if *secret != "" {
+			signature, ok := strings.CutPrefix(r.Header.Get("X-Honey-Signature"), "sha256=")
+			mac := hmac.New(sha256.New, []byte(*secret))
+			mac.Write(body)
+			expected := hex.EncodeToString(mac.Sum(nil))
+			if !ok || !hmac.Equal([]byte(signature), []byte(expected)) {
+				log.Printf("签名校验失败 %q", r.Header.Get("X-Honey-Signature"))
+				http.Error(w, "invalid signature", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		var msg webhookBody
+		if err = json.Unmarshal(body, &msg); err != nil {
+			log.Printf("请求体解析失败 %v", err)
+			http.Error(w, "invalid body", http.StatusBadRequest)
+			return
+		}
+		log.Printf("收到告警 %s\n%s", msg.Title, msg.Text)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("Webhook 接收端已启动 http://%s/webhook", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
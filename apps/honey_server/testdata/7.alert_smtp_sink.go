@@ -0,0 +1,104 @@
+package main
+
+// 本地模拟的 SMTP 收件端，用于联调邮件通知渠道，不投递邮件，只打印收到的信封与邮件内容。
+// 不支持 STARTTLS，接受任意 PLAIN 认证。
+// 运行：go run testdata/7.alert_smtp_sink.go
+// 通知渠道配置为 {"host": "127.0.0.1", "port": 2525, "from": "honey@example.com", "to": ["admin@example.com"]}
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+)
+
+var addr = flag.String("addr", "127.0.0.1:2525", "监听地址")
+
+func main() {
+	flag.Parse()
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("监听失败: %v", err)
+	}
+	log.Printf("SMTP 收件端已启动 %s", *addr)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Printf("接受连接失败: %v", err)
+			continue
+		}
+		go handle(conn)
+	}
+}
+
+// handle 处理单个SMTP会话
+func handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(time.Minute))
+
+	reader := bufio.NewReader(conn)
+	reply := func(format string, args ...any) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	var from string
+	var toList []string
+	reply("220 honey-smtp-sink ESMTP ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			reply("250-honey-smtp-sink")
+			reply("250 AUTH PLAIN")
+		case "HELO":
+			reply("250 honey-smtp-sink")
+		case "AUTH":
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			from = arg
+			toList = nil
+			reply("250 OK")
+		case "RCPT":
+			toList = append(toList, arg)
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err = reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" || line == ".\n" {
+					break
+				}
+				// 去除点填充
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			log.Printf("收到邮件 %s -> %s\n%s", from, strings.Join(toList, ", "), data.String())
+			reply("250 OK")
+		case "RSET":
+			from = ""
+			toList = nil
+			reply("250 OK")
+		case "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+// 本地模拟的 Syslog 接收端，用于联调 Syslog 通知渠道，同时监听 UDP 与 TCP（八位组计数分帧，RFC 6587）并打印收到的消息。
+// 运行：go run testdata/8.alert_syslog_listener.go
+// 通知渠道配置为 {"network": "udp", "addr": "127.0.0.1:5514"}，network 改为 tcp 即使用 TCP
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+)
+
+var addr = flag.String("addr", "127.0.0.1:5514", "监听地址（UDP与TCP共用）")
+
+func main() {
+	flag.Parse()
+
+	go listenUDP()
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("TCP 监听失败: %v", err)
+	}
+	log.Printf("Syslog 接收端已启动 udp/tcp %s", *addr)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Printf("接受连接失败: %v", err)
+			continue
+		}
+		go handleTCP(conn)
+	}
+}
+
+// listenUDP 接收UDP消息，每个数据报为一条消息
+func listenUDP() {
+	conn, err := net.ListenPacket("udp", *addr)
+	if err != nil {
+		log.Fatalf("UDP 监听失败: %v", err)
+	}
+	buf := make([]byte, 65535)
+	for {
+		n, remote, err := conn.ReadFrom(buf)
+		if err != nil {
+			log.Printf("UDP 读取失败: %v", err)
+			continue
+		}
+		log.Printf("[udp %s] %s", remote, buf[:n])
+	}
+}
+
+// handleTCP 按八位组计数分帧读取TCP消息：消息长度 + 空格 + 消息内容
+func handleTCP(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil || n <= 0 || n > 65535 {
+			log.Printf("[tcp %s] 无效的消息长度 %q", conn.RemoteAddr(), length)
+			return
+		}
+		msg := make([]byte, n)
+		if _, err = io.ReadFull(reader, msg); err != nil {
+			return
+		}
+		log.Printf("[tcp %s] %s", conn.RemoteAddr(), msg)
+	}
+}
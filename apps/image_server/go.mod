@@ -3,7 +3,6 @@ module image_server
 go 1.25.4
 
 require (
-	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/docker/docker v28.5.2+incompatible
 	github.com/gin-gonic/gin v1.11.0
 	github.com/go-playground/locales v0.14.1
@@ -27,7 +27,7 @@ type UpdateRequest struct {
 // 3. 校验新名称是否与其他模板重复
 // 4. 校验端口列表中端口是否重复
 // 5. 校验关联的虚拟服务是否存在
-// 6. 执行更新并返回结果
+// 6. 执行更新并返回结果（存在使用该模板的诱捕IP时提示同步）
 func (HostTemplateApi) UpdateView(c *gin.Context) {
 	// 绑定并验证请求参数（从上下文获取UpdateRequest结构体）
 	cr := middleware.GetBind[UpdateRequest](c)
@@ -93,6 +93,16 @@ func (HostTemplateApi) UpdateView(c *gin.Context) {
 		return
 	}
 
+	// 统计使用该模板的诱捕IP，提示通过诱捕端口的模板同步接口使修改生效
+	var honeyIPCount int64
+	global.DB.Table("honey_ip_models").
+		Where("host_template_id = ? and deleted_at is null", model.ID).
+		Count(&honeyIPCount)
+	if honeyIPCount > 0 {
+		res.OkWithMsg(fmt.Sprintf("主机模板更新成功，%d个诱捕ip使用该模板，需同步后生效", honeyIPCount), c)
+		return
+	}
+
 	// 返回更新成功消息
 	res.OkWithMsg("主机模板更新成功", c)
 }
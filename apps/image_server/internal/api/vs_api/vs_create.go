@@ -37,9 +37,9 @@ func getNextAvailableIP() (string, error) {
 	}
 	ip4 := ip.To4() // 转换为IPv4格式
 
-	// 查询数据库中当前已分配的最大IP记录
+	// 查询数据库中当前已分配的最大IP记录（内置模拟器服务不占用IP）
 	var service models.ServiceModel
-	err = global.DB.Order("ip DESC").First(&service).Error
+	err = global.DB.Where("type = ?", 1).Order("ip DESC").First(&service).Error
 	if err != nil {
 		// 若没有任何分配记录，从.2开始分配（10.2.0.2）
 		if err.Error() == "record not found" {
@@ -0,0 +1,123 @@
+package vs_api
+
+// File: api/vs_api/vs_emulator_create.go
+// Description: 内置模拟器服务 API，创建由节点直接模拟协议交互的低交互服务（含工控协议的设备标识与数据点配置），无需运行容器。
+
+import (
+	"fmt"
+	"image_server/internal/global"
+	"image_server/internal/middleware"
+	"image_server/internal/models"
+	"image_server/internal/utils/res"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// emulatorPortMap 节点支持的内置模拟器协议及其默认端口
+var emulatorPortMap = map[string]int{
+	"ssh":    22,
+	"telnet": 23,
+	"ftp":    21,
+	"http":   80,
+	"redis":  6379,
+	"mysql":  3306,
+	"modbus": 502,
+	"iec104": 2404,
+	"s7":     102,
+}
+
+// emulatorAreaMap 工控协议模拟器支持的数据区
+var emulatorAreaMap = map[string][]string{
+	"modbus": {"coil", "discrete", "holding", "input"},
+	"iec104": {"single", "measured"},
+	"s7":     {"db"},
+}
+
+// VsEmulatorCreateRequest 创建内置模拟器服务的请求参数结构
+type VsEmulatorCreateRequest struct {
+	Emulator string                `json:"emulator" binding:"required,oneof=ssh telnet ftp http redis mysql modbus iec104 s7"` // 模拟器协议，必填项
+	Title    string                `json:"title" binding:"max=64" label:"服务名称"`                                                // 服务名称，为空时使用协议名称
+	Config   models.EmulatorConfig `json:"config"`                                                                             // 设备配置（工控协议可选，为空时使用协议的默认设备）
+}
+
+// validateEmulatorConfig 校验工控协议模拟器的数据点：数据区需为该协议支持的数据区，地址及值需在协议范围内
+func validateEmulatorConfig(emulator string, config models.EmulatorConfig) error {
+	areaList, ok := emulatorAreaMap[emulator]
+	if !ok {
+		if len(config.PointList) > 0 {
+			return fmt.Errorf("%s模拟器不支持配置数据点", emulator)
+		}
+		return nil
+	}
+
+	maxAddress := 0xffff
+	if emulator == "iec104" {
+		maxAddress = 0xffffff // 信息对象地址为3字节
+	}
+	for _, point := range config.PointList {
+		if !slices.Contains(areaList, point.Area) {
+			return fmt.Errorf("%s模拟器不支持数据区%s", emulator, point.Area)
+		}
+		if point.Address < 0 || point.Address > maxAddress {
+			return fmt.Errorf("数据点地址%d超出范围", point.Address)
+		}
+		switch point.Area {
+		case "coil", "discrete", "single":
+			if point.Value != 0 && point.Value != 1 {
+				return fmt.Errorf("开关量数据点%d的值只能为0或1", point.Address)
+			}
+		case "holding", "input":
+			if point.Value < 0 || point.Value > 0xffff {
+				return fmt.Errorf("寄存器%d的值超出范围", point.Address)
+			}
+		case "db":
+			if point.DB < 1 || point.DB > 0xffff || point.Value < -0x8000 || point.Value > 0xffff {
+				return fmt.Errorf("数据块DB%d.%d的值超出范围", point.DB, point.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// VsEmulatorCreateView 创建内置模拟器服务的API入口函数
+// 内置模拟器服务不占用虚拟子网IP，诱捕端口关联后由节点直接在诱捕IP上模拟该协议
+func (VsApi) VsEmulatorCreateView(c *gin.Context) {
+	cr := middleware.GetBind[VsEmulatorCreateRequest](c)
+
+	if err := validateEmulatorConfig(cr.Emulator, cr.Config); err != nil {
+		res.FailWithMsg(err.Error(), c)
+		return
+	}
+
+	if cr.Title == "" {
+		cr.Title = fmt.Sprintf("%s模拟器", strings.ToUpper(cr.Emulator))
+	}
+
+	// 同名服务不允许重复创建
+	var service models.ServiceModel
+	err := global.DB.Take(&service, "title = ?", cr.Title).Error
+	if err == nil {
+		res.FailWithMsg("服务名称重复", c)
+		return
+	}
+
+	var model = models.ServiceModel{
+		Title:          cr.Title,
+		Type:           2,
+		Emulator:       cr.Emulator,
+		EmulatorConfig: cr.Config,
+		Port:           emulatorPortMap[cr.Emulator], // 协议默认端口，仅用于展示
+		Status:         1,                            // 模拟器无需启动容器，创建即可用
+	}
+	err = global.DB.Create(&model).Error
+	if err != nil {
+		logrus.Errorf("创建模拟器服务失败 %s", err)
+		res.FailWithMsg("创建模拟器服务失败", c)
+		return
+	}
+
+	res.Ok(model.ID, "创建模拟器服务成功", c)
+}
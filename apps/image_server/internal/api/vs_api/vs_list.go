@@ -18,6 +18,7 @@ type VsListRequest struct {
 	Port            int    `form:"port"`  // 筛选条件：服务端口
 	IP              string `form:"ip"`    // 筛选条件：服务IP地址
 	Title           string `form:"title"` // 筛选条件：服务标题（支持模糊查询）
+	Type            int8   `form:"type"`  // 筛选条件：服务类型（1 容器服务 2 内置模拟器）
 }
 
 // VsListView 获取虚拟服务列表的API入口函数
@@ -32,6 +33,7 @@ func (VsApi) VsListView(c *gin.Context) {
 		Title: cr.Title,
 		IP:    cr.IP,
 		Port:  cr.Port,
+		Type:  cr.Type,
 	},
 		common_service.QueryListRequest{
 			Likes:    []string{"title"}, // 标题字段支持模糊查询
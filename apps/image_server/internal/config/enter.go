@@ -11,7 +11,7 @@ type Config struct {
 	Logger    Logger   `yaml:"logger"`
 	Redis     Redis    `yaml:"redis"`
 	System    System   `yaml:"system"`
-	Jwt       Jwt      `yaml:"jwt"`
+	Auth      Auth     `yaml:"auth"`
 	WhiteList []string `yaml:"whiteList"`
 	VsNet     VsNet    `yaml:"vsNet"`
 }
@@ -55,15 +55,16 @@ type Redis struct {
 
 // 系统配置
 type System struct {
-	WebAddr string `yaml:"webAddr"`
-	Mode    string `yaml:"mode"`
+	WebAddr        string   `yaml:"webAddr"`
+	Mode           string   `yaml:"mode"`
+	TrustedProxies []string `yaml:"trustedProxies"` // 受信任的反向代理地址，仅信任来自这些地址的 X-Forwarded-For，为空时客户端IP取连接地址
 }
 
-// Jwt 配置
-type Jwt struct {
-	Expires int    `yaml:"expires"` // 过期时间，单位: 秒
-	Issuer  string `yaml:"issuer"`  // 签发者
-	Secret  string `yaml:"secret"`  // 密钥
+// 认证配置，用户凭据统一由 honey_server 校验
+type Auth struct {
+	IntrospectURL string `yaml:"introspectURL"` // honey_server 会话校验接口地址
+	CacheTTL      int    `yaml:"cacheTTL"`      // 校验结果缓存时间，单位: 秒，默认 10，会话吊销与权限变更最迟在缓存过期后生效
+	Timeout       int    `yaml:"timeout"`       // 请求会话校验接口的超时时间，单位: 秒，默认 5
 }
 
 // 虚拟服务网络配置
@@ -1,12 +1,12 @@
 package middleware
 
 // File: middleware/auth_middleware.go
-// Description: 提供认证相关的中间件功能。
+// Description: 提供认证相关的中间件功能。用户凭据由 honey_server 校验，会话吊销与权限变更同步生效。
 
 import (
 	"image_server/internal/global"
+	"image_server/internal/service/auth_service"
 	"image_server/internal/utils"
-	"image_server/internal/utils/jwts"
 	"image_server/internal/utils/res"
 
 	"github.com/gin-gonic/gin"
@@ -21,43 +21,25 @@ func AuthMiddleware(c *gin.Context) {
 		c.Next()
 		return
 	}
-	token := c.GetHeader("token") // 从请求头获取 token
-	claims, err := jwts.ParseToken(token)
+
+	// 转发请求携带的 token 或 API密钥，由 honey_server 校验
+	claims, err := auth_service.Introspect(auth_service.Credential{
+		Token:         c.GetHeader("token"),
+		Authorization: c.GetHeader("Authorization"),
+		ClientIP:      c.ClientIP(),
+	})
 	if err != nil {
-		// token 无效或解析失败
-		res.FailWithMsg("认证失败", c)
+		res.FailWithMsg(err.Error(), c)
 		c.Abort() // 阻止后续处理函数执行
 		return
 	}
 
-	// 解析成功，继续执行下一个中间件或处理函数
-	c.Set("claims", claims) // 将解析后的用户信息存入上下文，供后续使用
+	// 校验成功，继续执行下一个中间件或处理函数
+	c.Set("claims", claims) // 将用户信息存入上下文，供后续使用
 	c.Next()
 }
 
 // GetAuth 获取当前请求的用户信息
-func GetAuth(c *gin.Context) *jwts.Claims {
-	return c.MustGet("claims").(*jwts.Claims)
-}
-
-// AdminMiddleware 管理员权限中间件
-func AdminMiddleware(c *gin.Context) {
-	token := c.GetHeader("token") // 从请求头获取 token
-	claims, err := jwts.ParseToken(token)
-	if err != nil {
-		// token 无效
-		res.FailWithMsg("认证失败", c)
-		c.Abort()
-		return
-	}
-
-	// 校验角色是否为管理员
-	if claims.Role != 1 {
-		res.FailWithMsg("无权限访问", c)
-		c.Abort()
-		return
-	}
-
-	// 验证通过，继续执行
-	c.Next()
+func GetAuth(c *gin.Context) *auth_service.Claims {
+	return c.MustGet("claims").(*auth_service.Claims)
 }
@@ -15,17 +15,20 @@ import (
 // 服务模型
 type ServiceModel struct {
 	Model
-	Title         string     `json:"title"`                       // 服务名称 用镜像名称
-	Agreement     int8       `json:"agreement"`                   // 协议
-	ImageID       uint       `json:"imageID"`                     // 镜像ID
-	ImageModel    ImageModel `gorm:"foreignKey:ImageID" json:"-"` // 关联镜像模型
-	IP            string     `json:"ip"`                          // 容器IP
-	Port          int        `json:"port"`                        // 容器端口
-	Status        int8       `json:"status"`                      // 容器状态
-	ErrorMsg      string     `json:"errorMsg"`                    // 错误信息
-	HoneyIPCount  int        `json:"honeyIPCount"`                // 诱捕IP数量
-	ContainerID   string     `json:"containerID"`                 // 容器ID
-	ContainerName string     `json:"containerName"`               // 容器名称
+	Title          string         `json:"title"`                                           // 服务名称 用镜像名称
+	Type           int8           `gorm:"default:1" json:"type"`                           // 服务类型 1 容器服务 2 内置模拟器
+	Emulator       string         `gorm:"size:16" json:"emulator"`                         // 内置模拟器协议 ssh telnet ftp http redis mysql modbus iec104 s7
+	EmulatorConfig EmulatorConfig `gorm:"type:text;serializer:json" json:"emulatorConfig"` // 内置模拟器的设备配置
+	Agreement      int8           `json:"agreement"`                                       // 协议
+	ImageID        uint           `json:"imageID"`                                         // 镜像ID
+	ImageModel     ImageModel     `gorm:"foreignKey:ImageID" json:"-"`                     // 关联镜像模型
+	IP             string         `json:"ip"`                                              // 容器IP
+	Port           int            `json:"port"`                                            // 容器端口
+	Status         int8           `json:"status"`                                          // 容器状态
+	ErrorMsg       string         `json:"errorMsg"`                                        // 错误信息
+	HoneyIPCount   int            `json:"honeyIPCount"`                                    // 诱捕IP数量
+	ContainerID    string         `json:"containerID"`                                     // 容器ID
+	ContainerName  string         `json:"containerName"`                                   // 容器名称
 }
 
 // 获取服务状态
@@ -45,6 +48,11 @@ func (s *ServiceModel) BeforeDelete(tx *gorm.DB) error {
 		return errors.New("存在端口转发，不能删除虚拟服务")
 	}
 
+	// 内置模拟器运行在节点上，没有对应的容器
+	if s.Type == 2 {
+		return nil
+	}
+
 	command := fmt.Sprintf("docker rm -f %s", s.ContainerName)
 	err := cmd.Cmd(command)
 	if err != nil {
@@ -53,3 +61,21 @@ func (s *ServiceModel) BeforeDelete(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// 内置模拟器的设备配置（工控协议使用，为空时节点使用该协议的默认设备配置）
+// 设备标识在协议报文中以单字节表示长度，节点同样会截断超长配置
+type EmulatorConfig struct {
+	Vendor       string          `json:"vendor" binding:"max=64" label:"设备厂商"`       // 设备厂商
+	Model        string          `json:"model" binding:"max=64" label:"设备型号"`        // 设备型号
+	Firmware     string          `json:"firmware" binding:"max=64" label:"固件版本"`     // 固件版本
+	SerialNumber string          `json:"serialNumber" binding:"max=64" label:"序列号"`  // 序列号
+	PointList    []EmulatorPoint `json:"pointList" binding:"max=4096" label:"数据点列表"` // 数据点列表，最多4096个
+}
+
+// 模拟设备的数据点
+type EmulatorPoint struct {
+	Area    string  `json:"area"`    // 数据区 coil discrete holding input（Modbus） single measured（IEC104） db（S7）
+	DB      int     `json:"db"`      // S7数据块编号
+	Address int     `json:"address"` // 地址（IEC104为信息对象地址，S7为数据块内的字节偏移）
+	Value   float64 `json:"value"`   // 值（线圈、遥信为0或1，S7数据块为16位整数）
+}
@@ -0,0 +1,26 @@
+package routers
+
+// File: routers/audit.go
+// Description: 审计模型表，登记需要在审计日志中记录变更前后快照的路由及对应的模型
+
+import "image_server/internal/models"
+
+var auditModelMap = map[string]any{
+	// 镜像
+	"POST /image_server/mirror_cloud":       models.ImageModel{},
+	"PUT /image_server/mirror_cloud":        models.ImageModel{},
+	"DELETE /image_server/mirror_cloud/:id": models.ImageModel{},
+
+	// 虚拟服务
+	"POST /image_server/vs":          models.ServiceModel{},
+	"POST /image_server/vs/emulator": models.ServiceModel{},
+	"DELETE /image_server/vs":        models.ServiceModel{},
+
+	// 模板
+	"POST /image_server/host_template":     models.HostTemplateModel{},
+	"PUT /image_server/host_template":      models.HostTemplateModel{},
+	"DELETE /image_server/host_template":   models.HostTemplateModel{},
+	"POST /image_server/matrix_template":   models.MatrixTemplateModel{},
+	"PUT /image_server/matrix_template":    models.MatrixTemplateModel{},
+	"DELETE /image_server/matrix_template": models.MatrixTemplateModel{},
+}
@@ -16,9 +16,15 @@ func Run() {
 	system := global.Config.System
 	gin.SetMode(system.Mode)
 	r := gin.Default() // 创建默认路由
+	// 客户端IP用于审计日志及会话校验时转发，不配置受信任代理时不信任任何 X-Forwarded-For
+	if err := r.SetTrustedProxies(system.TrustedProxies); err != nil {
+		logrus.Fatalf("受信任代理配置错误 %s", err)
+	}
 	//r.Static("uploads", "uploads")                             // 静态文件服务
 	g := r.Group("image_server")                               // 统一路由前缀 /honey_server
 	g.Use(middleware.LogMiddleware, middleware.AuthMiddleware) // 系统必须登录才能访问，所有以 /honey_server 开头的路由默认都需要认证
+	g.Use(middleware.AuditMiddleware)                          // 记录新增、修改、删除操作的审计日志
+	g.Use(middleware.PermissionMiddleware)                     // 按路由权限表校验权限
 
 	MirrorCloudRouter(g)    // 镜像云相关路由
 	VsRouter(g)             // 虚拟服务相关路由
@@ -26,6 +32,10 @@ func Run() {
 	HostTemplateRouter(g)   // 主机模板相关路由
 	MatrixTemplateRouter(g) // 矩阵模板相关路由
 
+	middleware.RegisterRoutePermission(routePermissionMap) // 登记路由权限
+	middleware.CheckRoutePermission(r.Routes())            // 检查路由是否都登记了权限
+	middleware.RegisterAuditModel(auditModelMap)           // 登记审计快照模型
+
 	webAddr := system.WebAddr
 	logrus.Infof("web addr run %s", webAddr)
 
@@ -0,0 +1,39 @@
+package routers
+
+// File: routers/permission.go
+// Description: 路由权限表，登记每个路由所需的权限，权限码与 honey_server 的权限清单一致。
+// 新增路由时需要在此登记，未登记的路由一律拒绝访问
+
+var routePermissionMap = map[string]string{
+	// 镜像
+	"GET /image_server/mirror_cloud":         "image:read",
+	"GET /image_server/mirror_cloud/:id":     "image:read",
+	"GET /image_server/mirror_cloud/options": "image:read",
+	"POST /image_server/mirror_cloud/see":    "image:write",
+	"POST /image_server/mirror_cloud":        "image:write",
+	"PUT /image_server/mirror_cloud":         "image:write",
+	"DELETE /image_server/mirror_cloud/:id":  "image:write",
+
+	// 虚拟服务
+	"GET /image_server/vs":           "vs:read",
+	"GET /image_server/vs/options":   "vs:read",
+	"POST /image_server/vs":          "vs:write",
+	"POST /image_server/vs/emulator": "vs:write",
+	"DELETE /image_server/vs":        "vs:write",
+
+	// 虚拟网络
+	"GET /image_server/vs_net": "vs_net:read",
+	"PUT /image_server/vs_net": "vs_net:write",
+
+	// 模板
+	"GET /image_server/host_template":           "template:read",
+	"GET /image_server/host_template/options":   "template:read",
+	"POST /image_server/host_template":          "template:write",
+	"PUT /image_server/host_template":           "template:write",
+	"DELETE /image_server/host_template":        "template:write",
+	"GET /image_server/matrix_template":         "template:read",
+	"GET /image_server/matrix_template/options": "template:read",
+	"POST /image_server/matrix_template":        "template:write",
+	"PUT /image_server/matrix_template":         "template:write",
+	"DELETE /image_server/matrix_template":      "template:write",
+}
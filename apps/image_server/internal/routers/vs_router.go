@@ -18,6 +18,9 @@ func VsRouter(r *gin.RouterGroup) {
 	// 虚拟服务创建（POST），绑定 JSON 请求体
 	r.POST("vs", middleware.BindJsonMiddleware[vs_api.VsCreateRequest], app.VsCreateView)
 
+	// 内置模拟器服务创建（POST），绑定 JSON 请求体
+	r.POST("vs/emulator", middleware.BindJsonMiddleware[vs_api.VsEmulatorCreateRequest], app.VsEmulatorCreateView)
+
 	// 虚拟服务列表查询（GET），绑定 Query 参数
 	r.GET("vs", middleware.BindQueryMiddleware[vs_api.VsListRequest], app.VsListView)
 
@@ -0,0 +1,2 @@
+// Package auth_service 认证服务，通过 honey_server 的会话校验接口校验用户凭据并缓存结果
+package auth_service
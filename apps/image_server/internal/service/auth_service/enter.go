@@ -0,0 +1,171 @@
+package auth_service
+
+// File: service/auth_service/enter.go
+// Description: 用户凭据校验。image_server 没有用户与会话，请求携带的 token 或 API密钥原样转发给
+// honey_server 的会话校验接口，由其校验签名、会话吊销并返回有效权限；结果按凭据短暂缓存，减少请求次数
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image_server/internal/global"
+	"image_server/internal/utils"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	AllPermission  = "*"              // 全部权限
+	defaultTTL     = 10 * time.Second // 未配置时的校验结果缓存时间
+	defaultTimeout = 5 * time.Second  // 未配置时请求会话校验接口的超时时间
+	sweepInterval  = time.Minute      // 过期缓存的清理间隔
+)
+
+// Claims 校验通过的用户信息
+type Claims struct {
+	UserID         uint     `json:"userID"`         // 用户ID
+	Username       string   `json:"username"`       // 用户名
+	RoleID         uint     `json:"roleID"`         // 角色ID
+	SessionID      string   `json:"sessionID"`      // 会话ID，使用API密钥时为空
+	ApiKeyID       uint     `json:"apiKeyID"`       // API密钥ID，使用登录token时为0
+	PermissionList []string `json:"permissionList"` // 有效权限，* 表示全部权限
+	ExpiresAt      int64    `json:"expiresAt"`      // 凭据过期时间（Unix秒），0 表示不过期
+}
+
+// HasPermission 判断是否拥有指定权限
+func (c *Claims) HasPermission(code string) bool {
+	return utils.InList(c.PermissionList, AllPermission) || utils.InList(c.PermissionList, code)
+}
+
+// Credential 请求携带的凭据
+type Credential struct {
+	Token         string // token 请求头
+	Authorization string // Authorization 请求头，携带API密钥
+	ClientIP      string // 客户端IP，转发给 honey_server 用于记录API密钥的使用IP
+}
+
+// introspectResponse 会话校验接口的响应
+type introspectResponse struct {
+	Code int    `json:"code"`
+	Data Claims `json:"data"`
+	Msg  string `json:"msg"`
+}
+
+// cacheItem 校验结果缓存项
+type cacheItem struct {
+	claims *Claims
+	expire time.Time
+}
+
+var (
+	cache     sync.Map // 凭据摘要 -> cacheItem
+	lastSweep time.Time
+	sweepLock sync.Mutex
+)
+
+// cacheKey 凭据的缓存键，只保存摘要
+func cacheKey(cr Credential) string {
+	sum := sha256.Sum256([]byte(cr.Token + "\n" + cr.Authorization))
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheTTL 校验结果缓存时间
+func cacheTTL() time.Duration {
+	if global.Config.Auth.CacheTTL <= 0 {
+		return defaultTTL
+	}
+	return time.Duration(global.Config.Auth.CacheTTL) * time.Second
+}
+
+// sweep 按间隔清理过期的缓存
+func sweep(now time.Time) {
+	sweepLock.Lock()
+	defer sweepLock.Unlock()
+	if now.Sub(lastSweep) < sweepInterval {
+		return
+	}
+	lastSweep = now
+	cache.Range(func(key, value any) bool {
+		if now.After(value.(cacheItem).expire) {
+			cache.Delete(key)
+		}
+		return true
+	})
+}
+
+// Introspect 校验凭据，返回用户信息与有效权限
+func Introspect(cr Credential) (*Claims, error) {
+	if cr.Token == "" && cr.Authorization == "" {
+		return nil, errors.New("认证失败")
+	}
+	now := time.Now()
+	key := cacheKey(cr)
+	if v, ok := cache.Load(key); ok {
+		item := v.(cacheItem)
+		if now.Before(item.expire) {
+			return item.claims, nil
+		}
+	}
+	sweep(now)
+
+	claims, err := introspect(cr)
+	if err != nil {
+		return nil, err
+	}
+	expire := now.Add(cacheTTL())
+	if claims.ExpiresAt > 0 && time.Unix(claims.ExpiresAt, 0).Before(expire) {
+		expire = time.Unix(claims.ExpiresAt, 0)
+	}
+	cache.Store(key, cacheItem{claims: claims, expire: expire})
+	return claims, nil
+}
+
+// introspect 请求 honey_server 的会话校验接口
+func introspect(cr Credential) (*Claims, error) {
+	cfg := global.Config.Auth
+	timeout := defaultTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.IntrospectURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cr.Token != "" {
+		req.Header.Set("token", cr.Token)
+	}
+	if cr.Authorization != "" {
+		req.Header.Set("Authorization", cr.Authorization)
+	}
+	if cr.ClientIP != "" {
+		req.Header.Set("X-Forwarded-For", cr.ClientIP)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		logrus.Errorf("请求会话校验接口失败 %s", err)
+		return nil, errors.New("认证服务不可用")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logrus.Errorf("会话校验接口响应异常 %d", resp.StatusCode)
+		return nil, errors.New("认证服务不可用")
+	}
+
+	var body introspectResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("解析会话校验结果失败 %w", err)
+	}
+	if body.Code != 0 {
+		return nil, errors.New(body.Msg)
+	}
+	return &body.Data, nil
+}
@@ -0,0 +1,77 @@
+package log_service
+
+// File: service/log_service/audit_log.go
+// Description: 操作审计日志记录服务，记录新增、修改、删除类接口的调用，记录前对请求体和快照中的敏感字段脱敏
+
+import (
+	"encoding/json"
+	"image_server/internal/core"
+	"image_server/internal/global"
+	"image_server/internal/models"
+	"image_server/internal/utils"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redactMask = "******" // 脱敏后的值
+
+// sensitiveKeyList 字段名包含这些关键字时脱敏
+var sensitiveKeyList = []string{"password", "pwd", "secret", "token", "totp", "recovery"}
+
+// sensitiveExactKeyList 字段名等于这些关键字时脱敏（如两步验证码 code，包含匹配会误伤其他字段）
+var sensitiveExactKeyList = []string{"code"}
+
+// SaveAudit 异步保存操作审计日志，补充日志类型与归属地
+func SaveAudit(model models.LogModel) {
+	go func() {
+		model.Type = 2 // 2 代表操作审计日志
+		model.Addr = core.GetIpAddr(model.IP)
+		if err := global.DB.Create(&model).Error; err != nil {
+			logrus.Errorf("保存审计日志失败 %s", err)
+		}
+	}()
+}
+
+// Redact 对 JSON 数据中的敏感字段脱敏，非 JSON 数据原样返回
+func Redact(data []byte) string {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+	byteData, _ := json.Marshal(redact(v))
+	return string(byteData)
+}
+
+// redact 递归脱敏
+func redact(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, item := range value {
+			if isSensitiveKey(key) {
+				value[key] = redactMask
+				continue
+			}
+			value[key] = redact(item)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = redact(item)
+		}
+	}
+	return v
+}
+
+// isSensitiveKey 判断字段名是否为敏感字段
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if utils.InList(sensitiveExactKeyList, key) {
+		return true
+	}
+	for _, s := range sensitiveKeyList {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
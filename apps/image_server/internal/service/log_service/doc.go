@@ -0,0 +1,2 @@
+// Package log_service 日志记录服务，记录操作审计日志
+package log_service
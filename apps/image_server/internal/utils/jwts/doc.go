@@ -1,2 +0,0 @@
-// Package jwts 提供了用于生成和解析 JWT 的功能。
-package jwts
@@ -0,0 +1,19 @@
+package main
+
+// File:testdata/1.introspect.go
+// Description: 通过 honey_server 会话校验接口校验 token 的测试示例
+// 运行：go run testdata/1.introspect.go <honey_server 登录返回的 token>
+
+import (
+	"fmt"
+	"image_server/internal/core"
+	"image_server/internal/global"
+	"image_server/internal/service/auth_service"
+	"os"
+)
+
+func main() {
+	global.Config = core.ReadConfig()
+	claims, err := auth_service.Introspect(auth_service.Credential{Token: os.Args[len(os.Args)-1]})
+	fmt.Println(claims, err)
+}